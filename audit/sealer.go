@@ -0,0 +1,59 @@
+package audit
+
+import (
+	db "SimpleBank/db/sqlc"
+	"context"
+	"log"
+	"time"
+)
+
+// Sealer 定期将已经提交的审计日志链接到哈希链的尾部
+// 写入审计日志的事务只追加未封存的行，哈希链在事务之外由 Sealer 按批计算，避免所有涉及资金的事务争用同一个锁
+type Sealer struct {
+	store    db.Store
+	interval time.Duration
+}
+
+// NewSealer 创建一个每隔 interval 封存一次审计日志的 Sealer
+func NewSealer(store db.Store, interval time.Duration) *Sealer {
+	return &Sealer{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run 每隔 interval 封存一次所有未封存的审计日志，直到 ctx 被取消，interval 不大于 0 时不执行
+func (sealer *Sealer) Run(ctx context.Context) {
+	if sealer.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(sealer.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := sealer.Seal(ctx); err != nil {
+				log.Println("cannot seal audit log:", err)
+			}
+		}
+	}
+}
+
+// Seal 每次封存最多 pageSize 行，直到没有未封存的审计日志，返回封存的行数
+func (sealer *Sealer) Seal(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		sealed, err := sealer.store.SealAuditLogTx(ctx, pageSize)
+		total += sealed
+		if err != nil {
+			return total, err
+		}
+		if sealed < pageSize {
+			return total, nil
+		}
+	}
+}
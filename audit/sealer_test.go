@@ -0,0 +1,73 @@
+package audit
+
+import (
+	mockdb "SimpleBank/db/mock"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeal(t *testing.T) {
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, sealed int, err error)
+	}{
+		{
+			name: "NothingToSeal",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SealAuditLogTx(gomock.Any(), gomock.Eq(int32(pageSize))).Times(1).Return(0, nil)
+			},
+			checkResponse: func(t *testing.T, sealed int, err error) {
+				require.NoError(t, err)
+				require.Zero(t, sealed)
+			},
+		},
+		{
+			// 一批封存满时继续封存下一批，直到不足一批
+			name: "MultipleBatches",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().SealAuditLogTx(gomock.Any(), gomock.Eq(int32(pageSize))).Return(pageSize, nil),
+					store.EXPECT().SealAuditLogTx(gomock.Any(), gomock.Eq(int32(pageSize))).Return(3, nil),
+				)
+			},
+			checkResponse: func(t *testing.T, sealed int, err error) {
+				require.NoError(t, err)
+				require.Equal(t, pageSize+3, sealed)
+			},
+		},
+		{
+			// 出错时返回已经封存的行数
+			name: "Error",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().SealAuditLogTx(gomock.Any(), gomock.Any()).Return(pageSize, nil),
+					store.EXPECT().SealAuditLogTx(gomock.Any(), gomock.Any()).Return(0, errors.New("connection refused")),
+				)
+			},
+			checkResponse: func(t *testing.T, sealed int, err error) {
+				require.Error(t, err)
+				require.Equal(t, pageSize, sealed)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			sealed, err := NewSealer(store, 0).Seal(context.Background())
+			tc.checkResponse(t, sealed, err)
+		})
+	}
+}
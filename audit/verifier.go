@@ -0,0 +1,71 @@
+package audit
+
+import (
+	db "SimpleBank/db/sqlc"
+	"context"
+	"fmt"
+)
+
+// 每次从数据库中读取的审计日志行数
+const pageSize = 1000
+
+// Result 是一次校验审计日志的结果
+type Result struct {
+	// 已经校验的行数，包括第一处断裂的行
+	Checked int64
+	// 哈希链第一处断裂的行，哈希链完整时为 nil
+	Broken *db.AuditLog
+	// 断裂的原因
+	Reason string
+}
+
+// Verifier 按照哈希链的顺序校验已经封存的审计日志，找出第一处被篡改或者缺失的行
+// 还没有被 Sealer 封存的行不在哈希链中，不会被校验
+type Verifier struct {
+	store db.Store
+}
+
+// New 创建一个 Verifier
+func New(store db.Store) *Verifier {
+	return &Verifier{store: store}
+}
+
+// Verify 从第一行开始校验审计日志，每一行的 prev_hash 必须等于上一行的哈希值，并且哈希值必须与重新计算的结果一致
+func (verifier *Verifier) Verify(ctx context.Context) (Result, error) {
+	var result Result
+	var afterSeq int64
+	prevHash := ""
+
+	for {
+		entries, err := verifier.store.ListAuditLogs(ctx, db.ListAuditLogsParams{
+			AfterSeq: afterSeq,
+			Limit:    pageSize,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		for i := range entries {
+			entry := entries[i]
+			result.Checked++
+
+			if entry.PrevHash != prevHash {
+				result.Broken = &entry
+				result.Reason = fmt.Sprintf("prev_hash %q does not match hash %q of the previous entry", entry.PrevHash, prevHash)
+				return result, nil
+			}
+			if hash := db.AuditLogHash(entry); entry.Hash != hash {
+				result.Broken = &entry
+				result.Reason = fmt.Sprintf("hash %q does not match computed hash %q", entry.Hash, hash)
+				return result, nil
+			}
+
+			prevHash = entry.Hash
+			afterSeq = *entry.Seq
+		}
+
+		if len(entries) < pageSize {
+			return result, nil
+		}
+	}
+}
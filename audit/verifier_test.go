@@ -0,0 +1,140 @@
+package audit
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// chainAuditLogs 创建 n 行哈希链完整的审计日志
+func chainAuditLogs(n int) []db.AuditLog {
+	entries := make([]db.AuditLog, n)
+	prevHash := ""
+	for i := range entries {
+		seq := int64(i + 1)
+		entries[i] = db.AuditLog{
+			ID:        int64(i + 1),
+			Seq:       &seq,
+			Actor:     "owner",
+			Action:    db.AuditActionLedgerPost,
+			Target:    "journal_entry:1",
+			RequestID: "request",
+			Before:    "null",
+			After:     `{"amount":10}`,
+			PrevHash:  prevHash,
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC),
+		}
+		entries[i].Hash = db.AuditLogHash(entries[i])
+		prevHash = entries[i].Hash
+	}
+	return entries
+}
+
+func TestVerify(t *testing.T) {
+	testCases := []struct {
+		name          string
+		entries       func() []db.AuditLog
+		checkResponse func(t *testing.T, result Result)
+	}{
+		{
+			name:    "Intact",
+			entries: func() []db.AuditLog { return chainAuditLogs(3) },
+			checkResponse: func(t *testing.T, result Result) {
+				require.Equal(t, int64(3), result.Checked)
+				require.Nil(t, result.Broken)
+			},
+		},
+		{
+			name:    "Empty",
+			entries: func() []db.AuditLog { return nil },
+			checkResponse: func(t *testing.T, result Result) {
+				require.Zero(t, result.Checked)
+				require.Nil(t, result.Broken)
+			},
+		},
+		{
+			// 修改了内容但没有重新计算哈希值
+			name: "TamperedContent",
+			entries: func() []db.AuditLog {
+				entries := chainAuditLogs(3)
+				entries[1].After = `{"amount":1000}`
+				return entries
+			},
+			checkResponse: func(t *testing.T, result Result) {
+				require.Equal(t, int64(2), result.Checked)
+				require.NotNil(t, result.Broken)
+				require.Equal(t, int64(2), result.Broken.ID)
+			},
+		},
+		{
+			// 删除了一行，下一行的 prev_hash 与上一行的哈希值不一致
+			name: "MissingEntry",
+			entries: func() []db.AuditLog {
+				entries := chainAuditLogs(3)
+				return append(entries[:1], entries[2:]...)
+			},
+			checkResponse: func(t *testing.T, result Result) {
+				require.NotNil(t, result.Broken)
+				require.Equal(t, int64(3), result.Broken.ID)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				ListAuditLogs(gomock.Any(), gomock.Eq(db.ListAuditLogsParams{AfterSeq: 0, Limit: pageSize})).
+				Times(1).
+				Return(tc.entries(), nil)
+
+			result, err := New(store).Verify(context.Background())
+			require.NoError(t, err)
+			tc.checkResponse(t, result)
+		})
+	}
+}
+
+func TestVerifyPages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 第一页读满时继续从最后一行之后读取下一页
+	entries := chainAuditLogs(pageSize + 1)
+	store := mockdb.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().
+			ListAuditLogs(gomock.Any(), gomock.Eq(db.ListAuditLogsParams{AfterSeq: 0, Limit: pageSize})).
+			Return(entries[:pageSize], nil),
+		store.EXPECT().
+			ListAuditLogs(gomock.Any(), gomock.Eq(db.ListAuditLogsParams{AfterSeq: pageSize, Limit: pageSize})).
+			Return(entries[pageSize:], nil),
+	)
+
+	result, err := New(store).Verify(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(pageSize+1), result.Checked)
+	require.Nil(t, result.Broken)
+}
+
+func TestVerifyError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	_, err := New(store).Verify(context.Background())
+	require.Error(t, err)
+}
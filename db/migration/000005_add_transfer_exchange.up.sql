@@ -0,0 +1,15 @@
+ALTER TABLE "transfers" ADD COLUMN "to_amount" bigint;
+
+UPDATE "transfers" SET "to_amount" = "amount";
+
+ALTER TABLE "transfers" ALTER COLUMN "to_amount" SET NOT NULL;
+
+ALTER TABLE "transfers" ADD COLUMN "exchange_rate" numeric NOT NULL DEFAULT 1;
+
+ALTER TABLE "transfers" ADD COLUMN "quote_id" varchar NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN "transfers"."amount" IS 'must be positive, in the currency of from_account';
+
+COMMENT ON COLUMN "transfers"."to_amount" IS 'must be positive, in the currency of to_account';
+
+COMMENT ON COLUMN "transfers"."exchange_rate" IS 'units of to_account currency per unit of from_account currency';
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS "account_status_transition" ON "accounts";
+
+DROP FUNCTION IF EXISTS check_account_status_transition();
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "closed_account_zero_balance";
+
+ALTER TABLE IF EXISTS "accounts" DROP CONSTRAINT IF EXISTS "account_status_valid";
+
+ALTER TABLE IF EXISTS "accounts" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,27 @@
+ALTER TABLE "accounts" ADD COLUMN "status" varchar NOT NULL DEFAULT 'active';
+
+ALTER TABLE "accounts" ADD CONSTRAINT "account_status_valid" CHECK ("status" IN ('active', 'frozen', 'closed'));
+
+-- 已关闭的账户余额必须为 0
+ALTER TABLE "accounts" ADD CONSTRAINT "closed_account_zero_balance" CHECK ("status" <> 'closed' OR "balance" = 0);
+
+COMMENT ON COLUMN "accounts"."status" IS 'active, frozen or closed';
+
+-- 在数据库中强制执行账户状态机，避免绕过应用程序直接修改状态
+CREATE FUNCTION check_account_status_transition() RETURNS trigger AS $$
+BEGIN
+  IF NEW."status" <> OLD."status" AND NOT (
+    (OLD."status" = 'active' AND NEW."status" IN ('frozen', 'closed'))
+    OR (OLD."status" = 'frozen' AND NEW."status" = 'active')
+    OR (OLD."status" = 'closed' AND NEW."status" = 'active')
+  ) THEN
+    RAISE EXCEPTION 'invalid account status transition from % to %', OLD."status", NEW."status"
+      USING ERRCODE = 'check_violation';
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "account_status_transition"
+BEFORE UPDATE OF "status" ON "accounts"
+FOR EACH ROW EXECUTE FUNCTION check_account_status_transition();
@@ -0,0 +1,25 @@
+CREATE TABLE "tasks" (
+  "id" bigserial PRIMARY KEY,
+  "type" varchar NOT NULL,
+  "payload" jsonb NOT NULL DEFAULT '{}',
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" int NOT NULL DEFAULT 0,
+  "max_attempts" int NOT NULL,
+  "run_at" timestamptz NOT NULL DEFAULT (now()),
+  "last_error" varchar NOT NULL DEFAULT '',
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "tasks" ("run_at") WHERE "status" = 'pending';
+
+CREATE INDEX ON "tasks" ("type", "status");
+
+COMMENT ON COLUMN "tasks"."status" IS 'pending, succeeded, or dead once max_attempts is reached';
+
+COMMENT ON COLUMN "tasks"."run_at" IS 'the task is not processed before this time, used for retry backoff';
+
+ALTER TABLE "tasks" ADD CONSTRAINT "task_status_valid"
+  CHECK ("status" IN ('pending', 'succeeded', 'dead'));
+
+ALTER TABLE "tasks" ADD CONSTRAINT "task_max_attempts_positive" CHECK ("max_attempts" > 0);
@@ -0,0 +1,3 @@
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "user_role_valid";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,6 @@
+ALTER TABLE "users" ADD COLUMN "role" varchar NOT NULL DEFAULT 'depositor';
+
+ALTER TABLE "users" ADD CONSTRAINT "user_role_valid" CHECK ("role" IN ('depositor', 'banker'));
+
+-- 新注册的用户都是客户，银行职员由运维人员直接在数据库中指定
+COMMENT ON COLUMN "users"."role" IS 'depositor or banker';
@@ -0,0 +1,11 @@
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "reversal_id";
+
+DROP TABLE IF EXISTS "transfer_reversals";
+
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfer_reversed_amount_valid";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "reversed_amount";
+
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS "transfer_status_valid";
+
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,39 @@
+ALTER TABLE "transfers" ADD COLUMN "status" varchar NOT NULL DEFAULT 'completed';
+
+ALTER TABLE "transfers" ADD CONSTRAINT "transfer_status_valid" CHECK ("status" IN ('completed', 'partially_reversed', 'reversed'));
+
+ALTER TABLE "transfers" ADD COLUMN "reversed_amount" bigint NOT NULL DEFAULT 0;
+
+-- 退款的总金额不能超过原交易的金额，保证同一笔交易不会被重复冲正
+ALTER TABLE "transfers" ADD CONSTRAINT "transfer_reversed_amount_valid" CHECK ("reversed_amount" >= 0 AND "reversed_amount" <= "amount");
+
+COMMENT ON COLUMN "transfers"."status" IS 'completed, partially_reversed or reversed';
+
+COMMENT ON COLUMN "transfers"."reversed_amount" IS 'the total amount refunded so far, in the currency of from_account';
+
+CREATE TABLE "transfer_reversals" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "to_amount" bigint NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "reversed_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfer_reversals" ("transfer_id");
+
+COMMENT ON COLUMN "transfer_reversals"."amount" IS 'must be positive, refunded to from_account in its currency';
+
+COMMENT ON COLUMN "transfer_reversals"."to_amount" IS 'must be positive, taken back from to_account in its currency';
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+ALTER TABLE "transfer_reversals" ADD FOREIGN KEY ("reversed_by") REFERENCES "users" ("username");
+
+-- 冲正产生的补偿条目同时关联原交易和冲正记录，便于与原交易的条目区分
+ALTER TABLE "entries" ADD COLUMN "reversal_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("reversal_id") REFERENCES "transfer_reversals" ("id");
+
+COMMENT ON COLUMN "entries"."reversal_id" IS 'the reversal that created this compensating entry, if any';
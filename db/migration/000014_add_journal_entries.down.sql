@@ -0,0 +1,44 @@
+-- 系统账户一旦记过账，回滚就需要删除日记账的一半条目，客户账户的余额将无法与条目对账，因此拒绝回滚
+-- 只有系统账户从未被使用，并且不会违反恢复的唯一约束时才能回滚，否则需要先手动迁移数据
+DO $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM "entries"
+    JOIN "accounts" ON "accounts"."id" = "entries"."account_id"
+    WHERE "accounts"."owner" = 'simplebank_system'
+  ) OR EXISTS (
+    SELECT 1 FROM "transfers"
+    JOIN "accounts" ON "accounts"."id" IN ("transfers"."from_account_id", "transfers"."to_account_id")
+    WHERE "accounts"."owner" = 'simplebank_system'
+  ) THEN
+    RAISE EXCEPTION 'migration 000014 cannot be rolled back: system accounts already have postings';
+  END IF;
+
+  IF EXISTS (
+    SELECT 1 FROM "accounts"
+    WHERE "owner" <> 'simplebank_system'
+    GROUP BY "owner", "currency"
+    HAVING COUNT(*) > 1
+  ) THEN
+    RAISE EXCEPTION 'migration 000014 cannot be rolled back: an owner holds several accounts in the same currency';
+  END IF;
+END $$;
+
+DROP TRIGGER IF EXISTS "journal_entry_balanced" ON "entries";
+
+DROP FUNCTION IF EXISTS "check_journal_entry_balanced"();
+
+DROP TABLE IF EXISTS "system_accounts";
+
+-- 经过上面的检查，系统用户的账户都没有任何条目或者交易，可以安全删除
+DELETE FROM "accounts" WHERE "owner" = 'simplebank_system';
+
+DELETE FROM "users" WHERE "username" = 'simplebank_system';
+
+DROP INDEX IF EXISTS "owner_currency_key";
+
+ALTER TABLE "accounts" ADD CONSTRAINT "owner_currency_key" UNIQUE ("owner", "currency");
+
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "journal_entry_id";
+
+DROP TABLE IF EXISTS "journal_entries";
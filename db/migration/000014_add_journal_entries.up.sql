@@ -0,0 +1,86 @@
+-- 每一笔资金变动都记录为一条日记账分录，分录下的所有条目按货币分别求和都必须为 0
+CREATE TABLE "journal_entries" (
+  "id" bigserial PRIMARY KEY,
+  "kind" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "journal_entries" ADD CONSTRAINT "journal_kind_valid" CHECK ("kind" IN ('transfer', 'reversal', 'fee', 'interest'));
+
+COMMENT ON COLUMN "journal_entries"."kind" IS 'transfer, reversal, fee or interest';
+
+ALTER TABLE "entries" ADD COLUMN "journal_entry_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("journal_entry_id") REFERENCES "journal_entries" ("id");
+
+CREATE INDEX ON "entries" ("journal_entry_id");
+
+COMMENT ON COLUMN "entries"."journal_entry_id" IS 'the journal entry this posting line belongs to, null for entries created before the ledger';
+
+-- 在事务提交时检查分录是否平衡，同一个事务中可以依次插入分录的各个条目
+CREATE FUNCTION "check_journal_entry_balanced"() RETURNS trigger AS $$
+DECLARE
+  unbalanced_currency varchar;
+BEGIN
+  SELECT a.currency INTO unbalanced_currency
+  FROM entries e
+  JOIN accounts a ON a.id = e.account_id
+  WHERE e.journal_entry_id = NEW.journal_entry_id
+  GROUP BY a.currency
+  HAVING SUM(e.amount) <> 0
+  LIMIT 1;
+
+  IF unbalanced_currency IS NOT NULL THEN
+    RAISE EXCEPTION 'journal entry % is not balanced in %', NEW.journal_entry_id, unbalanced_currency
+      USING ERRCODE = 'check_violation';
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER "journal_entry_balanced"
+AFTER INSERT OR UPDATE ON "entries"
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW
+WHEN (NEW."journal_entry_id" IS NOT NULL)
+EXECUTE FUNCTION "check_journal_entry_balanced"();
+
+-- 系统用户持有银行自身的账户，密码为空，不能登录
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email")
+VALUES ('simplebank_system', '', 'SimpleBank System', 'system@simplebank.internal');
+
+-- 系统用户的每种货币可以有多个账户，普通用户的每种货币仍然只能有一个账户
+ALTER TABLE "accounts" DROP CONSTRAINT "owner_currency_key";
+
+CREATE UNIQUE INDEX "owner_currency_key" ON "accounts" ("owner", "currency") WHERE "owner" <> 'simplebank_system';
+
+CREATE TABLE "system_accounts" (
+  "name" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "account_id" bigint UNIQUE NOT NULL,
+  PRIMARY KEY ("name", "currency")
+);
+
+ALTER TABLE "system_accounts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+COMMENT ON COLUMN "system_accounts"."name" IS 'fx_position, fee_income or interest_expense';
+
+-- 系统账户的透支额度不受限制，余额为负表示银行的支出或者外汇头寸
+DO $$
+DECLARE
+  account_name varchar;
+  account_currency varchar;
+  new_account_id bigint;
+BEGIN
+  FOREACH account_name IN ARRAY ARRAY['fx_position', 'fee_income', 'interest_expense'] LOOP
+    FOREACH account_currency IN ARRAY ARRAY['USD', 'EUR', 'CAD'] LOOP
+      INSERT INTO accounts (owner, balance, currency, overdraft_limit)
+      VALUES ('simplebank_system', 0, account_currency, 9223372036854775807)
+      RETURNING id INTO new_account_id;
+
+      INSERT INTO system_accounts (name, currency, account_id)
+      VALUES (account_name, account_currency, new_account_id);
+    END LOOP;
+  END LOOP;
+END $$;
@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS "withdrawals";
+
+DROP TABLE IF EXISTS "deposits";
+
+DELETE FROM "entries" WHERE "journal_entry_id" IN (
+  SELECT "id" FROM "journal_entries" WHERE "kind" IN ('deposit', 'withdrawal')
+);
+
+DELETE FROM "journal_entries" WHERE "kind" IN ('deposit', 'withdrawal');
+
+ALTER TABLE "journal_entries" DROP CONSTRAINT "journal_kind_valid";
+
+ALTER TABLE "journal_entries" ADD CONSTRAINT "journal_kind_valid" CHECK ("kind" IN ('transfer', 'reversal', 'fee', 'interest'));
+
+COMMENT ON COLUMN "journal_entries"."kind" IS 'transfer, reversal, fee or interest';
+
+CREATE TEMPORARY TABLE "removed_system_accounts" AS
+SELECT "account_id" FROM "system_accounts" WHERE "name" IN ('cash', 'clearing');
+
+DELETE FROM "system_accounts" WHERE "account_id" IN (SELECT "account_id" FROM "removed_system_accounts");
+
+DELETE FROM "accounts" WHERE "id" IN (SELECT "account_id" FROM "removed_system_accounts");
+
+DROP TABLE "removed_system_accounts";
+
+COMMENT ON COLUMN "system_accounts"."name" IS 'fx_position, fee_income or interest_expense';
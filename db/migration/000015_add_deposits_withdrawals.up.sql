@@ -0,0 +1,76 @@
+ALTER TABLE "journal_entries" DROP CONSTRAINT "journal_kind_valid";
+
+ALTER TABLE "journal_entries" ADD CONSTRAINT "journal_kind_valid" CHECK ("kind" IN ('transfer', 'reversal', 'fee', 'interest', 'deposit', 'withdrawal'));
+
+COMMENT ON COLUMN "journal_entries"."kind" IS 'transfer, reversal, fee, interest, deposit or withdrawal';
+
+-- 现金账户记录进出银行的资金，清算账户暂存已经收到但是还没有入账的存款
+DO $$
+DECLARE
+  account_name varchar;
+  account_currency varchar;
+  new_account_id bigint;
+BEGIN
+  FOREACH account_name IN ARRAY ARRAY['cash', 'clearing'] LOOP
+    FOREACH account_currency IN ARRAY ARRAY['USD', 'EUR', 'CAD'] LOOP
+      INSERT INTO accounts (owner, balance, currency, overdraft_limit)
+      VALUES ('simplebank_system', 0, account_currency, 9223372036854775807)
+      RETURNING id INTO new_account_id;
+
+      INSERT INTO system_accounts (name, currency, account_id)
+      VALUES (account_name, account_currency, new_account_id);
+    END LOOP;
+  END LOOP;
+END $$;
+
+COMMENT ON COLUMN "system_accounts"."name" IS 'fx_position, fee_income, interest_expense, cash or clearing';
+
+CREATE TABLE "deposits" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "external_reference" varchar UNIQUE NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "resolved_at" timestamptz
+);
+
+ALTER TABLE "deposits" ADD CONSTRAINT "deposit_amount_positive" CHECK ("amount" > 0);
+
+ALTER TABLE "deposits" ADD CONSTRAINT "deposit_status_valid" CHECK ("status" IN ('pending', 'settled', 'failed'));
+
+CREATE INDEX ON "deposits" ("account_id");
+
+COMMENT ON COLUMN "deposits"."amount" IS 'must be positive, in the currency of the account';
+
+COMMENT ON COLUMN "deposits"."external_reference" IS 'the reference of the deposit in the external payment system';
+
+COMMENT ON COLUMN "deposits"."status" IS 'pending, settled or failed';
+
+COMMENT ON COLUMN "deposits"."resolved_at" IS 'when the deposit was settled or failed';
+
+ALTER TABLE "deposits" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "deposits" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
+
+CREATE TABLE "withdrawals" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "external_reference" varchar UNIQUE NOT NULL,
+  "created_by" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "withdrawals" ADD CONSTRAINT "withdrawal_amount_positive" CHECK ("amount" > 0);
+
+CREATE INDEX ON "withdrawals" ("account_id");
+
+COMMENT ON COLUMN "withdrawals"."amount" IS 'must be positive, in the currency of the account';
+
+COMMENT ON COLUMN "withdrawals"."external_reference" IS 'the reference of the withdrawal in the external payment system';
+
+ALTER TABLE "withdrawals" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+ALTER TABLE "withdrawals" ADD FOREIGN KEY ("created_by") REFERENCES "users" ("username");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "balance_drifts";
+
+DROP TABLE IF EXISTS "reconciliation_runs";
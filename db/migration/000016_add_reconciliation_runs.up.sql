@@ -0,0 +1,28 @@
+-- 每次对账重新计算所有账户的条目之和，并与账户的余额比较
+CREATE TABLE "reconciliation_runs" (
+  "id" bigserial PRIMARY KEY,
+  "accounts_checked" bigint NOT NULL,
+  "drift_count" bigint NOT NULL,
+  "started_at" timestamptz NOT NULL,
+  "finished_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+COMMENT ON COLUMN "reconciliation_runs"."drift_count" IS 'the number of accounts whose balance differs from the sum of their entries';
+
+CREATE TABLE "balance_drifts" (
+  "id" bigserial PRIMARY KEY,
+  "run_id" bigint NOT NULL,
+  "account_id" bigint NOT NULL,
+  "balance" bigint NOT NULL,
+  "entries_balance" bigint NOT NULL
+);
+
+CREATE INDEX ON "balance_drifts" ("run_id");
+
+COMMENT ON COLUMN "balance_drifts"."balance" IS 'accounts.balance when the run started';
+
+COMMENT ON COLUMN "balance_drifts"."entries_balance" IS 'the sum of the account entries when the run started';
+
+ALTER TABLE "balance_drifts" ADD FOREIGN KEY ("run_id") REFERENCES "reconciliation_runs" ("id");
+
+ALTER TABLE "balance_drifts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
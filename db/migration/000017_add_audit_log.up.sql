@@ -0,0 +1,42 @@
+-- 审计日志只能追加，每一行的哈希值由上一行的哈希值和本行的内容计算，修改任意一行都会使之后的哈希链断开
+CREATE TABLE "audit_log" (
+  "id" bigserial PRIMARY KEY,
+  "actor" varchar NOT NULL,
+  "action" varchar NOT NULL,
+  "target" varchar NOT NULL,
+  "request_id" varchar NOT NULL DEFAULT '',
+  "before" json NOT NULL DEFAULT 'null',
+  "after" json NOT NULL DEFAULT 'null',
+  "prev_hash" varchar UNIQUE NOT NULL,
+  "hash" varchar UNIQUE NOT NULL,
+  "created_at" timestamptz NOT NULL
+);
+
+CREATE INDEX ON "audit_log" ("target");
+
+COMMENT ON COLUMN "audit_log"."actor" IS 'the user who performed the action, or system for background jobs';
+
+COMMENT ON COLUMN "audit_log"."target" IS 'the affected object, for example account:1 or user:alice';
+
+COMMENT ON COLUMN "audit_log"."before" IS 'the object before the action, stored as json so the hashed text is kept byte for byte';
+
+COMMENT ON COLUMN "audit_log"."prev_hash" IS 'the hash of the previous row, empty for the first row';
+
+COMMENT ON COLUMN "audit_log"."hash" IS 'hex encoded SHA-256 of prev_hash and the content of this row';
+
+-- 禁止修改和删除审计日志
+CREATE FUNCTION reject_audit_log_change() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_log is append-only' USING ERRCODE = 'insufficient_privilege';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_log_append_only"
+BEFORE UPDATE OR DELETE ON "audit_log"
+FOR EACH ROW
+EXECUTE FUNCTION reject_audit_log_change();
+
+CREATE TRIGGER "audit_log_no_truncate"
+BEFORE TRUNCATE ON "audit_log"
+FOR EACH STATEMENT
+EXECUTE FUNCTION reject_audit_log_change();
@@ -0,0 +1,49 @@
+-- 交易限额规则，按照转出账户的货币和所有者的角色匹配，在交易事务中根据当前窗口内的交易记录检查
+CREATE TABLE "transfer_limit_rules" (
+  "id" varchar PRIMARY KEY,
+  "scope" varchar NOT NULL,
+  "period" varchar NOT NULL,
+  "metric" varchar NOT NULL,
+  "currency" varchar NOT NULL,
+  "role" varchar NOT NULL,
+  "max_value" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "transfer_limit_rules" ("currency", "role");
+
+COMMENT ON COLUMN "transfer_limit_rules"."scope" IS 'account: transfers from the account, user: transfers from all accounts of the owner in the currency';
+
+COMMENT ON COLUMN "transfer_limit_rules"."period" IS 'transfer (a single transfer), hour (the last hour), day or month (the current UTC calendar day or month)';
+
+COMMENT ON COLUMN "transfer_limit_rules"."metric" IS 'amount (in minor units of the currency) or count (number of transfers)';
+
+ALTER TABLE "transfer_limit_rules" ADD CONSTRAINT "transfer_limit_scope_valid" CHECK ("scope" IN ('account', 'user'));
+
+ALTER TABLE "transfer_limit_rules" ADD CONSTRAINT "transfer_limit_period_valid" CHECK ("period" IN ('transfer', 'hour', 'day', 'month'));
+
+ALTER TABLE "transfer_limit_rules" ADD CONSTRAINT "transfer_limit_metric_valid" CHECK ("metric" IN ('amount', 'count') AND NOT ("period" = 'transfer' AND "metric" = 'count'));
+
+ALTER TABLE "transfer_limit_rules" ADD CONSTRAINT "transfer_limit_max_value_positive" CHECK ("max_value" > 0);
+
+-- 按照时间窗口汇总账户转出的交易
+CREATE INDEX ON "transfers" ("from_account_id", "created_at");
+
+-- 客户的默认限额
+INSERT INTO "transfer_limit_rules" ("id", "scope", "period", "metric", "currency", "role", "max_value")
+SELECT
+  lower("currency") || '-' || "rule"."suffix",
+  "rule"."scope",
+  "rule"."period",
+  "rule"."metric",
+  "currency",
+  'depositor',
+  "rule"."max_value"
+FROM unnest(ARRAY['USD', 'EUR', 'CAD']) AS "currency"
+CROSS JOIN (VALUES
+  ('account-single', 'account', 'transfer', 'amount', 1000000),
+  ('account-daily', 'account', 'day', 'amount', 2500000),
+  ('account-hourly-count', 'account', 'hour', 'count', 30),
+  ('user-daily', 'user', 'day', 'amount', 5000000),
+  ('user-monthly', 'user', 'month', 'amount', 20000000)
+) AS "rule" ("suffix", "scope", "period", "metric", "max_value");
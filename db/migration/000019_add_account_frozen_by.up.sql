@@ -0,0 +1,6 @@
+-- 记录冻结账户的用户，客户只能解冻自己冻结的账户，银行职员冻结的账户只能由银行职员解冻
+ALTER TABLE "accounts" ADD COLUMN "frozen_by" varchar;
+
+ALTER TABLE "accounts" ADD FOREIGN KEY ("frozen_by") REFERENCES "users" ("username");
+
+COMMENT ON COLUMN "accounts"."frozen_by" IS 'the user who froze the account, null unless the account is frozen';
@@ -0,0 +1,14 @@
+UPDATE "tasks" SET "status" = 'pending' WHERE "status" = 'running';
+
+ALTER TABLE "tasks" DROP CONSTRAINT IF EXISTS "task_status_valid";
+
+ALTER TABLE "tasks" ADD CONSTRAINT "task_status_valid"
+  CHECK ("status" IN ('pending', 'succeeded', 'dead'));
+
+DROP INDEX IF EXISTS "tasks_run_at_idx";
+
+CREATE INDEX "tasks_run_at_idx" ON "tasks" ("run_at") WHERE "status" = 'pending';
+
+COMMENT ON COLUMN "tasks"."status" IS 'pending, succeeded, or dead once max_attempts is reached';
+
+COMMENT ON COLUMN "tasks"."run_at" IS 'the task is not processed before this time, used for retry backoff';
@@ -0,0 +1,14 @@
+-- 任务在处理之前先被标记为 running ，处理过程不再占用数据库事务
+-- running 状态的任务的 run_at 为租约的到期时间，处理器崩溃之后任务在租约到期时会被重新处理
+ALTER TABLE "tasks" DROP CONSTRAINT "task_status_valid";
+
+ALTER TABLE "tasks" ADD CONSTRAINT "task_status_valid"
+  CHECK ("status" IN ('pending', 'running', 'succeeded', 'dead'));
+
+DROP INDEX IF EXISTS "tasks_run_at_idx";
+
+CREATE INDEX "tasks_run_at_idx" ON "tasks" ("run_at") WHERE "status" IN ('pending', 'running');
+
+COMMENT ON COLUMN "tasks"."status" IS 'pending, running while a processor holds the lease, succeeded, or dead once max_attempts is reached';
+
+COMMENT ON COLUMN "tasks"."run_at" IS 'the task is not processed before this time, used for retry backoff and as the lease expiry of running tasks';
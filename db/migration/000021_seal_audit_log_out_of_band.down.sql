@@ -0,0 +1,43 @@
+-- 回滚之后哈希链按照 id 的顺序校验，未封存的行或者封存顺序与 id 顺序不一致的行都会使哈希链断开
+-- 回滚之前需要先停止服务并运行封存程序，封存顺序与 id 顺序不一致时不能回滚
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM "audit_log" WHERE "seq" IS NULL) THEN
+    RAISE EXCEPTION 'audit_log has unsealed rows, seal them before rolling back';
+  END IF;
+  IF EXISTS (
+    SELECT 1 FROM (
+      SELECT "seq", lag("seq") OVER (ORDER BY "id") AS "prev_seq" FROM "audit_log"
+    ) AS "chain"
+    WHERE "seq" < "prev_seq"
+  ) THEN
+    RAISE EXCEPTION 'audit_log was sealed out of id order and cannot be verified by id after rolling back';
+  END IF;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION reject_audit_log_change() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_log is append-only' USING ERRCODE = 'insufficient_privilege';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS "audit_log_unsealed_idx";
+
+DROP INDEX IF EXISTS "audit_log_hash_key";
+
+DROP INDEX IF EXISTS "audit_log_prev_hash_key";
+
+ALTER TABLE "audit_log" ADD CONSTRAINT "audit_log_prev_hash_key" UNIQUE ("prev_hash");
+
+ALTER TABLE "audit_log" ADD CONSTRAINT "audit_log_hash_key" UNIQUE ("hash");
+
+ALTER TABLE "audit_log" ALTER COLUMN "hash" DROP DEFAULT;
+
+ALTER TABLE "audit_log" ALTER COLUMN "prev_hash" DROP DEFAULT;
+
+ALTER TABLE "audit_log" DROP COLUMN IF EXISTS "seq";
+
+COMMENT ON COLUMN "audit_log"."prev_hash" IS 'the hash of the previous row, empty for the first row';
+
+COMMENT ON COLUMN "audit_log"."hash" IS 'hex encoded SHA-256 of prev_hash and the content of this row';
@@ -0,0 +1,52 @@
+-- 审计日志的哈希链改为由后台的封存程序按照 seq 的顺序批量计算，写入审计日志的事务不再争用全局的咨询锁
+-- 新写入的行 seq 为 NULL ，prev_hash 和 hash 为空字符串，封存之后才受哈希链的保护
+ALTER TABLE "audit_log" ADD COLUMN "seq" bigint UNIQUE;
+
+ALTER TABLE "audit_log" ALTER COLUMN "prev_hash" SET DEFAULT '';
+
+ALTER TABLE "audit_log" ALTER COLUMN "hash" SET DEFAULT '';
+
+-- 已有的行都已经按照 id 的顺序计算了哈希链
+ALTER TABLE "audit_log" DISABLE TRIGGER "audit_log_append_only";
+
+UPDATE "audit_log" SET "seq" = "id";
+
+ALTER TABLE "audit_log" ENABLE TRIGGER "audit_log_append_only";
+
+-- 未封存的行的哈希值都为空字符串，唯一约束只对已经封存的行生效
+ALTER TABLE "audit_log" DROP CONSTRAINT "audit_log_prev_hash_key";
+
+ALTER TABLE "audit_log" DROP CONSTRAINT "audit_log_hash_key";
+
+CREATE UNIQUE INDEX "audit_log_prev_hash_key" ON "audit_log" ("prev_hash") WHERE "seq" IS NOT NULL;
+
+CREATE UNIQUE INDEX "audit_log_hash_key" ON "audit_log" ("hash") WHERE "seq" IS NOT NULL;
+
+CREATE INDEX "audit_log_unsealed_idx" ON "audit_log" ("id") WHERE "seq" IS NULL;
+
+COMMENT ON COLUMN "audit_log"."seq" IS 'position in the hash chain assigned by the sealer, NULL until the row is sealed';
+
+COMMENT ON COLUMN "audit_log"."prev_hash" IS 'the hash of the previous row in seq order, empty for the first row and for unsealed rows';
+
+COMMENT ON COLUMN "audit_log"."hash" IS 'hex encoded SHA-256 of prev_hash and the content of this row, empty until the row is sealed';
+
+-- 唯一允许的修改是封存一行未封存的行，即只设置 seq 、 prev_hash 和 hash ，其他修改、删除和清空仍然被拒绝
+CREATE OR REPLACE FUNCTION reject_audit_log_change() RETURNS trigger AS $$
+BEGIN
+  IF TG_OP = 'UPDATE' THEN
+    IF OLD.seq IS NULL AND NEW.seq IS NOT NULL
+      AND NEW.id = OLD.id
+      AND NEW.actor = OLD.actor
+      AND NEW.action = OLD.action
+      AND NEW.target = OLD.target
+      AND NEW.request_id = OLD.request_id
+      AND NEW.before::text = OLD.before::text
+      AND NEW.after::text = OLD.after::text
+      AND NEW.created_at = OLD.created_at
+    THEN
+      RETURN NEW;
+    END IF;
+  END IF;
+  RAISE EXCEPTION 'audit_log is append-only' USING ERRCODE = 'insufficient_privilege';
+END;
+$$ LANGUAGE plpgsql;
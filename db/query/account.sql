@@ -7,16 +7,29 @@ INSERT INTO accounts (
   $1, $2, $3
 ) RETURNING *;
 
+-- name: GetAccount :one
+/* 只读取账户不加锁，用于之后按照账户 ID 的顺序加锁的事务 */
+SELECT * FROM accounts
+WHERE id = $1 LIMIT 1;
+
 -- name: GetAccountForUpdate :one
 SELECT * FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE;
 
 -- name: ListAccounts :many
+/* 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询 */
 SELECT * FROM accounts
+WHERE owner = sqlc.arg(owner)
+  AND id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1 /* 进行分页显示，设置想要获取的行数 */
-OFFSET $2 /* 在开始返回结果之前跳过指定的行数 */;
+LIMIT sqlc.arg('limit') /* 进行分页显示，设置想要获取的行数 */
+OFFSET sqlc.arg('offset') /* 在开始返回结果之前跳过指定的行数 */;
+
+-- name: ListAccountCurrencies :many
+/* 查询多个账户的货币类型，用于将交易的金额格式化为对应货币的金额 */
+SELECT id, currency FROM accounts
+WHERE id = ANY(sqlc.arg(ids)::bigint[]);
 
 -- name: UpdateAccount :one
 UPDATE accounts
@@ -30,6 +43,16 @@ set balance = balance + sqlc.arg(amount)
 WHERE id = sqlc.arg(id)
 RETURNING *;
 
--- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1;
\ No newline at end of file
+-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+set overdraft_limit = sqlc.arg(overdraft_limit)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdateAccountStatus :one
+/* 冻结账户时记录冻结的用户，其他状态时清空 */
+UPDATE accounts
+set status = sqlc.arg(status),
+  frozen_by = sqlc.narg(frozen_by)
+WHERE id = sqlc.arg(id)
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,46 @@
+-- name: LockAuditLog :exec
+/* 在事务结束之前独占审计日志的哈希链尾部，保证同一时间只有一个封存程序在延长哈希链，哈希链不会分叉 */
+SELECT pg_advisory_xact_lock(sqlc.arg(lock_id)::bigint);
+
+-- name: GetLastSealedAuditLog :one
+SELECT * FROM audit_log
+WHERE seq IS NOT NULL
+ORDER BY seq DESC
+LIMIT 1;
+
+-- name: CreateAuditLog :one
+/* 写入一行未封存的审计日志，哈希值由封存程序在之后计算 */
+INSERT INTO audit_log (
+  actor,
+  action,
+  target,
+  request_id,
+  before,
+  after,
+  created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: ListUnsealedAuditLogs :many
+/* 按照 id 的顺序读取已经提交但还没有封存的审计日志 */
+SELECT * FROM audit_log
+WHERE seq IS NULL
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: SealAuditLog :one
+/* 将一行审计日志链接到哈希链的尾部，已经封存的行不能再次封存 */
+UPDATE audit_log
+SET seq = sqlc.arg(seq)::bigint,
+    prev_hash = sqlc.arg(prev_hash),
+    hash = sqlc.arg(hash)
+WHERE id = sqlc.arg(id) AND seq IS NULL
+RETURNING *;
+
+-- name: ListAuditLogs :many
+/* 按照哈希链的顺序分页读取已经封存的审计日志，从 after_seq 之后开始 */
+SELECT * FROM audit_log
+WHERE seq > sqlc.arg(after_seq)::bigint
+ORDER BY seq
+LIMIT sqlc.arg('limit');
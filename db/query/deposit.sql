@@ -0,0 +1,30 @@
+-- name: CreateDeposit :one
+INSERT INTO deposits (
+  account_id,
+  amount,
+  external_reference,
+  status,
+  created_by,
+  resolved_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetDeposit :one
+SELECT * FROM deposits
+WHERE id = $1 LIMIT 1;
+
+-- name: GetDepositForUpdate :one
+SELECT * FROM deposits
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ResolveDeposit :one
+/* 只有等待入账的存款可以修改状态 */
+UPDATE deposits
+SET
+  status = sqlc.arg(status),
+  resolved_at = now()
+WHERE id = sqlc.arg(id) AND status = 'pending'
+RETURNING *;
+
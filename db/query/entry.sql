@@ -1,9 +1,12 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id,
+  reversal_id,
+  journal_entry_id
 ) VALUES (
-  $1, $2
+  $1, $2, $3, $4, $5
 ) RETURNING *;
 
 -- name: GetEntry :one
@@ -11,7 +14,87 @@ SELECT * FROM entries
 WHERE id = $1 LIMIT 1;
 
 -- name: ListEntries :many
+/* 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询 */
 SELECT * FROM entries
+WHERE account_id = sqlc.arg(account_id)
+  AND id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $1 /* 进行分页显示，设置想要获取的行数 */
-OFFSET $2 /* 在开始返回结果之前跳过指定的行数 */;
\ No newline at end of file
+LIMIT sqlc.arg('limit') /* 进行分页显示，设置想要获取的行数 */
+OFFSET sqlc.arg('offset') /* 在开始返回结果之前跳过指定的行数 */;
+
+-- name: GetAccountBalanceAt :one
+/* 用账户当前余额减去指定时间之后的所有条目金额，得到账户在该时间点的余额 */
+SELECT (a.balance - COALESCE(SUM(e.amount), 0))::bigint AS balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= sqlc.arg(at)
+WHERE a.id = sqlc.arg(account_id)
+GROUP BY a.id, a.balance;
+
+-- name: ListStatementEntries :many
+/* 查询账户在时间范围内的一页条目，并计算每条条目入账之后的账户余额以及交易的对方账户 */
+/* 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 page_offset 为 0 并从上一页最后一条记录的 id 之后开始查询 */
+WITH page AS (
+  SELECT * FROM entries
+  WHERE entries.account_id = sqlc.arg(account_id)
+    AND entries.created_at >= sqlc.arg(start_time)
+    AND entries.created_at < sqlc.arg(end_time)
+    AND entries.id > sqlc.arg(after_id)
+  ORDER BY entries.id
+  LIMIT sqlc.arg(page_size)
+  OFFSET sqlc.arg(page_offset)
+), later AS (
+  /* 当前页之后入账的所有条目的金额总和 */
+  SELECT COALESCE(SUM(entries.amount), 0)::bigint AS total
+  FROM entries
+  WHERE entries.account_id = sqlc.arg(account_id)
+    AND entries.id > (SELECT COALESCE(MAX(page.id), 0) FROM page)
+)
+SELECT
+  page.id,
+  page.account_id,
+  page.amount,
+  page.created_at,
+  page.transfer_id,
+  page.reversal_id,
+  counterparty.account_id AS counterparty_account_id,
+  (
+    accounts.balance - later.total
+    - (SUM(page.amount) OVER (ORDER BY page.id DESC) - page.amount)
+  )::bigint AS running_balance
+FROM page
+CROSS JOIN later
+JOIN accounts ON accounts.id = page.account_id
+/* 同一笔交易中另一条条目所属的账户即为对方账户，冲正产生的条目只与同一次冲正的另一条条目对应 */
+LEFT JOIN entries AS counterparty
+  ON counterparty.transfer_id = page.transfer_id
+  AND counterparty.reversal_id IS NOT DISTINCT FROM page.reversal_id
+  AND counterparty.id <> page.id
+ORDER BY page.id;
+
+-- name: ListStatementExportEntries :many
+/* 导出对账单时从 after_id 之后分批查询账户在时间范围内的条目以及交易的对方账户 */
+/* 不计算入账之后的账户余额，由调用方从期初余额开始逐条累加，避免每一批都重新汇总之后的所有条目 */
+WITH batch AS (
+  SELECT * FROM entries
+  WHERE entries.account_id = sqlc.arg(account_id)
+    AND entries.created_at >= sqlc.arg(start_time)
+    AND entries.created_at < sqlc.arg(end_time)
+    AND entries.id > sqlc.arg(after_id)
+  ORDER BY entries.id
+  LIMIT sqlc.arg(page_size)
+)
+SELECT
+  batch.id,
+  batch.account_id,
+  batch.amount,
+  batch.created_at,
+  batch.transfer_id,
+  batch.reversal_id,
+  counterparty.account_id AS counterparty_account_id
+FROM batch
+/* 同一笔交易中另一条条目所属的账户即为对方账户，冲正产生的条目只与同一次冲正的另一条条目对应 */
+LEFT JOIN entries AS counterparty
+  ON counterparty.transfer_id = batch.transfer_id
+  AND counterparty.reversal_id IS NOT DISTINCT FROM batch.reversal_id
+  AND counterparty.id <> batch.id
+ORDER BY batch.id;
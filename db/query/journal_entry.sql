@@ -0,0 +1,22 @@
+-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (
+  kind,
+  description
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: GetJournalEntry :one
+SELECT * FROM journal_entries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListJournalEntryLines :many
+SELECT * FROM entries
+WHERE journal_entry_id = $1
+ORDER BY id;
+
+-- name: GetSystemAccount :one
+SELECT accounts.* FROM accounts
+JOIN system_accounts ON system_accounts.account_id = accounts.id
+WHERE system_accounts.name = $1 AND system_accounts.currency = $2
+LIMIT 1;
@@ -0,0 +1,49 @@
+-- name: CountAccounts :one
+SELECT COUNT(*) FROM accounts;
+
+-- name: ListAccountBalanceDrifts :many
+/* 余额与所有条目之和不一致的账户，没有条目的账户的条目之和为 0 */
+SELECT
+  accounts.id AS account_id,
+  accounts.balance,
+  COALESCE(SUM(entries.amount), 0)::bigint AS entries_balance
+FROM accounts
+LEFT JOIN entries ON entries.account_id = accounts.id
+GROUP BY accounts.id
+HAVING accounts.balance <> COALESCE(SUM(entries.amount), 0)
+ORDER BY accounts.id;
+
+-- name: CreateReconciliationRun :one
+INSERT INTO reconciliation_runs (
+  accounts_checked,
+  drift_count,
+  started_at
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: CreateBalanceDrift :one
+INSERT INTO balance_drifts (
+  run_id,
+  account_id,
+  balance,
+  entries_balance
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetLatestReconciliationRun :one
+SELECT * FROM reconciliation_runs
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: ListBalanceDrifts :many
+/* 同时返回账户的所有者和货币，便于定位出现差异的账户 */
+SELECT
+  balance_drifts.*,
+  accounts.owner,
+  accounts.currency
+FROM balance_drifts
+JOIN accounts ON accounts.id = balance_drifts.account_id
+WHERE balance_drifts.run_id = $1
+ORDER BY balance_drifts.account_id;
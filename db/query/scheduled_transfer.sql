@@ -0,0 +1,67 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  schedule,
+  next_run_at,
+  end_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
+
+-- name: ListScheduledTransfers :many
+/* 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询 */
+SELECT * FROM scheduled_transfers
+WHERE owner = sqlc.arg(owner)
+  AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateScheduledTransfer :one
+/* 只修改传入了值的字段，没有传入值（为 NULL ）的字段保持不变，已经完成或者取消的计划交易不能再修改 */
+UPDATE scheduled_transfers
+SET amount = COALESCE(sqlc.narg(amount), amount),
+    schedule = COALESCE(sqlc.narg(schedule), schedule),
+    next_run_at = COALESCE(sqlc.narg(next_run_at), next_run_at),
+    end_at = COALESCE(sqlc.narg(end_at), end_at),
+    status = COALESCE(sqlc.narg(status), status)
+WHERE id = sqlc.arg(id)
+  AND status IN ('active', 'paused')
+RETURNING *;
+
+-- name: GetDueScheduledTransferForUpdate :one
+/* 锁定一条已经到期的计划交易，被其他服务器实例锁定的行会被跳过，因此多个实例不会重复执行同一条计划交易 */
+SELECT * FROM scheduled_transfers
+WHERE status = 'active'
+  AND next_run_at <= sqlc.arg(now)
+ORDER BY next_run_at
+LIMIT 1
+FOR UPDATE SKIP LOCKED;
+
+-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (
+  scheduled_transfer_id,
+  scheduled_at,
+  transfer_id,
+  status,
+  failure_reason
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListScheduledTransferRuns :many
+/* 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询 */
+SELECT * FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = sqlc.arg(scheduled_transfer_id)
+  AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
@@ -0,0 +1,30 @@
+-- name: CreateSession :one
+INSERT INTO sessions (
+  id,
+  username,
+  refresh_token,
+  user_agent,
+  client_ip,
+  is_blocked,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetSession :one
+SELECT * FROM sessions
+WHERE id = $1 LIMIT 1;
+
+-- name: BlockSession :one
+/* 封禁之后该会话的刷新令牌立即失效，不能再用于获取新的访问令牌 */
+UPDATE sessions
+SET is_blocked = true
+WHERE id = $1
+RETURNING *;
+
+-- name: BlockUserSessions :execrows
+/* 封禁用户所有未封禁的会话，用于修改密码之后让之前签发的刷新令牌全部失效 */
+UPDATE sessions
+SET is_blocked = true
+WHERE username = $1
+  AND is_blocked = false;
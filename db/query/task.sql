@@ -0,0 +1,67 @@
+-- name: CreateTask :one
+INSERT INTO tasks (
+  type,
+  payload,
+  max_attempts,
+  run_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetTask :one
+SELECT * FROM tasks
+WHERE id = $1 LIMIT 1;
+
+-- name: ClaimDueTask :one
+/* 领取一个已经到期的待处理任务，或者租约已经到期的处理中任务，被其他处理器锁定的行会被跳过 */
+/* 领取时即增加一次尝试次数，run_at 设置为租约的到期时间，处理器崩溃或者处理超时也会计入尝试次数 */
+/* 租约到期的任务若已经达到最大尝试次数，则不再领取，直接进入死信状态 */
+WITH due AS (
+  SELECT t.id FROM tasks AS t
+  WHERE t.status IN ('pending', 'running')
+    AND t.run_at <= sqlc.arg(now)
+  ORDER BY t.run_at
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+UPDATE tasks
+SET status = CASE WHEN tasks.attempts >= tasks.max_attempts THEN 'dead' ELSE 'running' END,
+    attempts = CASE WHEN tasks.attempts >= tasks.max_attempts THEN tasks.attempts ELSE tasks.attempts + 1 END,
+    run_at = CASE WHEN tasks.attempts >= tasks.max_attempts THEN tasks.run_at ELSE sqlc.arg(lease_until)::timestamptz END,
+    last_error = CASE WHEN tasks.attempts >= tasks.max_attempts THEN 'task lease expired' ELSE tasks.last_error END,
+    updated_at = now()
+FROM due
+WHERE tasks.id = due.id
+RETURNING tasks.*;
+
+-- name: UpdateTaskResult :one
+/* 记录一次处理的结果，尝试次数已经在领取任务时增加 */
+/* 只有任务没有被其他处理器重新领取时才能记录，重新领取会增加尝试次数，因此 attempts 与领取时不同 */
+UPDATE tasks
+SET status = sqlc.arg(status),
+    run_at = sqlc.arg(run_at),
+    last_error = sqlc.arg(last_error),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'running'
+  AND attempts = sqlc.arg(attempts)
+RETURNING *;
+
+-- name: ListDeadTasks :many
+/* 查询已经超过最大尝试次数的任务，便于排查问题后重新处理 */
+SELECT * FROM tasks
+WHERE status = 'dead'
+  AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: RequeueDeadTask :one
+/* 将死信任务重新放回队列，并重置尝试次数 */
+UPDATE tasks
+SET status = 'pending',
+    attempts = 0,
+    run_at = now(),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+  AND status = 'dead'
+RETURNING *;
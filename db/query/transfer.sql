@@ -2,18 +2,55 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  to_amount,
+  exchange_rate,
+  quote_id
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetTransfer :one
 SELECT * FROM transfers
 WHERE id = $1 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+/* 冲正时锁定原交易，同一笔交易的冲正依次执行，避免重复冲正 */
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: AddTransferReversedAmount :one
+/* 累加已退款的金额，全部退款之后交易的状态为 reversed ，否则为 partially_reversed */
+UPDATE transfers
+SET
+  reversed_amount = reversed_amount + sqlc.arg(amount),
+  status = CASE
+    WHEN reversed_amount + sqlc.arg(amount) = amount THEN 'reversed'
+    ELSE 'partially_reversed'
+  END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
 -- name: ListTransfers :many
+/* 查询与指定账户相关的所有交易（转出和转入），可选的过滤条件为 NULL 时不进行过滤 */
+/* 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询 */
+/* 金额范围按照该账户的货币计算：转出交易使用 amount ，转入交易使用 to_amount */
 SELECT * FROM transfers
-WHERE from_account_id = $1 OR to_account_id = $2
+WHERE (
+    (from_account_id = sqlc.arg(account_id) AND sqlc.narg(direction)::varchar IS DISTINCT FROM 'incoming')
+    OR (to_account_id = sqlc.arg(account_id) AND sqlc.narg(direction)::varchar IS DISTINCT FROM 'outgoing')
+  )
+  AND (sqlc.narg(counterparty_account_id)::bigint IS NULL
+    OR from_account_id = sqlc.narg(counterparty_account_id)
+    OR to_account_id = sqlc.narg(counterparty_account_id))
+  AND (sqlc.narg(min_amount)::bigint IS NULL
+    OR (CASE WHEN from_account_id = sqlc.arg(account_id) THEN amount ELSE to_amount END) >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::bigint IS NULL
+    OR (CASE WHEN from_account_id = sqlc.arg(account_id) THEN amount ELSE to_amount END) <= sqlc.narg(max_amount))
+  AND (sqlc.narg(start_time)::timestamptz IS NULL OR created_at >= sqlc.narg(start_time))
+  AND (sqlc.narg(end_time)::timestamptz IS NULL OR created_at < sqlc.narg(end_time))
+  AND id > sqlc.arg(after_id)
 ORDER BY id
-LIMIT $3 /* 进行分页显示，设置想要获取的行数 */
-OFFSET $4 /* 在开始返回结果之前跳过指定的行数 */;
\ No newline at end of file
+LIMIT sqlc.arg('limit') /* 进行分页显示，设置想要获取的行数 */
+OFFSET sqlc.arg('offset') /* 在开始返回结果之前跳过指定的行数 */;
@@ -0,0 +1,60 @@
+-- name: ListTransferLimitRules :many
+/* 返回适用于指定货币和角色的所有限额规则 */
+SELECT * FROM transfer_limit_rules
+WHERE currency = $1 AND role = $2
+ORDER BY id;
+
+-- name: SumAccountTransfers :one
+/* 汇总账户从 since 开始转出的交易和取款，已经退款的金额不计入，全部退款的交易不计入笔数 */
+SELECT
+  COALESCE(SUM(outflows.amount), 0)::bigint AS total_amount,
+  COUNT(*) FILTER (WHERE outflows.counted) AS transfer_count
+FROM (
+  SELECT (transfers.amount - transfers.reversed_amount)::bigint AS amount, transfers.status <> 'reversed' AS counted
+  FROM transfers
+  WHERE transfers.from_account_id = sqlc.arg(account_id)
+    AND transfers.created_at >= sqlc.arg(since)
+  UNION ALL
+  SELECT withdrawals.amount, true AS counted
+  FROM withdrawals
+  WHERE withdrawals.account_id = sqlc.arg(account_id)
+    AND withdrawals.created_at >= sqlc.arg(since)
+) AS outflows;
+
+-- name: SumUserTransfers :one
+/* 汇总用户所有该货币的账户从 since 开始转出的交易和取款，已经退款的金额不计入，全部退款的交易不计入笔数 */
+SELECT
+  COALESCE(SUM(outflows.amount), 0)::bigint AS total_amount,
+  COUNT(*) FILTER (WHERE outflows.counted) AS transfer_count
+FROM (
+  SELECT (transfers.amount - transfers.reversed_amount)::bigint AS amount, transfers.status <> 'reversed' AS counted
+  FROM transfers
+  JOIN accounts ON accounts.id = transfers.from_account_id
+  WHERE accounts.owner = sqlc.arg(owner)
+    AND accounts.currency = sqlc.arg(currency)
+    AND transfers.created_at >= sqlc.arg(since)
+  UNION ALL
+  SELECT withdrawals.amount, true AS counted
+  FROM withdrawals
+  JOIN accounts ON accounts.id = withdrawals.account_id
+  WHERE accounts.owner = sqlc.arg(owner)
+    AND accounts.currency = sqlc.arg(currency)
+    AND withdrawals.created_at >= sqlc.arg(since)
+) AS outflows;
+
+-- name: CreateTransferLimitRule :one
+INSERT INTO transfer_limit_rules (
+  id,
+  scope,
+  period,
+  metric,
+  currency,
+  role,
+  max_value
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: DeleteTransferLimitRule :exec
+DELETE FROM transfer_limit_rules
+WHERE id = $1;
@@ -10,4 +10,34 @@ INSERT INTO users (
 
 -- name: GetUser :one
 SELECT * FROM users
-WHERE username = $1 LIMIT 1;
\ No newline at end of file
+WHERE username = $1 LIMIT 1;
+
+-- name: GetUserForUpdate :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1
+FOR NO KEY UPDATE;
+-- name: VerifyUserEmail :one
+/* 只有验证码发送到的邮箱仍然是用户当前的邮箱时才会验证成功 */
+UPDATE users
+SET is_email_verified = true
+WHERE username = sqlc.arg(username)
+  AND email = sqlc.arg(email)
+RETURNING *;
+
+-- name: UpdateUser :one
+/* 只修改传入了值的字段，其他字段保持不变 */
+UPDATE users
+SET
+  hashed_password = COALESCE(sqlc.narg(hashed_password), hashed_password),
+  password_changed_at = COALESCE(sqlc.narg(password_changed_at), password_changed_at),
+  full_name = COALESCE(sqlc.narg(full_name), full_name),
+  email = COALESCE(sqlc.narg(email), email),
+  is_email_verified = COALESCE(sqlc.narg(is_email_verified), is_email_verified),
+  role = COALESCE(sqlc.narg(role), role)
+WHERE username = sqlc.arg(username)
+RETURNING *;
+
+-- name: GetUserPasswordChangedAt :one
+/* 认证时检查访问令牌是否在最近一次修改密码之前签发 */
+SELECT password_changed_at FROM users
+WHERE username = $1 LIMIT 1;
@@ -0,0 +1,18 @@
+-- name: CreateVerifyEmail :one
+INSERT INTO verify_emails (
+  username,
+  email,
+  secret_code
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: UseVerifyEmail :one
+/* 将一个未使用并且没有过期的验证码标记为已使用，验证码不正确、已经使用或者已经过期时不返回任何行 */
+UPDATE verify_emails
+SET is_used = true
+WHERE id = sqlc.arg(id)
+  AND secret_code = sqlc.arg(secret_code)
+  AND is_used = false
+  AND expired_at > now()
+RETURNING *;
@@ -0,0 +1,13 @@
+-- name: CreateWithdrawal :one
+INSERT INTO withdrawals (
+  account_id,
+  amount,
+  external_reference,
+  created_by
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetWithdrawal :one
+SELECT * FROM withdrawals
+WHERE id = $1 LIMIT 1;
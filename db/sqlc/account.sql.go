@@ -7,13 +7,14 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const addAccountBalance = `-- name: AddAccountBalance :one
 UPDATE accounts
 set balance = balance + $1
 WHERE id = $2
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, status, frozen_by
 `
 
 type AddAccountBalanceParams struct {
@@ -30,6 +31,9 @@ func (q *Queries) AddAccountBalance(ctx context.Context, arg AddAccountBalancePa
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.FrozenBy,
 	)
 	return i, err
 }
@@ -41,7 +45,7 @@ INSERT INTO accounts (
   currency
 ) VALUES (
   $1, $2, $3
-) RETURNING id, owner, balance, currency, created_at
+) RETURNING id, owner, balance, currency, created_at, overdraft_limit, status, frozen_by
 `
 
 type CreateAccountParams struct {
@@ -59,22 +63,37 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.FrozenBy,
 	)
 	return i, err
 }
 
-const deleteAccount = `-- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1
+const getAccount = `-- name: GetAccount :one
+SELECT id, owner, balance, currency, created_at, overdraft_limit, status, frozen_by FROM accounts
+WHERE id = $1 LIMIT 1
 `
 
-func (q *Queries) DeleteAccount(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, deleteAccount, id)
-	return err
+// 只读取账户不加锁，用于之后按照账户 ID 的顺序加锁的事务
+func (q *Queries) GetAccount(ctx context.Context, id int64) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccount, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.FrozenBy,
+	)
+	return i, err
 }
 
 const getAccountForUpdate = `-- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, overdraft_limit, status, frozen_by FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE
 `
@@ -88,24 +107,68 @@ func (q *Queries) GetAccountForUpdate(ctx context.Context, id int64) (Account, e
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.FrozenBy,
 	)
 	return i, err
 }
 
+const listAccountCurrencies = `-- name: ListAccountCurrencies :many
+SELECT id, currency FROM accounts
+WHERE id = ANY($1::bigint[])
+`
+
+type ListAccountCurrenciesRow struct {
+	ID       int64  `json:"id"`
+	Currency string `json:"currency"`
+}
+
+// 查询多个账户的货币类型，用于将交易的金额格式化为对应货币的金额
+func (q *Queries) ListAccountCurrencies(ctx context.Context, ids []int64) ([]ListAccountCurrenciesRow, error) {
+	rows, err := q.db.Query(ctx, listAccountCurrencies, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountCurrenciesRow{}
+	for rows.Next() {
+		var i ListAccountCurrenciesRow
+		if err := rows.Scan(&i.ID, &i.Currency); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, overdraft_limit, status, frozen_by FROM accounts
+WHERE owner = $1
+  AND id > $2
 ORDER BY id
-LIMIT $1 /* 进行分页显示，设置想要获取的行数 */
-OFFSET $2 /* 在开始返回结果之前跳过指定的行数 */
+LIMIT $4 /* 进行分页显示，设置想要获取的行数 */
+OFFSET $3 /* 在开始返回结果之前跳过指定的行数 */
 `
 
 type ListAccountsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Owner   string `json:"owner"`
+	AfterID int64  `json:"after_id"`
+	Offset  int32  `json:"offset"`
+	Limit   int32  `json:"limit"`
 }
 
+// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询
 func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
-	rows, err := q.db.Query(ctx, listAccounts, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listAccounts,
+		arg.Owner,
+		arg.AfterID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +182,9 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.OverdraftLimit,
+			&i.Status,
+			&i.FrozenBy,
 		); err != nil {
 			return nil, err
 		}
@@ -134,7 +200,7 @@ const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 set balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, status, frozen_by
 `
 
 type UpdateAccountParams struct {
@@ -151,6 +217,68 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const updateAccountOverdraftLimit = `-- name: UpdateAccountOverdraftLimit :one
+UPDATE accounts
+set overdraft_limit = $1
+WHERE id = $2
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, status, frozen_by
+`
+
+type UpdateAccountOverdraftLimitParams struct {
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	ID             int64 `json:"id"`
+}
+
+func (q *Queries) UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountOverdraftLimit, arg.OverdraftLimit, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+set status = $1,
+  frozen_by = $2
+WHERE id = $3
+RETURNING id, owner, balance, currency, created_at, overdraft_limit, status, frozen_by
+`
+
+type UpdateAccountStatusParams struct {
+	Status   string         `json:"status"`
+	FrozenBy sql.NullString `json:"frozen_by"`
+	ID       int64          `json:"id"`
+}
+
+// 冻结账户时记录冻结的用户，其他状态时清空
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountStatus, arg.Status, arg.FrozenBy, arg.ID)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.FrozenBy,
 	)
 	return i, err
 }
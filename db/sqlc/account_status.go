@@ -0,0 +1,175 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// 定义账户的所有状态
+const (
+	AccountStatusActive = "active"
+	AccountStatusFrozen = "frozen"
+	AccountStatusClosed = "closed"
+)
+
+// accountStatusTransitions 记录每个状态允许转换到的状态，必须与数据库中的 check_account_status_transition 触发器保持一致
+var accountStatusTransitions = map[string][]string{
+	AccountStatusActive: {AccountStatusFrozen, AccountStatusClosed},
+	AccountStatusFrozen: {AccountStatusActive},
+	AccountStatusClosed: {AccountStatusActive},
+}
+
+// CanTransitionAccountStatus 检查账户状态是否允许从 from 转换为 to
+func CanTransitionAccountStatus(from, to string) bool {
+	for _, status := range accountStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateAccountStatusTxParams 包含修改账户状态所需要的输入参数
+type UpdateAccountStatusTxParams struct {
+	AccountID int64
+	// 账户当前必须处于的状态，例如解冻只能作用于冻结的账户，重新开启只能作用于关闭的账户
+	FromStatus string
+	Status     string
+	// 修改状态的用户，冻结账户时记录为 frozen_by
+	ChangedBy string
+	// 修改状态的用户是否为银行职员，银行职员可以解冻任意账户，客户只能解冻自己冻结的账户
+	ChangedByBanker bool
+}
+
+// UpdateAccountStatusTx 在一个事务中锁定账户，检查当前状态以及状态转换是否合法后修改账户状态
+func (store *SQLStore) UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (Account, error) {
+	var result Account
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if account.Status != arg.FromStatus {
+			return fmt.Errorf("%w: account is %s, not %s", ErrInvalidAccountStatusTransition, account.Status, arg.FromStatus)
+		}
+		if account.Status == AccountStatusFrozen && arg.Status == AccountStatusActive && !arg.ChangedByBanker &&
+			(!account.FrozenBy.Valid || account.FrozenBy.String != arg.ChangedBy) {
+			return ErrAccountFrozenByOther
+		}
+
+		result, err = transitionAccountStatus(ctx, q, account, arg.Status, arg.ChangedBy)
+		return err
+	})
+
+	return result, err
+}
+
+// CloseAccountTxParams 包含关闭账户所需要的输入参数
+type CloseAccountTxParams struct {
+	AccountID int64
+	// 接收剩余余额的账户，账户余额为 0 时可以为 nil
+	SweepAccountID *int64
+}
+
+// CloseAccountTxResult 包含关闭账户事务的结果
+type CloseAccountTxResult struct {
+	Account Account `json:"account"`
+	// 将剩余余额转入 sweep 账户的交易，没有剩余余额时为 nil
+	SweepTransfer *TransferTxResult `json:"sweep_transfer"`
+}
+
+// CloseAccountTx 在一个事务中关闭账户，若账户还有余额，先将余额全部转入 sweep 账户
+func (store *SQLStore) CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error) {
+	var result CloseAccountTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// 与 transferTx 一样先锁定账户的所有者，再按照 ID 从小到大的顺序锁定账户，避免死锁
+		owned, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		_, err = q.GetUserForUpdate(ctx, owned.Owner)
+		if err != nil {
+			return err
+		}
+
+		accountIDs := []int64{arg.AccountID}
+		if arg.SweepAccountID != nil {
+			accountIDs = append(accountIDs, *arg.SweepAccountID)
+		}
+		sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+
+		accounts := make(map[int64]Account, len(accountIDs))
+		for _, id := range accountIDs {
+			account, err := q.GetAccountForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+			accounts[id] = account
+		}
+		account := accounts[arg.AccountID]
+
+		// 先检查状态转换，避免为无法关闭的账户转出余额
+		if !CanTransitionAccountStatus(account.Status, AccountStatusClosed) {
+			return fmt.Errorf("%w: from %s to %s", ErrInvalidAccountStatusTransition, account.Status, AccountStatusClosed)
+		}
+
+		// 只有正的余额可以转入 sweep 账户，透支的账户必须先还清欠款
+		if account.Balance != 0 {
+			if arg.SweepAccountID == nil || account.Balance < 0 {
+				return ErrAccountBalanceNotZero
+			}
+
+			sweepAccount := accounts[*arg.SweepAccountID]
+			if sweepAccount.ID == account.ID {
+				return fmt.Errorf("sweep account must be different from the closed account")
+			}
+			if sweepAccount.Currency != account.Currency {
+				return fmt.Errorf("sweep account [%d] currency mismatch: %s vs %s", sweepAccount.ID, sweepAccount.Currency, account.Currency)
+			}
+
+			// 转出余额是关闭账户的一部分，不受交易限额的限制
+			sweep, err := transferTx(ctx, q, TransferTxParams{
+				FromAccountID: account.ID,
+				ToAccountID:   sweepAccount.ID,
+				Amount:        account.Balance,
+			}, false)
+			if err != nil {
+				return err
+			}
+			result.SweepTransfer = &sweep
+			account = sweep.FromAccount
+		}
+
+		result.Account, err = transitionAccountStatus(ctx, q, account, AccountStatusClosed, "")
+		return err
+	})
+
+	return result, err
+}
+
+// transitionAccountStatus 使用传入的事务查询对象修改已经锁定的账户的状态，冻结账户时将 changedBy 记录为 frozen_by
+func transitionAccountStatus(ctx context.Context, q *Queries, account Account, status string, changedBy string) (Account, error) {
+	if !CanTransitionAccountStatus(account.Status, status) {
+		return account, fmt.Errorf("%w: from %s to %s", ErrInvalidAccountStatusTransition, account.Status, status)
+	}
+	// 已关闭的账户余额必须为 0
+	if status == AccountStatusClosed && account.Balance != 0 {
+		return account, ErrAccountBalanceNotZero
+	}
+
+	updated, err := q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+		ID:       account.ID,
+		Status:   status,
+		FrozenBy: sql.NullString{String: changedBy, Valid: status == AccountStatusFrozen && changedBy != ""},
+	})
+	if err != nil {
+		return updated, err
+	}
+
+	err = appendAuditLog(ctx, q, AuditActionAccountStatus, fmt.Sprintf("account:%d", account.ID), account, updated)
+	return updated, err
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"SimpleBank/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransitionAccountStatus(t *testing.T) {
+	require.True(t, CanTransitionAccountStatus(AccountStatusActive, AccountStatusFrozen))
+	require.True(t, CanTransitionAccountStatus(AccountStatusActive, AccountStatusClosed))
+	require.True(t, CanTransitionAccountStatus(AccountStatusFrozen, AccountStatusActive))
+	require.True(t, CanTransitionAccountStatus(AccountStatusClosed, AccountStatusActive))
+
+	// 冻结的账户必须先解冻才能关闭，状态不能转换为自身
+	require.False(t, CanTransitionAccountStatus(AccountStatusFrozen, AccountStatusClosed))
+	require.False(t, CanTransitionAccountStatus(AccountStatusClosed, AccountStatusFrozen))
+	require.False(t, CanTransitionAccountStatus(AccountStatusActive, AccountStatusActive))
+	require.False(t, CanTransitionAccountStatus(AccountStatusActive, "deleted"))
+}
+
+func TestUpdateAccountStatusTx(t *testing.T) {
+	account := createRandomAccount(t)
+	require.Equal(t, AccountStatusActive, account.Status)
+
+	// 冻结账户，记录冻结账户的用户
+	frozen, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:  account.ID,
+		FromStatus: AccountStatusActive,
+		Status:     AccountStatusFrozen,
+		ChangedBy:  account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusFrozen, frozen.Status)
+	require.Equal(t, account.Owner, frozen.FrozenBy.String)
+
+	// 冻结的账户不能直接关闭
+	_, err = testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:  account.ID,
+		FromStatus: AccountStatusFrozen,
+		Status:     AccountStatusClosed,
+	})
+	require.ErrorIs(t, err, ErrInvalidAccountStatusTransition)
+
+	// 账户不是关闭状态，不能重新开启
+	_, err = testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:  account.ID,
+		FromStatus: AccountStatusClosed,
+		Status:     AccountStatusActive,
+	})
+	require.ErrorIs(t, err, ErrInvalidAccountStatusTransition)
+
+	// 解冻账户
+	active, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:  account.ID,
+		FromStatus: AccountStatusFrozen,
+		Status:     AccountStatusActive,
+		ChangedBy:  account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, active.Status)
+	require.False(t, active.FrozenBy.Valid)
+}
+
+func TestUnfreezeBankerFreeze(t *testing.T) {
+	account := createRandomAccount(t)
+	banker := createRandomUser(t)
+
+	_, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:       account.ID,
+		FromStatus:      AccountStatusActive,
+		Status:          AccountStatusFrozen,
+		ChangedBy:       banker.Username,
+		ChangedByBanker: true,
+	})
+	require.NoError(t, err)
+
+	// 客户不能解冻银行职员冻结的账户
+	_, err = testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:  account.ID,
+		FromStatus: AccountStatusFrozen,
+		Status:     AccountStatusActive,
+		ChangedBy:  account.Owner,
+	})
+	require.ErrorIs(t, err, ErrAccountFrozenByOther)
+
+	// 银行职员可以解冻
+	active, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:       account.ID,
+		FromStatus:      AccountStatusFrozen,
+		Status:          AccountStatusActive,
+		ChangedBy:       banker.Username,
+		ChangedByBanker: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, active.Status)
+}
+
+func TestUpdateAccountStatusTrigger(t *testing.T) {
+	account := createRandomAccount(t)
+	_, err := testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		ID:     account.ID,
+		Status: AccountStatusFrozen,
+	})
+	require.NoError(t, err)
+
+	// 绕过 Store 直接修改状态时，数据库中的触发器也会拒绝不合法的状态转换
+	_, err = testQueries.UpdateAccountStatus(context.Background(), UpdateAccountStatusParams{
+		ID:     account.ID,
+		Status: AccountStatusClosed,
+	})
+	require.Error(t, err)
+}
+
+func TestTransferTxAccountNotActive(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	_, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:  account2.ID,
+		FromStatus: AccountStatusActive,
+		Status:     AccountStatusFrozen,
+	})
+	require.NoError(t, err)
+
+	// 不能向冻结的账户转入，也不能从冻结的账户转出
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account1.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	// 事务应该被回滚，两个账户的余额都没有发生变化
+	updatedAccount1, err := testQueries.GetAccountForUpdate(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+}
+
+func TestCloseAccountTx(t *testing.T) {
+	account := createRandomAccount(t)
+	// sweep 账户必须和关闭的账户拥有相同的货币类型
+	sweepAccount, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Balance:  util.RandomMoney(),
+		Currency: account.Currency,
+	})
+	require.NoError(t, err)
+
+	// 账户还有余额且没有指定 sweep 账户时，不能关闭
+	_, err = testStore.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrAccountBalanceNotZero)
+
+	// 将剩余余额转入 sweep 账户后关闭
+	result, err := testStore.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: &sweepAccount.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, result.Account.Status)
+	require.Zero(t, result.Account.Balance)
+	require.NotNil(t, result.SweepTransfer)
+	require.Equal(t, account.Balance, result.SweepTransfer.Transfer.Amount)
+	require.Equal(t, sweepAccount.Balance+account.Balance, result.SweepTransfer.ToAccount.Balance)
+
+	// 已关闭的账户不能再次关闭
+	_, err = testStore.CloseAccountTx(context.Background(), CloseAccountTxParams{AccountID: account.ID})
+	require.ErrorIs(t, err, ErrInvalidAccountStatusTransition)
+
+	// 重新开启已关闭的账户
+	reopened, err := testStore.UpdateAccountStatusTx(context.Background(), UpdateAccountStatusTxParams{
+		AccountID:  account.ID,
+		FromStatus: AccountStatusClosed,
+		Status:     AccountStatusActive,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusActive, reopened.Status)
+	require.Zero(t, reopened.Balance)
+}
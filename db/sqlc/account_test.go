@@ -6,7 +6,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/require"
 )
 
@@ -88,46 +87,69 @@ func TestUpdateAccount(t *testing.T) {
 	require.Equal(t, account2.Balance, arg.Balance)
 }
 
-func TestDeleteAccount(t *testing.T) {
-	// 创建随机用户并且返回创建的 account 对象
-	account1 := createRandomAccount(t)
-
-	// 根据创建的用户 account1 的 ID 项删除记录
-	err := testQueries.DeleteAccount(context.Background(), account1.ID)
-	// 调用 testify 包中的子包 require 的 NoError() ，判断是否没有产生错误
-	require.NoError(t, err)
-
-	// 根据创建的用户 account1 的 ID 项查询记录
-	account2, err := testQueries.GetAccountForUpdate(context.Background(), account1.ID)
-	// 调用 testify 包中的子包 require 的 Error() ，判断是否产生错误
-	require.Error(t, err)
-	// 调用 testify 包中的子包 require 的 EqualError() ，判断产生的错误是否为 pgx.ErrNoRows.Error()
-	require.EqualError(t, err, pgx.ErrNoRows.Error())
-	// 调用 testify 包中的子包 require 的 Empty() ，判断查询到的 account2 是否为空
-	require.Empty(t, account2)
-}
-
 func TestListAccounts(t *testing.T) {
-	// 创建多个账号（这里指定为 10 个）
+	// 创建多个账号（这里指定为 10 个），记录最后一个账号用于按所有者查询
+	var lastAccount Account
 	for i := 0; i < 10; i++ {
-		createRandomAccount(t)
+		lastAccount = createRandomAccount(t)
 	}
 
 	// 指定查询参数
 	arg := ListAccountsParams{
+		Owner:  lastAccount.Owner,
 		Limit:  5,
-		Offset: 5,
+		Offset: 0,
 	}
 
 	// 根据指定的查询参数进行查询
 	accounts, err := testQueries.ListAccounts(context.Background(), arg)
 	// 调用 testify 包中的子包 require 的 NoError() ，判断是否没有产生错误
 	require.NoError(t, err)
-	// 调用 testify 包中的子包 require 的 Len() ，判断返回的记录数是否为指定的记录数
-	require.Len(t, accounts, 5)
+	// 调用 testify 包中的子包 require 的 NotEmpty() ，判断是否查询到了记录
+	require.NotEmpty(t, accounts)
 
-	// 循环调用 testify 包中的子包 require 的 NotEmpty() ，判断每条记录是否不为空
+	// 循环调用 testify 包中的子包 require 的 NotEmpty() ，判断每条记录是否不为空且属于指定的所有者
 	for _, account := range accounts {
 		require.NotEmpty(t, account)
+		require.Equal(t, lastAccount.Owner, account.Owner)
+	}
+}
+
+func TestListAccountsCursor(t *testing.T) {
+	// 每个所有者的每种货币只能有一个账户，为同一个所有者创建所有货币的账户
+	user := createRandomUser(t)
+	var accounts []Account
+	for _, currency := range []string{util.USD, util.EUR, util.CAD} {
+		arg := CreateAccountParams{
+			Owner:    user.Username,
+			Balance:  util.RandomMoney(),
+			Currency: currency,
+		}
+		account, err := testQueries.CreateAccount(context.Background(), arg)
+		require.NoError(t, err)
+		accounts = append(accounts, account)
+	}
+
+	// 从第 1 个账户的 id 之后开始查询，只返回之后的账户
+	arg := ListAccountsParams{
+		Owner:   user.Username,
+		AfterID: accounts[0].ID,
+		Limit:   10,
 	}
+	result, err := testQueries.ListAccounts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, accounts[1:], result)
+}
+
+func TestListAccountCurrencies(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	// 不存在的账户不会出现在结果中
+	rows, err := testQueries.ListAccountCurrencies(context.Background(), []int64{account1.ID, account2.ID, 0})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []ListAccountCurrenciesRow{
+		{ID: account1.ID, Currency: account1.Currency},
+		{ID: account2.ID, Currency: account2.Currency},
+	}, rows)
 }
@@ -0,0 +1,23 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// CreateAccountTx 在一个事务中创建账户并写入审计日志
+func (store *SQLStore) CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	var result Account
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.CreateAccount(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return appendAuditLog(ctx, q, AuditActionAccountCreate, fmt.Sprintf("account:%d", result.ID), nil, result)
+	})
+
+	return result, err
+}
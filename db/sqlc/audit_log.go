@@ -0,0 +1,187 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 定义审计日志的所有操作
+const (
+	AuditActionLedgerPost    = "ledger.post"
+	AuditActionAccountCreate = "account.create"
+	AuditActionAccountStatus = "account.status"
+	AuditActionUserCreate    = "user.create"
+	AuditActionUserUpdate    = "user.update"
+)
+
+// 定义没有登录用户的操作在审计日志中记录的操作者
+const (
+	// 后台任务等不是由请求触发的操作
+	AuditActorSystem = "system"
+	// 注册、验证邮箱等不需要登录的请求
+	AuditActorAnonymous = "anonymous"
+)
+
+// auditLogLockID 是封存审计日志时使用的事务级咨询锁的 ID ，只有封存程序之间会争用该锁
+const auditLogLockID = 7_291_001
+
+// AuditMetadata 是写入审计日志时从请求中获取的信息
+type AuditMetadata struct {
+	Actor     string
+	RequestID string
+}
+
+type auditMetadataKey struct{}
+
+// WithAuditMetadata 返回一个带有审计信息的 ctx ，之后使用该 ctx 的事务写入的审计日志都会记录这些信息
+func WithAuditMetadata(ctx context.Context, metadata AuditMetadata) context.Context {
+	return context.WithValue(ctx, auditMetadataKey{}, metadata)
+}
+
+// AuditMetadataFromContext 返回 ctx 中的审计信息，没有审计信息或者没有操作者时操作者为 AuditActorSystem
+func AuditMetadataFromContext(ctx context.Context) AuditMetadata {
+	metadata, _ := ctx.Value(auditMetadataKey{}).(AuditMetadata)
+	if metadata.Actor == "" {
+		metadata.Actor = AuditActorSystem
+	}
+	return metadata
+}
+
+// AuditLogHash 计算一行审计日志的哈希值，包括上一行的哈希值以及本行除了 ID 和哈希值之外的所有内容
+// 各个字段编码为一个 JSON 字符串数组，避免字段之间的拼接产生歧义
+func AuditLogHash(entry AuditLog) string {
+	content, _ := json.Marshal([]string{
+		entry.PrevHash,
+		entry.Actor,
+		entry.Action,
+		entry.Target,
+		entry.RequestID,
+		entry.Before,
+		entry.After,
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// appendAuditLog 使用传入的事务查询对象写入一行未封存的审计日志，与被审计的操作在同一个事务中提交或者回滚
+// before 和 after 分别为操作之前和之后的对象，没有时为 nil
+// 写入时不读取哈希链的尾部，也不持有全局的锁，因此并发的事务不会因为审计日志而互相等待
+// 哈希值由 SealAuditLogTx 在事务提交之后计算，在封存之前该行还不受哈希链的保护
+func appendAuditLog(ctx context.Context, q *Queries, action, target string, before, after interface{}) error {
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return fmt.Errorf("cannot encode audit log before: %w", err)
+	}
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return fmt.Errorf("cannot encode audit log after: %w", err)
+	}
+
+	metadata := AuditMetadataFromContext(ctx)
+	_, err = q.CreateAuditLog(ctx, CreateAuditLogParams{
+		Actor:     metadata.Actor,
+		Action:    action,
+		Target:    target,
+		RequestID: metadata.RequestID,
+		Before:    string(beforeJSON),
+		After:     string(afterJSON),
+		CreatedAt: time.Now(),
+	})
+	return err
+}
+
+// SealAuditLogTx 在一个事务中将最多 limit 行未封存的审计日志按照 id 的顺序链接到哈希链的尾部，返回封存的行数
+// 哈希链的顺序由封存时分配的 seq 决定，而不是 id ：id 较小的事务可能较晚提交，它写入的行会在之后的封存中链接到尾部
+// 封存程序之间使用事务级的咨询锁互斥，写入审计日志的事务不会等待该锁
+func (store *SQLStore) SealAuditLogTx(ctx context.Context, limit int32) (int, error) {
+	var sealed int
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.LockAuditLog(ctx, auditLogLockID)
+		if err != nil {
+			return err
+		}
+
+		var seq int64
+		prevHash := ""
+		last, err := q.GetLastSealedAuditLog(ctx)
+		switch {
+		case err == nil:
+			seq = *last.Seq
+			prevHash = last.Hash
+		case !errors.Is(err, pgx.ErrNoRows):
+			return err
+		}
+
+		entries, err := q.ListUnsealedAuditLogs(ctx, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			seq++
+			entry.PrevHash = prevHash
+			entry.Hash = AuditLogHash(entry)
+
+			_, err = q.SealAuditLog(ctx, SealAuditLogParams{
+				ID:       entry.ID,
+				Seq:      seq,
+				PrevHash: entry.PrevHash,
+				Hash:     entry.Hash,
+			})
+			if err != nil {
+				return err
+			}
+			prevHash = entry.Hash
+		}
+
+		sealed = len(entries)
+		return nil
+	})
+
+	return sealed, err
+}
+
+// auditUser 是审计日志中记录的用户信息，不包括密码的哈希值
+type auditUser struct {
+	Username        string    `json:"username"`
+	FullName        string    `json:"full_name"`
+	Email           string    `json:"email"`
+	Role            string    `json:"role"`
+	IsEmailVerified bool      `json:"is_email_verified"`
+	PasswordChanged time.Time `json:"password_changed_at"`
+}
+
+// newAuditUser 将用户转换为审计日志中记录的用户信息
+func newAuditUser(user User) auditUser {
+	return auditUser{
+		Username:        user.Username,
+		FullName:        user.FullName,
+		Email:           user.Email,
+		Role:            user.Role,
+		IsEmailVerified: user.IsEmailVerified,
+		PasswordChanged: user.PasswordChangedAt,
+	}
+}
+
+// auditAccountBalance 是日记账分录在审计日志中记录的账户余额
+type auditAccountBalance struct {
+	AccountID int64  `json:"account_id"`
+	Currency  string `json:"currency"`
+	Balance   int64  `json:"balance"`
+}
+
+// auditJournal 是日记账分录在审计日志中记录的内容，Balances 为记账之后的账户余额
+type auditJournal struct {
+	JournalEntry JournalEntry          `json:"journal_entry"`
+	Entries      []Entry               `json:"entries"`
+	Balances     []auditAccountBalance `json:"balances"`
+}
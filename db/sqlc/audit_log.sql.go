@@ -0,0 +1,225 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: audit_log.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const createAuditLog = `-- name: CreateAuditLog :one
+INSERT INTO audit_log (
+  actor,
+  action,
+  target,
+  request_id,
+  before,
+  after,
+  created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, actor, action, target, request_id, before, after, prev_hash, hash, created_at, seq
+`
+
+type CreateAuditLogParams struct {
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	RequestID string    `json:"request_id"`
+	Before    string    `json:"before"`
+	After     string    `json:"after"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 写入一行未封存的审计日志，哈希值由封存程序在之后计算
+func (q *Queries) CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error) {
+	row := q.db.QueryRow(ctx, createAuditLog,
+		arg.Actor,
+		arg.Action,
+		arg.Target,
+		arg.RequestID,
+		arg.Before,
+		arg.After,
+		arg.CreatedAt,
+	)
+	var i AuditLog
+	err := row.Scan(
+		&i.ID,
+		&i.Actor,
+		&i.Action,
+		&i.Target,
+		&i.RequestID,
+		&i.Before,
+		&i.After,
+		&i.PrevHash,
+		&i.Hash,
+		&i.CreatedAt,
+		&i.Seq,
+	)
+	return i, err
+}
+
+const getLastSealedAuditLog = `-- name: GetLastSealedAuditLog :one
+SELECT id, actor, action, target, request_id, before, after, prev_hash, hash, created_at, seq FROM audit_log
+WHERE seq IS NOT NULL
+ORDER BY seq DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastSealedAuditLog(ctx context.Context) (AuditLog, error) {
+	row := q.db.QueryRow(ctx, getLastSealedAuditLog)
+	var i AuditLog
+	err := row.Scan(
+		&i.ID,
+		&i.Actor,
+		&i.Action,
+		&i.Target,
+		&i.RequestID,
+		&i.Before,
+		&i.After,
+		&i.PrevHash,
+		&i.Hash,
+		&i.CreatedAt,
+		&i.Seq,
+	)
+	return i, err
+}
+
+const listAuditLogs = `-- name: ListAuditLogs :many
+SELECT id, actor, action, target, request_id, before, after, prev_hash, hash, created_at, seq FROM audit_log
+WHERE seq > $1::bigint
+ORDER BY seq
+LIMIT $2
+`
+
+type ListAuditLogsParams struct {
+	AfterSeq int64 `json:"after_seq"`
+	Limit    int32 `json:"limit"`
+}
+
+// 按照哈希链的顺序分页读取已经封存的审计日志，从 after_seq 之后开始
+func (q *Queries) ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuditLog, error) {
+	rows, err := q.db.Query(ctx, listAuditLogs, arg.AfterSeq, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditLog{}
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.Target,
+			&i.RequestID,
+			&i.Before,
+			&i.After,
+			&i.PrevHash,
+			&i.Hash,
+			&i.CreatedAt,
+			&i.Seq,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnsealedAuditLogs = `-- name: ListUnsealedAuditLogs :many
+SELECT id, actor, action, target, request_id, before, after, prev_hash, hash, created_at, seq FROM audit_log
+WHERE seq IS NULL
+ORDER BY id
+LIMIT $1
+`
+
+// 按照 id 的顺序读取已经提交但还没有封存的审计日志
+func (q *Queries) ListUnsealedAuditLogs(ctx context.Context, limit int32) ([]AuditLog, error) {
+	rows, err := q.db.Query(ctx, listUnsealedAuditLogs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuditLog{}
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.Target,
+			&i.RequestID,
+			&i.Before,
+			&i.After,
+			&i.PrevHash,
+			&i.Hash,
+			&i.CreatedAt,
+			&i.Seq,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAuditLog = `-- name: LockAuditLog :exec
+SELECT pg_advisory_xact_lock($1::bigint)
+`
+
+// 在事务结束之前独占审计日志的哈希链尾部，保证同一时间只有一个封存程序在延长哈希链，哈希链不会分叉
+func (q *Queries) LockAuditLog(ctx context.Context, lockID int64) error {
+	_, err := q.db.Exec(ctx, lockAuditLog, lockID)
+	return err
+}
+
+const sealAuditLog = `-- name: SealAuditLog :one
+UPDATE audit_log
+SET seq = $1::bigint,
+    prev_hash = $2,
+    hash = $3
+WHERE id = $4 AND seq IS NULL
+RETURNING id, actor, action, target, request_id, before, after, prev_hash, hash, created_at, seq
+`
+
+type SealAuditLogParams struct {
+	Seq      int64  `json:"seq"`
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+	ID       int64  `json:"id"`
+}
+
+// 将一行审计日志链接到哈希链的尾部，已经封存的行不能再次封存
+func (q *Queries) SealAuditLog(ctx context.Context, arg SealAuditLogParams) (AuditLog, error) {
+	row := q.db.QueryRow(ctx, sealAuditLog,
+		arg.Seq,
+		arg.PrevHash,
+		arg.Hash,
+		arg.ID,
+	)
+	var i AuditLog
+	err := row.Scan(
+		&i.ID,
+		&i.Actor,
+		&i.Action,
+		&i.Target,
+		&i.RequestID,
+		&i.Before,
+		&i.After,
+		&i.PrevHash,
+		&i.Hash,
+		&i.CreatedAt,
+		&i.Seq,
+	)
+	return i, err
+}
@@ -0,0 +1,155 @@
+package db
+
+import (
+	"SimpleBank/util"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// auditLogColumns 是读取审计日志时扫描的所有列
+const auditLogColumns = "id, actor, action, target, request_id, before, after, prev_hash, hash, created_at, seq"
+
+// getAuditLog 读取满足 where 条件的一行审计日志
+func getAuditLog(t *testing.T, where string, args ...interface{}) AuditLog {
+	var entry AuditLog
+	err := testQueries.db.QueryRow(context.Background(), "SELECT "+auditLogColumns+" FROM audit_log WHERE "+where, args...).Scan(
+		&entry.ID, &entry.Actor, &entry.Action, &entry.Target, &entry.RequestID, &entry.Before, &entry.After, &entry.PrevHash, &entry.Hash, &entry.CreatedAt, &entry.Seq,
+	)
+	require.NoError(t, err)
+	return entry
+}
+
+// lastAuditLog 返回最后写入的一行审计日志
+func lastAuditLog(t *testing.T) AuditLog {
+	return getAuditLog(t, "id = (SELECT max(id) FROM audit_log)")
+}
+
+// sealAllAuditLogs 封存所有未封存的审计日志
+func sealAllAuditLogs(t *testing.T) {
+	for {
+		sealed, err := testStore.SealAuditLogTx(context.Background(), 100)
+		require.NoError(t, err)
+		if sealed < 100 {
+			return
+		}
+	}
+}
+
+func TestCreateAccountTxAuditLog(t *testing.T) {
+	user := createRandomUser(t)
+	ctx := WithAuditMetadata(context.Background(), AuditMetadata{Actor: user.Username, RequestID: util.RandomString(12)})
+
+	account, err := testStore.CreateAccountTx(ctx, CreateAccountParams{
+		Owner:    user.Username,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+
+	entry := lastAuditLog(t)
+	require.Equal(t, AuditActionAccountCreate, entry.Action)
+	require.Equal(t, fmt.Sprintf("account:%d", account.ID), entry.Target)
+	require.Equal(t, user.Username, entry.Actor)
+	require.Equal(t, AuditMetadataFromContext(ctx).RequestID, entry.RequestID)
+	require.Equal(t, "null", entry.Before)
+
+	var after Account
+	require.NoError(t, json.Unmarshal([]byte(entry.After), &after))
+	require.Equal(t, account.ID, after.ID)
+
+	// 写入时没有计算哈希值，封存之后重新计算的哈希值与保存的一致
+	require.Nil(t, entry.Seq)
+	require.Empty(t, entry.Hash)
+	sealAllAuditLogs(t)
+	entry = getAuditLog(t, "id = $1", entry.ID)
+	require.NotNil(t, entry.Seq)
+	require.Equal(t, AuditLogHash(entry), entry.Hash)
+}
+
+func TestTransferTxAuditLog(t *testing.T) {
+	account1 := fundAccount(t, createAccountInCurrency(t, util.USD), 100)
+	account2 := createAccountInCurrency(t, util.USD)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+
+	// 没有请求信息的操作由系统执行，并且链接到上一行审计日志
+	entry := lastAuditLog(t)
+	require.Equal(t, AuditActionLedgerPost, entry.Action)
+	require.Equal(t, fmt.Sprintf("journal_entry:%d", *result.FromEntry.JournalEntryID), entry.Target)
+	require.Equal(t, AuditActorSystem, entry.Actor)
+
+	// 封存之后链接到哈希链中的上一行
+	sealAllAuditLogs(t)
+	entry = getAuditLog(t, "id = $1", entry.ID)
+	prev := getAuditLog(t, "seq = $1", *entry.Seq-1)
+	require.Equal(t, prev.Hash, entry.PrevHash)
+
+	var before []auditAccountBalance
+	require.NoError(t, json.Unmarshal([]byte(entry.Before), &before))
+	require.Len(t, before, 2)
+	var after auditJournal
+	require.NoError(t, json.Unmarshal([]byte(entry.After), &after))
+	require.Len(t, after.Entries, 2)
+	require.Len(t, after.Balances, 2)
+	for i := range before {
+		require.Equal(t, before[i].AccountID, after.Balances[i].AccountID)
+	}
+}
+
+func TestSealAuditLogTx(t *testing.T) {
+	sealAllAuditLogs(t)
+	last, err := testQueries.GetLastSealedAuditLog(context.Background())
+	require.NoError(t, err)
+
+	createRandomUser(t)
+	createRandomUser(t)
+	sealed, err := testStore.SealAuditLogTx(context.Background(), 100)
+	require.NoError(t, err)
+	require.Equal(t, 2, sealed)
+
+	// 新封存的行按照 id 的顺序链接到原来的哈希链尾部，seq 连续
+	first := getAuditLog(t, "seq = $1", *last.Seq+1)
+	second := getAuditLog(t, "seq = $1", *last.Seq+2)
+	require.Less(t, first.ID, second.ID)
+	require.Equal(t, last.Hash, first.PrevHash)
+	require.Equal(t, first.Hash, second.PrevHash)
+	require.Equal(t, AuditLogHash(second), second.Hash)
+
+	// 没有未封存的行时不做任何修改
+	sealed, err = testStore.SealAuditLogTx(context.Background(), 100)
+	require.NoError(t, err)
+	require.Zero(t, sealed)
+}
+
+func TestAuditLogAppendOnly(t *testing.T) {
+	createRandomUser(t)
+	unsealed := lastAuditLog(t)
+
+	// 未封存的行只能被封存，不能修改内容
+	_, err := testQueries.db.Exec(context.Background(), "UPDATE audit_log SET actor = 'attacker' WHERE id = $1", unsealed.ID)
+	require.Error(t, err)
+
+	sealAllAuditLogs(t)
+	entry := getAuditLog(t, "id = $1", unsealed.ID)
+
+	// 已经封存的行不能被修改、重新封存、删除或者清空
+	_, err = testQueries.db.Exec(context.Background(), "UPDATE audit_log SET actor = 'attacker' WHERE id = $1", entry.ID)
+	require.Error(t, err)
+	_, err = testQueries.SealAuditLog(context.Background(), SealAuditLogParams{ID: entry.ID, Seq: *entry.Seq + 1000, Hash: "forged"})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = testQueries.db.Exec(context.Background(), "DELETE FROM audit_log WHERE id = $1", entry.ID)
+	require.Error(t, err)
+	_, err = testQueries.db.Exec(context.Background(), "TRUNCATE audit_log")
+	require.Error(t, err)
+
+	require.Equal(t, entry, getAuditLog(t, "id = $1", entry.ID))
+}
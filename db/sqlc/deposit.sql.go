@@ -0,0 +1,130 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: deposit.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const createDeposit = `-- name: CreateDeposit :one
+INSERT INTO deposits (
+  account_id,
+  amount,
+  external_reference,
+  status,
+  created_by,
+  resolved_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, account_id, amount, external_reference, status, created_by, created_at, resolved_at
+`
+
+type CreateDepositParams struct {
+	AccountID         int64      `json:"account_id"`
+	Amount            int64      `json:"amount"`
+	ExternalReference string     `json:"external_reference"`
+	Status            string     `json:"status"`
+	CreatedBy         string     `json:"created_by"`
+	ResolvedAt        *time.Time `json:"resolved_at"`
+}
+
+func (q *Queries) CreateDeposit(ctx context.Context, arg CreateDepositParams) (Deposit, error) {
+	row := q.db.QueryRow(ctx, createDeposit,
+		arg.AccountID,
+		arg.Amount,
+		arg.ExternalReference,
+		arg.Status,
+		arg.CreatedBy,
+		arg.ResolvedAt,
+	)
+	var i Deposit
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.ExternalReference,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
+
+const getDeposit = `-- name: GetDeposit :one
+SELECT id, account_id, amount, external_reference, status, created_by, created_at, resolved_at FROM deposits
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetDeposit(ctx context.Context, id int64) (Deposit, error) {
+	row := q.db.QueryRow(ctx, getDeposit, id)
+	var i Deposit
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.ExternalReference,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
+
+const getDepositForUpdate = `-- name: GetDepositForUpdate :one
+SELECT id, account_id, amount, external_reference, status, created_by, created_at, resolved_at FROM deposits
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetDepositForUpdate(ctx context.Context, id int64) (Deposit, error) {
+	row := q.db.QueryRow(ctx, getDepositForUpdate, id)
+	var i Deposit
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.ExternalReference,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
+
+const resolveDeposit = `-- name: ResolveDeposit :one
+UPDATE deposits
+SET
+  status = $1,
+  resolved_at = now()
+WHERE id = $2 AND status = 'pending'
+RETURNING id, account_id, amount, external_reference, status, created_by, created_at, resolved_at
+`
+
+type ResolveDepositParams struct {
+	Status string `json:"status"`
+	ID     int64  `json:"id"`
+}
+
+// 只有等待入账的存款可以修改状态
+func (q *Queries) ResolveDeposit(ctx context.Context, arg ResolveDepositParams) (Deposit, error) {
+	row := q.db.QueryRow(ctx, resolveDeposit, arg.Status, arg.ID)
+	var i Deposit
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.ExternalReference,
+		&i.Status,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,247 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// 定义存款的所有状态
+const (
+	DepositStatusPending = "pending"
+	DepositStatusSettled = "settled"
+	DepositStatusFailed  = "failed"
+)
+
+// DepositTxParams 包含向账户存款所需要的输入参数
+type DepositTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// must be positive, in the currency of the account
+	Amount            int64  `json:"amount"`
+	ExternalReference string `json:"external_reference"`
+	CreatedBy         string `json:"created_by"`
+	// 为 true 时资金已经到账，直接存入账户，否则存款等待外部系统确认之后再入账
+	Settled bool `json:"settled"`
+}
+
+// DepositTxResult 包含存款事务的结果
+type DepositTxResult struct {
+	Deposit Deposit `json:"deposit"`
+	Account Account `json:"account"`
+	// 存入账户的条目，存款等待入账或者失败时为 nil
+	Entry *Entry `json:"entry"`
+}
+
+// DepositTx 在一个事务中创建一笔存款并记录对应的日记账分录
+// 已到账的存款从现金账户直接存入客户账户，等待入账的存款先从现金账户记入清算账户，客户账户的余额不变
+func (store *SQLStore) DepositTx(ctx context.Context, arg DepositTxParams) (DepositTxResult, error) {
+	var result DepositTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// 等待入账的存款不修改客户账户，只读取账户检查状态和货币，不锁定账户，避免与按照 ID 顺序加锁的分录产生死锁
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+		if account.Status != AccountStatusActive {
+			return fmt.Errorf("%w: account [%d] is %s", ErrAccountNotActive, account.ID, account.Status)
+		}
+
+		status := DepositStatusPending
+		var resolvedAt *time.Time
+		if arg.Settled {
+			status = DepositStatusSettled
+			now := time.Now()
+			resolvedAt = &now
+		}
+		result.Deposit, err = q.CreateDeposit(ctx, CreateDepositParams{
+			AccountID:         arg.AccountID,
+			Amount:            arg.Amount,
+			ExternalReference: arg.ExternalReference,
+			Status:            status,
+			CreatedBy:         arg.CreatedBy,
+			ResolvedAt:        resolvedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		cash, err := systemAccount(ctx, q, SystemAccountCash, account.Currency)
+		if err != nil {
+			return err
+		}
+		if !arg.Settled {
+			clearing, err := systemAccount(ctx, q, SystemAccountClearing, account.Currency)
+			if err != nil {
+				return err
+			}
+			_, err = postJournal(ctx, q, PostJournalParams{
+				Kind:        JournalKindDeposit,
+				Description: fmt.Sprintf("pending deposit %d", result.Deposit.ID),
+				Lines: []JournalLine{
+					{AccountID: cash.ID, Amount: -arg.Amount},
+					{AccountID: clearing.ID, Amount: arg.Amount},
+				},
+			})
+			result.Account = account
+			return err
+		}
+
+		result.Account, result.Entry, err = creditDeposit(ctx, q, result.Deposit, cash.ID)
+		return err
+	})
+
+	return result, err
+}
+
+// ResolveDepositTxParams 包含修改等待入账的存款状态所需要的输入参数
+type ResolveDepositTxParams struct {
+	DepositID int64 `json:"deposit_id"`
+	// DepositStatusSettled 或者 DepositStatusFailed
+	Status string `json:"status"`
+}
+
+// ResolveDepositTx 在一个事务中将等待入账的存款标记为已入账或者失败
+// 入账时将清算账户中的资金存入客户账户，失败时将清算账户中的资金退回现金账户
+func (store *SQLStore) ResolveDepositTx(ctx context.Context, arg ResolveDepositTxParams) (DepositTxResult, error) {
+	var result DepositTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// 锁定存款，同一笔存款只能被处理一次
+		deposit, err := q.GetDepositForUpdate(ctx, arg.DepositID)
+		if err != nil {
+			return err
+		}
+		if deposit.Status != DepositStatusPending {
+			return fmt.Errorf("%w: deposit is %s", ErrDepositNotPending, deposit.Status)
+		}
+
+		result.Deposit, err = q.ResolveDeposit(ctx, ResolveDepositParams{
+			ID:     deposit.ID,
+			Status: arg.Status,
+		})
+		if err != nil {
+			return err
+		}
+
+		account, err := q.GetAccount(ctx, deposit.AccountID)
+		if err != nil {
+			return err
+		}
+		clearing, err := systemAccount(ctx, q, SystemAccountClearing, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		switch arg.Status {
+		case DepositStatusSettled:
+			result.Account, result.Entry, err = creditDeposit(ctx, q, result.Deposit, clearing.ID)
+			return err
+		case DepositStatusFailed:
+			cash, err := systemAccount(ctx, q, SystemAccountCash, account.Currency)
+			if err != nil {
+				return err
+			}
+			_, err = postJournal(ctx, q, PostJournalParams{
+				Kind:        JournalKindDeposit,
+				Description: fmt.Sprintf("failed deposit %d", deposit.ID),
+				Lines: []JournalLine{
+					{AccountID: clearing.ID, Amount: -deposit.Amount},
+					{AccountID: cash.ID, Amount: deposit.Amount},
+				},
+			})
+			result.Account = account
+			return err
+		}
+		return fmt.Errorf("invalid deposit status: %s", arg.Status)
+	})
+
+	return result, err
+}
+
+// creditDeposit 从 sourceAccountID 系统账户扣除存款的金额并存入客户账户，返回存入之后的客户账户和对应的条目
+func creditDeposit(ctx context.Context, q *Queries, deposit Deposit, sourceAccountID int64) (Account, *Entry, error) {
+	journal, err := postJournal(ctx, q, PostJournalParams{
+		Kind:        JournalKindDeposit,
+		Description: fmt.Sprintf("deposit %d", deposit.ID),
+		Lines: []JournalLine{
+			{AccountID: sourceAccountID, Amount: -deposit.Amount},
+			{AccountID: deposit.AccountID, Amount: deposit.Amount},
+		},
+	})
+	if err != nil {
+		return Account{}, nil, err
+	}
+	return journal.Accounts[1], &journal.Entries[1], nil
+}
+
+// WithdrawTxParams 包含从账户取款所需要的输入参数
+type WithdrawTxParams struct {
+	AccountID int64 `json:"account_id"`
+	// must be positive, in the currency of the account
+	Amount            int64  `json:"amount"`
+	ExternalReference string `json:"external_reference"`
+	CreatedBy         string `json:"created_by"`
+}
+
+// WithdrawTxResult 包含取款事务的结果
+type WithdrawTxResult struct {
+	Withdrawal Withdrawal `json:"withdrawal"`
+	Account    Account    `json:"account"`
+	Entry      Entry      `json:"entry"`
+}
+
+// WithdrawTx 在一个事务中创建一笔取款，从客户账户扣除金额并记入现金账户，账户的余额不能低于允许的透支额度
+// 取款与转出的交易一样受到限额规则的约束，并计入限额的已使用金额和笔数，违反时返回 *TransferLimitError
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg WithdrawTxParams) (WithdrawTxResult, error) {
+	var result WithdrawTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		// 与交易相同，先锁定账户的所有者再锁定账户，并发的交易和取款不能同时通过限额检查
+		owner, err := q.GetUserForUpdate(ctx, account.Owner)
+		if err != nil {
+			return err
+		}
+		err = checkTransferLimits(ctx, q, account, owner.Role, arg.Amount, time.Now())
+		if err != nil {
+			return err
+		}
+
+		cash, err := systemAccount(ctx, q, SystemAccountCash, account.Currency)
+		if err != nil {
+			return err
+		}
+
+		result.Withdrawal, err = q.CreateWithdrawal(ctx, CreateWithdrawalParams{
+			AccountID:         arg.AccountID,
+			Amount:            arg.Amount,
+			ExternalReference: arg.ExternalReference,
+			CreatedBy:         arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		journal, err := postJournal(ctx, q, PostJournalParams{
+			Kind:        JournalKindWithdrawal,
+			Description: fmt.Sprintf("withdrawal %d", result.Withdrawal.ID),
+			Lines: []JournalLine{
+				{AccountID: arg.AccountID, Amount: -arg.Amount},
+				{AccountID: cash.ID, Amount: arg.Amount},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Account, result.Entry = journal.Accounts[0], journal.Entries[0]
+		return nil
+	})
+
+	return result, err
+}
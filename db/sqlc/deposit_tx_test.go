@@ -0,0 +1,125 @@
+package db
+
+import (
+	"SimpleBank/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositTxSettled(t *testing.T) {
+	account := createAccountInCurrency(t, util.USD)
+	cash := requireSystemAccount(t, SystemAccountCash, util.USD)
+
+	result, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:         account.ID,
+		Amount:            100,
+		ExternalReference: util.RandomString(12),
+		CreatedBy:         account.Owner,
+		Settled:           true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, DepositStatusSettled, result.Deposit.Status)
+	require.NotNil(t, result.Deposit.ResolvedAt)
+	require.Equal(t, account.Balance+100, result.Account.Balance)
+
+	// 存入的条目和现金账户的条目属于同一条分录
+	require.NotNil(t, result.Entry)
+	lines, err := testQueries.ListJournalEntryLines(context.Background(), result.Entry.JournalEntryID)
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	require.Equal(t, cash.ID, lines[0].AccountID)
+	require.Equal(t, int64(-100), lines[0].Amount)
+}
+
+func TestDepositTxPending(t *testing.T) {
+	account := createAccountInCurrency(t, util.EUR)
+	reference := util.RandomString(12)
+
+	// 等待入账的存款不修改账户的余额
+	result, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:         account.ID,
+		Amount:            100,
+		ExternalReference: reference,
+		CreatedBy:         account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, DepositStatusPending, result.Deposit.Status)
+	require.Nil(t, result.Deposit.ResolvedAt)
+	require.Nil(t, result.Entry)
+	require.Equal(t, account.Balance, result.Account.Balance)
+
+	// 同一个外部编号只能存款一次
+	_, err = testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:         account.ID,
+		Amount:            100,
+		ExternalReference: reference,
+		CreatedBy:         account.Owner,
+	})
+	require.Error(t, err)
+
+	// 入账之后资金从清算账户存入客户账户
+	settled, err := testStore.ResolveDepositTx(context.Background(), ResolveDepositTxParams{
+		DepositID: result.Deposit.ID,
+		Status:    DepositStatusSettled,
+	})
+	require.NoError(t, err)
+	require.Equal(t, DepositStatusSettled, settled.Deposit.Status)
+	require.NotNil(t, settled.Deposit.ResolvedAt)
+	require.NotNil(t, settled.Entry)
+	require.Equal(t, account.Balance+100, settled.Account.Balance)
+
+	// 同一笔存款只能处理一次
+	_, err = testStore.ResolveDepositTx(context.Background(), ResolveDepositTxParams{
+		DepositID: result.Deposit.ID,
+		Status:    DepositStatusFailed,
+	})
+	require.ErrorIs(t, err, ErrDepositNotPending)
+}
+
+func TestDepositTxFailed(t *testing.T) {
+	account := createAccountInCurrency(t, util.CAD)
+
+	result, err := testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:         account.ID,
+		Amount:            100,
+		ExternalReference: util.RandomString(12),
+		CreatedBy:         account.Owner,
+	})
+	require.NoError(t, err)
+
+	// 存款失败时资金退回现金账户，客户账户的余额不变
+	failed, err := testStore.ResolveDepositTx(context.Background(), ResolveDepositTxParams{
+		DepositID: result.Deposit.ID,
+		Status:    DepositStatusFailed,
+	})
+	require.NoError(t, err)
+	require.Equal(t, DepositStatusFailed, failed.Deposit.Status)
+	require.Nil(t, failed.Entry)
+	require.Equal(t, account.Balance, failed.Account.Balance)
+}
+
+func TestWithdrawTx(t *testing.T) {
+	account := fundAccount(t, createAccountInCurrency(t, util.USD), 100)
+
+	result, err := testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID:         account.ID,
+		Amount:            100,
+		ExternalReference: util.RandomString(12),
+		CreatedBy:         account.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance-100, result.Account.Balance)
+	require.Equal(t, int64(-100), result.Entry.Amount)
+	require.Equal(t, int64(100), result.Withdrawal.Amount)
+
+	// 取款之后账户的余额不能低于允许的透支额度
+	_, err = testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID:         account.ID,
+		Amount:            result.Account.Balance + 1,
+		ExternalReference: util.RandomString(12),
+		CreatedBy:         account.Owner,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
@@ -7,36 +7,73 @@ package db
 
 import (
 	"context"
+	"time"
 )
 
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id,
+  reversal_id,
+  journal_entry_id
 ) VALUES (
-  $1, $2
-) RETURNING id, account_id, amount, created_at
+  $1, $2, $3, $4, $5
+) RETURNING id, account_id, amount, created_at, transfer_id, reversal_id, journal_entry_id
 `
 
 type CreateEntryParams struct {
-	AccountID int64 `json:"account_id"`
-	Amount    int64 `json:"amount"`
+	AccountID      int64  `json:"account_id"`
+	Amount         int64  `json:"amount"`
+	TransferID     *int64 `json:"transfer_id"`
+	ReversalID     *int64 `json:"reversal_id"`
+	JournalEntryID *int64 `json:"journal_entry_id"`
 }
 
 func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error) {
-	row := q.db.QueryRow(ctx, createEntry, arg.AccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createEntry,
+		arg.AccountID,
+		arg.Amount,
+		arg.TransferID,
+		arg.ReversalID,
+		arg.JournalEntryID,
+	)
 	var i Entry
 	err := row.Scan(
 		&i.ID,
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.ReversalID,
+		&i.JournalEntryID,
 	)
 	return i, err
 }
 
+const getAccountBalanceAt = `-- name: GetAccountBalanceAt :one
+SELECT (a.balance - COALESCE(SUM(e.amount), 0))::bigint AS balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= $1
+WHERE a.id = $2
+GROUP BY a.id, a.balance
+`
+
+type GetAccountBalanceAtParams struct {
+	At        time.Time `json:"at"`
+	AccountID int64     `json:"account_id"`
+}
+
+// 用账户当前余额减去指定时间之后的所有条目金额，得到账户在该时间点的余额
+func (q *Queries) GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getAccountBalanceAt, arg.At, arg.AccountID)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
 const getEntry = `-- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id, reversal_id, journal_entry_id FROM entries
 WHERE id = $1 LIMIT 1
 `
 
@@ -48,24 +85,37 @@ func (q *Queries) GetEntry(ctx context.Context, id int64) (Entry, error) {
 		&i.AccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.TransferID,
+		&i.ReversalID,
+		&i.JournalEntryID,
 	)
 	return i, err
 }
 
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id, reversal_id, journal_entry_id FROM entries
+WHERE account_id = $1
+  AND id > $2
 ORDER BY id
-LIMIT $1 /* 进行分页显示，设置想要获取的行数 */
-OFFSET $2 /* 在开始返回结果之前跳过指定的行数 */
+LIMIT $4 /* 进行分页显示，设置想要获取的行数 */
+OFFSET $3 /* 在开始返回结果之前跳过指定的行数 */
 `
 
 type ListEntriesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	AccountID int64 `json:"account_id"`
+	AfterID   int64 `json:"after_id"`
+	Offset    int32 `json:"offset"`
+	Limit     int32 `json:"limit"`
 }
 
+// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询
 func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
-	rows, err := q.db.Query(ctx, listEntries, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listEntries,
+		arg.AccountID,
+		arg.AfterID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +128,188 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
+			&i.ReversalID,
+			&i.JournalEntryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementEntries = `-- name: ListStatementEntries :many
+WITH page AS (
+  SELECT id, account_id, amount, created_at, transfer_id, reversal_id, journal_entry_id FROM entries
+  WHERE entries.account_id = $1
+    AND entries.created_at >= $2
+    AND entries.created_at < $3
+    AND entries.id > $4
+  ORDER BY entries.id
+  LIMIT $6
+  OFFSET $5
+), later AS (
+  /* 当前页之后入账的所有条目的金额总和 */
+  SELECT COALESCE(SUM(entries.amount), 0)::bigint AS total
+  FROM entries
+  WHERE entries.account_id = $1
+    AND entries.id > (SELECT COALESCE(MAX(page.id), 0) FROM page)
+)
+SELECT
+  page.id,
+  page.account_id,
+  page.amount,
+  page.created_at,
+  page.transfer_id,
+  page.reversal_id,
+  counterparty.account_id AS counterparty_account_id,
+  (
+    accounts.balance - later.total
+    - (SUM(page.amount) OVER (ORDER BY page.id DESC) - page.amount)
+  )::bigint AS running_balance
+FROM page
+CROSS JOIN later
+JOIN accounts ON accounts.id = page.account_id
+LEFT JOIN entries AS counterparty
+  ON counterparty.transfer_id = page.transfer_id
+  AND counterparty.reversal_id IS NOT DISTINCT FROM page.reversal_id
+  AND counterparty.id <> page.id
+ORDER BY page.id
+`
+
+type ListStatementEntriesParams struct {
+	AccountID  int64     `json:"account_id"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	AfterID    int64     `json:"after_id"`
+	PageOffset int32     `json:"page_offset"`
+	PageSize   int32     `json:"page_size"`
+}
+
+type ListStatementEntriesRow struct {
+	ID                    int64     `json:"id"`
+	AccountID             int64     `json:"account_id"`
+	Amount                int64     `json:"amount"`
+	CreatedAt             time.Time `json:"created_at"`
+	TransferID            *int64    `json:"transfer_id"`
+	ReversalID            *int64    `json:"reversal_id"`
+	CounterpartyAccountID *int64    `json:"counterparty_account_id"`
+	RunningBalance        int64     `json:"running_balance"`
+}
+
+// 查询账户在时间范围内的一页条目，并计算每条条目入账之后的账户余额以及交易的对方账户
+// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 page_offset 为 0 并从上一页最后一条记录的 id 之后开始查询
+// 同一笔交易中另一条条目所属的账户即为对方账户，冲正产生的条目只与同一次冲正的另一条条目对应
+func (q *Queries) ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error) {
+	rows, err := q.db.Query(ctx, listStatementEntries,
+		arg.AccountID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.AfterID,
+		arg.PageOffset,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatementEntriesRow{}
+	for rows.Next() {
+		var i ListStatementEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.ReversalID,
+			&i.CounterpartyAccountID,
+			&i.RunningBalance,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatementExportEntries = `-- name: ListStatementExportEntries :many
+WITH batch AS (
+  SELECT id, account_id, amount, created_at, transfer_id, reversal_id, journal_entry_id FROM entries
+  WHERE entries.account_id = $1
+    AND entries.created_at >= $2
+    AND entries.created_at < $3
+    AND entries.id > $4
+  ORDER BY entries.id
+  LIMIT $5
+)
+SELECT
+  batch.id,
+  batch.account_id,
+  batch.amount,
+  batch.created_at,
+  batch.transfer_id,
+  batch.reversal_id,
+  counterparty.account_id AS counterparty_account_id
+FROM batch
+LEFT JOIN entries AS counterparty
+  ON counterparty.transfer_id = batch.transfer_id
+  AND counterparty.reversal_id IS NOT DISTINCT FROM batch.reversal_id
+  AND counterparty.id <> batch.id
+ORDER BY batch.id
+`
+
+type ListStatementExportEntriesParams struct {
+	AccountID int64     `json:"account_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	AfterID   int64     `json:"after_id"`
+	PageSize  int32     `json:"page_size"`
+}
+
+type ListStatementExportEntriesRow struct {
+	ID                    int64     `json:"id"`
+	AccountID             int64     `json:"account_id"`
+	Amount                int64     `json:"amount"`
+	CreatedAt             time.Time `json:"created_at"`
+	TransferID            *int64    `json:"transfer_id"`
+	ReversalID            *int64    `json:"reversal_id"`
+	CounterpartyAccountID *int64    `json:"counterparty_account_id"`
+}
+
+// 导出对账单时从 after_id 之后分批查询账户在时间范围内的条目以及交易的对方账户
+// 不计算入账之后的账户余额，由调用方从期初余额开始逐条累加，避免每一批都重新汇总之后的所有条目
+// 同一笔交易中另一条条目所属的账户即为对方账户，冲正产生的条目只与同一次冲正的另一条条目对应
+func (q *Queries) ListStatementExportEntries(ctx context.Context, arg ListStatementExportEntriesParams) ([]ListStatementExportEntriesRow, error) {
+	rows, err := q.db.Query(ctx, listStatementExportEntries,
+		arg.AccountID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.AfterID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatementExportEntriesRow{}
+	for rows.Next() {
+		var i ListStatementExportEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.ReversalID,
+			&i.CounterpartyAccountID,
 		); err != nil {
 			return nil, err
 		}
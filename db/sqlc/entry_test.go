@@ -4,6 +4,7 @@ import (
 	"SimpleBank/util"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -62,8 +63,9 @@ func TestListEntries(t *testing.T) {
 
 	// 指定查询参数
 	arg := ListEntriesParams{
-		Limit:  5,
-		Offset: 5,
+		AccountID: account.ID,
+		Limit:     5,
+		Offset:    5,
 	}
 
 	// 根据指定的查询参数进行查询
@@ -73,9 +75,162 @@ func TestListEntries(t *testing.T) {
 	// 调用 testify 包中的子包 require 的 Len() ，判断返回的记录数是否为指定的记录数
 	require.Len(t, entries, 5)
 
-	// 循环调用 testify 包中的子包 require 的 NotEmpty() ，判断每条记录是否不为空
+	// 循环调用 testify 包中的子包 require 的 NotEmpty() ，判断每条记录是否不为空且属于指定的账户
 	for _, entry := range entries {
 		require.NotEmpty(t, entry)
+		require.Equal(t, account.ID, entry.AccountID)
 	}
 
 }
+
+func TestListEntriesCursor(t *testing.T) {
+	account := createRandomAccount(t)
+	for i := 0; i < 10; i++ {
+		createRandomEntry(t, account)
+	}
+
+	// 先使用页码分页查询第二页，作为游标分页的预期结果
+	expected, err := testQueries.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: account.ID,
+		Limit:     5,
+		Offset:    5,
+	})
+	require.NoError(t, err)
+
+	// 查询第一页，然后从第一页最后一条记录的 id 之后开始查询下一页
+	firstPage, err := testQueries.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: account.ID,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Len(t, firstPage, 5)
+
+	secondPage, err := testQueries.ListEntries(context.Background(), ListEntriesParams{
+		AccountID: account.ID,
+		AfterID:   firstPage[len(firstPage)-1].ID,
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, expected, secondPage)
+}
+
+func TestListStatementEntries(t *testing.T) {
+	// 创建两个余额足够的账户，并记录创建条目之前的时间作为对账单的开始时间
+	account := fundAccount(t, createRandomAccount(t), 100)
+	other := fundAccount(t, createRandomAccount(t), 100)
+	startTime := time.Now()
+
+	// 通过交易创建多个条目（这里为 4 个），使条目和账户余额保持一致
+	amounts := []int64{50, -20, 30, -10}
+	for _, amount := range amounts {
+		fromAccountID, toAccountID := other.ID, account.ID
+		if amount < 0 {
+			fromAccountID, toAccountID = account.ID, other.ID
+		}
+		_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: fromAccountID,
+			ToAccountID:   toAccountID,
+			Amount:        abs(amount),
+		})
+		require.NoError(t, err)
+	}
+	endTime := time.Now().Add(time.Second)
+
+	// 期初余额为创建条目之前的余额
+	opening, err := testQueries.GetAccountBalanceAt(context.Background(), GetAccountBalanceAtParams{
+		AccountID: account.ID,
+		At:        startTime,
+	})
+	require.NoError(t, err)
+
+	// 分两页查询对账单中的条目
+	page1, err := testQueries.ListStatementEntries(context.Background(), ListStatementEntriesParams{
+		AccountID: account.ID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		AfterID:   0,
+		PageSize:  3,
+	})
+	require.NoError(t, err)
+	require.Len(t, page1, 3)
+
+	page2, err := testQueries.ListStatementEntries(context.Background(), ListStatementEntriesParams{
+		AccountID: account.ID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		AfterID:   page1[len(page1)-1].ID,
+		PageSize:  3,
+	})
+	require.NoError(t, err)
+	require.Len(t, page2, 1)
+
+	// 每条条目之后的余额等于期初余额加上到该条目为止的所有条目金额
+	balance := opening
+	for i, entry := range append(page1, page2...) {
+		require.Equal(t, account.ID, entry.AccountID)
+		balance += entry.Amount
+		require.Equal(t, balance, entry.RunningBalance, "entry %d", i)
+	}
+
+	// 期末余额等于最后一条条目之后的余额
+	closing, err := testQueries.GetAccountBalanceAt(context.Background(), GetAccountBalanceAtParams{
+		AccountID: account.ID,
+		At:        endTime,
+	})
+	require.NoError(t, err)
+	require.Equal(t, balance, closing)
+}
+
+func TestListStatementExportEntries(t *testing.T) {
+	account := fundAccount(t, createRandomAccount(t), 100)
+	other := fundAccount(t, createRandomAccount(t), 100)
+	startTime := time.Now()
+
+	// 通过交易创建 3 个条目
+	for i := 0; i < 3; i++ {
+		_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID: account.ID,
+			ToAccountID:   other.ID,
+			Amount:        10,
+		})
+		require.NoError(t, err)
+	}
+	endTime := time.Now().Add(time.Second)
+
+	// 分两批读取，第二批从第一批最后一条记录的 id 之后开始
+	batch1, err := testQueries.ListStatementExportEntries(context.Background(), ListStatementExportEntriesParams{
+		AccountID: account.ID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		AfterID:   0,
+		PageSize:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, batch1, 2)
+
+	batch2, err := testQueries.ListStatementExportEntries(context.Background(), ListStatementExportEntriesParams{
+		AccountID: account.ID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		AfterID:   batch1[len(batch1)-1].ID,
+		PageSize:  2,
+	})
+	require.NoError(t, err)
+	require.Len(t, batch2, 1)
+
+	for _, entry := range append(batch1, batch2...) {
+		require.Equal(t, account.ID, entry.AccountID)
+		require.Equal(t, int64(-10), entry.Amount)
+		require.NotNil(t, entry.TransferID)
+		require.NotNil(t, entry.CounterpartyAccountID)
+		require.Equal(t, other.ID, *entry.CounterpartyAccountID)
+	}
+}
+
+// abs 返回 amount 的绝对值
+func abs(amount int64) int64 {
+	if amount < 0 {
+		return -amount
+	}
+	return amount
+}
@@ -0,0 +1,30 @@
+package db
+
+import "errors"
+
+var (
+	// ErrInsufficientFunds 表示交易会使转出账户的余额低于其允许的透支额度
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrIdempotencyKeyReused 表示同一个幂等键被用于了不同内容的请求
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+	// ErrAccountNotActive 表示交易的转出账户或者转入账户已被冻结或者关闭
+	ErrAccountNotActive = errors.New("account is not active")
+	// ErrInvalidAccountStatusTransition 表示账户的状态不允许转换为目标状态
+	ErrInvalidAccountStatusTransition = errors.New("invalid account status transition")
+	// ErrAccountFrozenByOther 表示账户由其他用户（例如银行职员）冻结，当前用户不能解冻
+	ErrAccountFrozenByOther = errors.New("account was frozen by another user")
+	// ErrAccountBalanceNotZero 表示账户还有余额或者欠款，不能直接关闭
+	ErrAccountBalanceNotZero = errors.New("account balance is not zero")
+	// ErrTransferAlreadyReversed 表示交易的金额已经全部退款，不能再次冲正
+	ErrTransferAlreadyReversed = errors.New("transfer is already reversed")
+	// ErrReversalExceedsTransfer 表示退款的金额超过了交易剩余可以退款的金额
+	ErrReversalExceedsTransfer = errors.New("reversal amount exceeds the remaining transfer amount")
+	// ErrUnbalancedJournal 表示日记账分录的条目按货币求和不为 0
+	ErrUnbalancedJournal = errors.New("journal entry is not balanced")
+	// ErrDepositNotPending 表示存款已经入账或者失败，不能再修改状态
+	ErrDepositNotPending = errors.New("deposit is not pending")
+	// ErrTransferLimitExceeded 表示交易违反了限额规则，具体违反的规则见 TransferLimitError
+	ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+	// ErrTaskLeaseExpired 表示任务处理完成时租约已经到期，任务已被其他处理器重新领取，本次的处理结果没有被记录
+	ErrTaskLeaseExpired = errors.New("task lease expired")
+)
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+)
+
+// IdempotentTransferTxParams 包含带有幂等键的交易所需要的所有输入参数
+type IdempotentTransferTxParams struct {
+	TransferTxParams
+	// 幂等键属于发起请求的用户，不同用户之间的幂等键互不影响
+	Username string
+	Key      string
+	// 请求内容的散列值，用于判断同一个幂等键是否被用于了不同的请求
+	RequestHash string
+	ExpiresAt   time.Time
+}
+
+// transferRequestCanonical 是计算交易请求散列值时使用的规范格式，金额以最小货币单位表示
+// 请求转换为该格式后计算散列值，金额的十进制写法不同（例如 "1.5" 和 "1.50"）不影响散列值
+type transferRequestCanonical struct {
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+}
+
+// TransferRequestHash 计算交易请求的 sha256 散列值，用于判断同一个幂等键是否被用于了不同的请求
+// amount 为转出金额的最小货币单位，currency 为转出金额的货币
+func TransferRequestHash(fromAccountID int64, toAccountID int64, amount int64, currency string) string {
+	// 结构体只包含整数和字符串字段，编码不会失败
+	data, _ := json.Marshal(transferRequestCanonical{
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+		Currency:      currency,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// IdempotentTransferTxResult 包含带有幂等键的交易事务的结果
+type IdempotentTransferTxResult struct {
+	TransferTxResult
+	// 为 true 时表示该幂等键之前已经成功执行过，本次返回的是当时保存的结果
+	Replayed bool
+}
+
+// IdempotentTransferTx 在同一个事务中执行交易并保存幂等键和交易结果，重复的请求会直接返回第一次的结果
+func (store *SQLStore) IdempotentTransferTx(ctx context.Context, arg IdempotentTransferTxParams) (IdempotentTransferTxResult, error) {
+	var result IdempotentTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// 先插入幂等键，并发的相同请求会在唯一索引上等待，直到当前事务提交或者回滚
+		_, err := q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+			Username:    arg.Username,
+			Key:         arg.Key,
+			RequestHash: arg.RequestHash,
+			ExpiresAt:   arg.ExpiresAt,
+		})
+		if err == pgx.ErrNoRows {
+			// 没有插入任何记录，说明该幂等键已经存在且没有过期
+			return replayIdempotencyKey(ctx, q, arg, &result)
+		}
+		if err != nil {
+			return err
+		}
+
+		// 幂等键是第一次使用，执行交易
+		result.TransferTxResult, err = transferTx(ctx, q, arg.TransferTxParams, true)
+		if err != nil {
+			return err
+		}
+
+		// 将交易结果保存到幂等键中，与交易一起提交
+		response, err := json.Marshal(result.TransferTxResult)
+		if err != nil {
+			return err
+		}
+		_, err = q.UpdateIdempotencyKeyResponse(ctx, UpdateIdempotencyKeyResponseParams{
+			Username: arg.Username,
+			Key:      arg.Key,
+			Response: pgtype.JSONB{Bytes: response, Status: pgtype.Present},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// replayIdempotencyKey 读取已经存在的幂等键，若请求内容相同则将保存的交易结果写入 result
+func replayIdempotencyKey(ctx context.Context, q *Queries, arg IdempotentTransferTxParams, result *IdempotentTransferTxResult) error {
+	idempotencyKey, err := q.GetIdempotencyKey(ctx, GetIdempotencyKeyParams{
+		Username: arg.Username,
+		Key:      arg.Key,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 同一个幂等键被用于了不同内容的请求
+	if idempotencyKey.RequestHash != arg.RequestHash {
+		return ErrIdempotencyKeyReused
+	}
+
+	result.Replayed = true
+	return json.Unmarshal(idempotencyKey.Response.Bytes, &result.TransferTxResult)
+}
@@ -0,0 +1,115 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: journal_entry.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createJournalEntry = `-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (
+  kind,
+  description
+) VALUES (
+  $1, $2
+) RETURNING id, kind, description, created_at
+`
+
+type CreateJournalEntryParams struct {
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+}
+
+func (q *Queries) CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (JournalEntry, error) {
+	row := q.db.QueryRow(ctx, createJournalEntry, arg.Kind, arg.Description)
+	var i JournalEntry
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getJournalEntry = `-- name: GetJournalEntry :one
+SELECT id, kind, description, created_at FROM journal_entries
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetJournalEntry(ctx context.Context, id int64) (JournalEntry, error) {
+	row := q.db.QueryRow(ctx, getJournalEntry, id)
+	var i JournalEntry
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSystemAccount = `-- name: GetSystemAccount :one
+SELECT accounts.id, accounts.owner, accounts.balance, accounts.currency, accounts.created_at, accounts.overdraft_limit, accounts.status, accounts.frozen_by FROM accounts
+JOIN system_accounts ON system_accounts.account_id = accounts.id
+WHERE system_accounts.name = $1 AND system_accounts.currency = $2
+LIMIT 1
+`
+
+type GetSystemAccountParams struct {
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+}
+
+func (q *Queries) GetSystemAccount(ctx context.Context, arg GetSystemAccountParams) (Account, error) {
+	row := q.db.QueryRow(ctx, getSystemAccount, arg.Name, arg.Currency)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.OverdraftLimit,
+		&i.Status,
+		&i.FrozenBy,
+	)
+	return i, err
+}
+
+const listJournalEntryLines = `-- name: ListJournalEntryLines :many
+SELECT id, account_id, amount, created_at, transfer_id, reversal_id, journal_entry_id FROM entries
+WHERE journal_entry_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListJournalEntryLines(ctx context.Context, journalEntryID *int64) ([]Entry, error) {
+	rows, err := q.db.Query(ctx, listJournalEntryLines, journalEntryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Entry{}
+	for rows.Next() {
+		var i Entry
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.TransferID,
+			&i.ReversalID,
+			&i.JournalEntryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"SimpleBank/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createAccountInCurrency 为一个新用户创建指定货币的账户
+func createAccountInCurrency(t *testing.T, currency string) Account {
+	user := createRandomUser(t)
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Balance:  util.RandomMoney(),
+		Currency: currency,
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func requireSystemAccount(t *testing.T, name, currency string) Account {
+	account, err := testQueries.GetSystemAccount(context.Background(), GetSystemAccountParams{
+		Name:     name,
+		Currency: currency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, SystemUsername, account.Owner)
+	require.Equal(t, currency, account.Currency)
+	return account
+}
+
+func TestPostJournalFee(t *testing.T) {
+	account := fundAccount(t, createAccountInCurrency(t, util.USD), 100)
+	feeIncome := requireSystemAccount(t, SystemAccountFeeIncome, util.USD)
+
+	result, err := testStore.PostJournal(context.Background(), PostJournalParams{
+		Kind:        JournalKindFee,
+		Description: "monthly fee",
+		Lines: []JournalLine{
+			{AccountID: account.ID, Amount: -5},
+			{AccountID: feeIncome.ID, Amount: 5},
+		},
+	})
+	require.NoError(t, err)
+	require.NotZero(t, result.JournalEntry.ID)
+	require.Equal(t, JournalKindFee, result.JournalEntry.Kind)
+	require.Equal(t, "monthly fee", result.JournalEntry.Description)
+
+	// 条目和账户与分录行的顺序一一对应
+	require.Len(t, result.Entries, 2)
+	require.Equal(t, int64(-5), result.Entries[0].Amount)
+	require.Equal(t, &result.JournalEntry.ID, result.Entries[0].JournalEntryID)
+	require.Equal(t, account.Balance-5, result.Accounts[0].Balance)
+	require.Equal(t, feeIncome.ID, result.Accounts[1].ID)
+
+	lines, err := testQueries.ListJournalEntryLines(context.Background(), &result.JournalEntry.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Entries, lines)
+}
+
+func TestPostJournalInterest(t *testing.T) {
+	account := createAccountInCurrency(t, util.EUR)
+	interestExpense := requireSystemAccount(t, SystemAccountInterestExpense, util.EUR)
+
+	// 系统账户的透支额度不受限制，可以支付任意金额的利息
+	result, err := testStore.PostJournal(context.Background(), PostJournalParams{
+		Kind: JournalKindInterest,
+		Lines: []JournalLine{
+			{AccountID: interestExpense.ID, Amount: -3},
+			{AccountID: account.ID, Amount: 3},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Balance+3, result.Accounts[1].Balance)
+}
+
+func TestPostJournalUnbalanced(t *testing.T) {
+	account1 := fundAccount(t, createAccountInCurrency(t, util.USD), 100)
+	account2 := createAccountInCurrency(t, util.USD)
+	account3 := createAccountInCurrency(t, util.EUR)
+
+	testCases := []struct {
+		name  string
+		lines []JournalLine
+	}{
+		{
+			name:  "SingleLine",
+			lines: []JournalLine{{AccountID: account1.ID, Amount: 0}},
+		},
+		{
+			name: "NonZeroSum",
+			lines: []JournalLine{
+				{AccountID: account1.ID, Amount: -10},
+				{AccountID: account2.ID, Amount: 9},
+			},
+		},
+		{
+			// 不同货币的金额不能相互抵消
+			name: "DifferentCurrencies",
+			lines: []JournalLine{
+				{AccountID: account1.ID, Amount: -10},
+				{AccountID: account3.ID, Amount: 10},
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := testStore.PostJournal(context.Background(), PostJournalParams{
+				Kind:  JournalKindTransfer,
+				Lines: tc.lines,
+			})
+			require.ErrorIs(t, err, ErrUnbalancedJournal)
+		})
+	}
+
+	// 分录不平衡时事务被回滚，账户的余额保持不变
+	account, err := testQueries.GetAccountForUpdate(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, account.Balance)
+}
+
+func TestJournalBalancedConstraint(t *testing.T) {
+	account := createAccountInCurrency(t, util.USD)
+	store := testStore.(*SQLStore)
+
+	// 绕过 PostJournal 直接插入不平衡的条目，数据库在事务提交时拒绝
+	err := store.execTx(context.Background(), func(q *Queries) error {
+		journal, err := q.CreateJournalEntry(context.Background(), CreateJournalEntryParams{
+			Kind: JournalKindFee,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateEntry(context.Background(), CreateEntryParams{
+			AccountID:      account.ID,
+			Amount:         -1,
+			JournalEntryID: &journal.ID,
+		})
+		return err
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not balanced")
+}
+
+func TestTransferTxJournal(t *testing.T) {
+	account1 := fundAccount(t, createAccountInCurrency(t, util.USD), 100)
+	account2 := createAccountInCurrency(t, util.EUR)
+	usdPosition := requireSystemAccount(t, SystemAccountFXPosition, util.USD)
+	eurPosition := requireSystemAccount(t, SystemAccountFXPosition, util.EUR)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        100,
+		ToAmount:      92,
+		ExchangeRate:  "0.92",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.FromEntry.JournalEntryID)
+	require.Equal(t, result.FromEntry.JournalEntryID, result.ToEntry.JournalEntryID)
+
+	// 跨币种交易通过两种货币的外汇头寸账户结算，每种货币的条目之和都为 0
+	lines, err := testQueries.ListJournalEntryLines(context.Background(), result.FromEntry.JournalEntryID)
+	require.NoError(t, err)
+	require.Len(t, lines, 4)
+
+	amounts := make(map[int64]int64)
+	for _, line := range lines {
+		amounts[line.AccountID] += line.Amount
+	}
+	require.Equal(t, map[int64]int64{
+		account1.ID:    -100,
+		usdPosition.ID: 100,
+		eurPosition.ID: -92,
+		account2.ID:    92,
+	}, amounts)
+
+	// 只有客户账户的条目关联交易记录
+	require.Equal(t, &result.Transfer.ID, lines[0].TransferID)
+	require.Nil(t, lines[1].TransferID)
+	require.Nil(t, lines[2].TransferID)
+	require.Equal(t, &result.Transfer.ID, lines[3].TransferID)
+}
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// 定义日记账分录的所有类型
+const (
+	JournalKindTransfer   = "transfer"
+	JournalKindReversal   = "reversal"
+	JournalKindFee        = "fee"
+	JournalKindInterest   = "interest"
+	JournalKindDeposit    = "deposit"
+	JournalKindWithdrawal = "withdrawal"
+)
+
+// SystemUsername 是持有银行自身账户的系统用户
+const SystemUsername = "simplebank_system"
+
+// 定义所有的系统账户，每种货币各有一个
+const (
+	SystemAccountFXPosition      = "fx_position"
+	SystemAccountFeeIncome       = "fee_income"
+	SystemAccountInterestExpense = "interest_expense"
+	SystemAccountCash            = "cash"
+	SystemAccountClearing        = "clearing"
+)
+
+// JournalLine 是日记账分录中的一个条目，正数表示记入账户，负数表示从账户扣除
+type JournalLine struct {
+	AccountID  int64  `json:"account_id"`
+	Amount     int64  `json:"amount"`
+	TransferID *int64 `json:"transfer_id"`
+	ReversalID *int64 `json:"reversal_id"`
+}
+
+// PostJournalParams 包含记录一条日记账分录所需要的输入参数
+type PostJournalParams struct {
+	Kind        string        `json:"kind"`
+	Description string        `json:"description"`
+	Lines       []JournalLine `json:"lines"`
+	// 为 false 时分录涉及的账户都必须是正常状态，为 true 时已冻结的账户也可以记账，已关闭的账户始终不能记账
+	AllowFrozen bool `json:"allow_frozen"`
+}
+
+// PostJournalResult 包含记录日记账分录的结果
+// Entries 和 Accounts 与 Lines 的顺序一一对应，Accounts 为所有条目记账之后的账户
+type PostJournalResult struct {
+	JournalEntry JournalEntry `json:"journal_entry"`
+	Entries      []Entry      `json:"entries"`
+	Accounts     []Account    `json:"accounts"`
+}
+
+// PostJournal 在一个事务中记录一条日记账分录，创建所有条目并更新账户余额
+// 分录的条目按货币分别求和都必须为 0 ，否则返回 ErrUnbalancedJournal ，数据库在事务提交时也会再次检查
+// 每条分录都会在同一个事务中写入审计日志
+func (store *SQLStore) PostJournal(ctx context.Context, arg PostJournalParams) (PostJournalResult, error) {
+	var result PostJournalResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = postJournal(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// postJournal 使用传入的事务查询对象记录日记账分录，便于和其他操作组合在同一个事务中
+func postJournal(ctx context.Context, q *Queries, arg PostJournalParams) (result PostJournalResult, err error) {
+	if len(arg.Lines) < 2 {
+		err = fmt.Errorf("%w: at least 2 lines are required", ErrUnbalancedJournal)
+		return
+	}
+
+	// 同一个账户的多个条目合并为一次余额修改
+	deltas := make(map[int64]int64)
+	for _, line := range arg.Lines {
+		deltas[line.AccountID] += line.Amount
+	}
+	accountIDs := make([]int64, 0, len(deltas))
+	for id := range deltas {
+		accountIDs = append(accountIDs, id)
+	}
+
+	// 为了避免死锁，所有分录都按照账户 ID 从小到大的顺序获取行锁
+	sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+	accounts := make(map[int64]Account, len(accountIDs))
+	for _, id := range accountIDs {
+		accounts[id], err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     id,
+			Amount: deltas[id],
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	// 不同货币的金额不能相互抵消，每种货币的条目之和都必须为 0
+	sums := make(map[string]int64)
+	for _, line := range arg.Lines {
+		sums[accounts[line.AccountID].Currency] += line.Amount
+	}
+	for currency, sum := range sums {
+		if sum != 0 {
+			err = fmt.Errorf("%w: %s lines sum to %d", ErrUnbalancedJournal, currency, sum)
+			return
+		}
+	}
+
+	// 此时所有账户的行锁都已经持有，状态和余额不会被并发修改
+	for _, id := range accountIDs {
+		account := accounts[id]
+		if account.Status == AccountStatusClosed || (account.Status == AccountStatusFrozen && !arg.AllowFrozen) {
+			err = fmt.Errorf("%w: account [%d] is %s", ErrAccountNotActive, account.ID, account.Status)
+			return
+		}
+	}
+	for _, id := range accountIDs {
+		account := accounts[id]
+		if deltas[id] < 0 && account.Balance < -account.OverdraftLimit {
+			err = ErrInsufficientFunds
+			return
+		}
+	}
+
+	result.JournalEntry, err = q.CreateJournalEntry(ctx, CreateJournalEntryParams{
+		Kind:        arg.Kind,
+		Description: arg.Description,
+	})
+	if err != nil {
+		return
+	}
+
+	result.Entries = make([]Entry, len(arg.Lines))
+	result.Accounts = make([]Account, len(arg.Lines))
+	for i, line := range arg.Lines {
+		result.Entries[i], err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:      line.AccountID,
+			Amount:         line.Amount,
+			TransferID:     line.TransferID,
+			ReversalID:     line.ReversalID,
+			JournalEntryID: &result.JournalEntry.ID,
+		})
+		if err != nil {
+			return
+		}
+		result.Accounts[i] = accounts[line.AccountID]
+	}
+
+	// 审计日志中记录分录涉及的所有账户在记账之前和之后的余额
+	before := make([]auditAccountBalance, len(accountIDs))
+	after := auditJournal{
+		JournalEntry: result.JournalEntry,
+		Entries:      result.Entries,
+		Balances:     make([]auditAccountBalance, len(accountIDs)),
+	}
+	for i, id := range accountIDs {
+		account := accounts[id]
+		before[i] = auditAccountBalance{AccountID: id, Currency: account.Currency, Balance: account.Balance - deltas[id]}
+		after.Balances[i] = auditAccountBalance{AccountID: id, Currency: account.Currency, Balance: account.Balance}
+	}
+	err = appendAuditLog(ctx, q, AuditActionLedgerPost, fmt.Sprintf("journal_entry:%d", result.JournalEntry.ID), before, after)
+	return
+}
+
+// exchangeLines 返回从 debit 账户扣除并记入 credit 账户的分录条目，debit 和 credit 分别为第一个和最后一个条目
+// 两个账户的货币不同时，通过两种货币的外汇头寸系统账户结算，使每种货币的条目之和都为 0
+func exchangeLines(ctx context.Context, q *Queries, debit, credit JournalLine) ([]JournalLine, error) {
+	rows, err := q.ListAccountCurrencies(ctx, []int64{debit.AccountID, credit.AccountID})
+	if err != nil {
+		return nil, err
+	}
+	currencies := make(map[int64]string, len(rows))
+	for _, row := range rows {
+		currencies[row.ID] = row.Currency
+	}
+
+	debitCurrency, creditCurrency := currencies[debit.AccountID], currencies[credit.AccountID]
+	if debitCurrency == creditCurrency {
+		return []JournalLine{debit, credit}, nil
+	}
+
+	debitPosition, err := systemAccount(ctx, q, SystemAccountFXPosition, debitCurrency)
+	if err != nil {
+		return nil, err
+	}
+	creditPosition, err := systemAccount(ctx, q, SystemAccountFXPosition, creditCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return []JournalLine{
+		debit,
+		{AccountID: debitPosition.ID, Amount: -debit.Amount},
+		{AccountID: creditPosition.ID, Amount: -credit.Amount},
+		credit,
+	}, nil
+}
+
+// systemAccount 返回指定名称和货币的系统账户
+func systemAccount(ctx context.Context, q *Queries, name, currency string) (Account, error) {
+	account, err := q.GetSystemAccount(ctx, GetSystemAccountParams{
+		Name:     name,
+		Currency: currency,
+	})
+	if err != nil {
+		return account, fmt.Errorf("cannot get %s account for %s: %w", name, currency, err)
+	}
+	return account, nil
+}
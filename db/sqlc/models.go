@@ -5,7 +5,11 @@
 package db
 
 import (
+	"database/sql"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgtype"
 )
 
 type Account struct {
@@ -14,6 +18,57 @@ type Account struct {
 	Balance   int64     `json:"balance"`
 	Currency  string    `json:"currency"`
 	CreatedAt time.Time `json:"created_at"`
+	// how far below zero the balance may go
+	OverdraftLimit int64 `json:"overdraft_limit"`
+	// active, frozen or closed
+	Status string `json:"status"`
+	// the user who froze the account, null unless the account is frozen
+	FrozenBy sql.NullString `json:"frozen_by"`
+}
+
+type AuditLog struct {
+	ID int64 `json:"id"`
+	// the user who performed the action, or system for background jobs
+	Actor  string `json:"actor"`
+	Action string `json:"action"`
+	// the affected object, for example account:1 or user:alice
+	Target    string `json:"target"`
+	RequestID string `json:"request_id"`
+	// the object before the action, stored as json so the hashed text is kept byte for byte
+	Before string `json:"before"`
+	After  string `json:"after"`
+	// the hash of the previous row in seq order, empty for the first row and for unsealed rows
+	PrevHash string `json:"prev_hash"`
+	// hex encoded SHA-256 of prev_hash and the content of this row, empty until the row is sealed
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+	// position in the hash chain assigned by the sealer, NULL until the row is sealed
+	Seq *int64 `json:"seq"`
+}
+
+type BalanceDrift struct {
+	ID        int64 `json:"id"`
+	RunID     int64 `json:"run_id"`
+	AccountID int64 `json:"account_id"`
+	// accounts.balance when the run started
+	Balance int64 `json:"balance"`
+	// the sum of the account entries when the run started
+	EntriesBalance int64 `json:"entries_balance"`
+}
+
+type Deposit struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// must be positive, in the currency of the account
+	Amount int64 `json:"amount"`
+	// the reference of the deposit in the external payment system
+	ExternalReference string `json:"external_reference"`
+	// pending, settled or failed
+	Status    string    `json:"status"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	// when the deposit was settled or failed
+	ResolvedAt *time.Time `json:"resolved_at"`
 }
 
 type Entry struct {
@@ -22,15 +77,144 @@ type Entry struct {
 	// can be negative or positive
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// the transfer that created this entry, if any
+	TransferID *int64 `json:"transfer_id"`
+	// the reversal that created this compensating entry, if any
+	ReversalID *int64 `json:"reversal_id"`
+	// the journal entry this posting line belongs to, null for entries created before the ledger
+	JournalEntryID *int64 `json:"journal_entry_id"`
+}
+
+type IdempotencyKey struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+	// sha256 of the request body the key was first used with
+	RequestHash string       `json:"request_hash"`
+	Response    pgtype.JSONB `json:"response"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type JournalEntry struct {
+	ID int64 `json:"id"`
+	// transfer, reversal, fee, interest, deposit or withdrawal
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ReconciliationRun struct {
+	ID              int64 `json:"id"`
+	AccountsChecked int64 `json:"accounts_checked"`
+	// the number of accounts whose balance differs from the sum of their entries
+	DriftCount int64     `json:"drift_count"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+type ScheduledTransfer struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// must be positive
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+	// cron expression or @every <duration>
+	Schedule  string     `json:"schedule"`
+	NextRunAt time.Time  `json:"next_run_at"`
+	EndAt     *time.Time `json:"end_at"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type ScheduledTransferRun struct {
+	ID                  int64 `json:"id"`
+	ScheduledTransferID int64 `json:"scheduled_transfer_id"`
+	// the next_run_at that was due when the run was executed
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	TransferID    *int64    `json:"transfer_id"`
+	Status        string    `json:"status"`
+	FailureReason string    `json:"failure_reason"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type Session struct {
+	// the id of the refresh token payload
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	RefreshToken string    `json:"refresh_token"`
+	UserAgent    string    `json:"user_agent"`
+	ClientIp     string    `json:"client_ip"`
+	IsBlocked    bool      `json:"is_blocked"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type SystemAccount struct {
+	// fx_position, fee_income, interest_expense, cash or clearing
+	Name      string `json:"name"`
+	Currency  string `json:"currency"`
+	AccountID int64  `json:"account_id"`
+}
+
+type Task struct {
+	ID      int64        `json:"id"`
+	Type    string       `json:"type"`
+	Payload pgtype.JSONB `json:"payload"`
+	// pending, running while a processor holds the lease, succeeded, or dead once max_attempts is reached
+	Status      string `json:"status"`
+	Attempts    int32  `json:"attempts"`
+	MaxAttempts int32  `json:"max_attempts"`
+	// the task is not processed before this time, used for retry backoff and as the lease expiry of running tasks
+	RunAt     time.Time `json:"run_at"`
+	LastError string    `json:"last_error"`
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Transfer struct {
 	ID            int64 `json:"id"`
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
-	// must be positive
+	// must be positive, in the currency of from_account
 	Amount    int64     `json:"amount"`
 	CreatedAt time.Time `json:"created_at"`
+	// must be positive, in the currency of to_account
+	ToAmount int64 `json:"to_amount"`
+	// units of to_account currency per unit of from_account currency
+	ExchangeRate string `json:"exchange_rate"`
+	QuoteID      string `json:"quote_id"`
+	// completed, partially_reversed or reversed
+	Status string `json:"status"`
+	// the total amount refunded so far, in the currency of from_account
+	ReversedAmount int64 `json:"reversed_amount"`
+}
+
+type TransferLimitRule struct {
+	ID string `json:"id"`
+	// account: transfers from the account, user: transfers from all accounts of the owner in the currency
+	Scope string `json:"scope"`
+	// transfer (a single transfer), hour (the last hour), day or month (the current UTC calendar day or month)
+	Period string `json:"period"`
+	// amount (in minor units of the currency) or count (number of transfers)
+	Metric    string    `json:"metric"`
+	Currency  string    `json:"currency"`
+	Role      string    `json:"role"`
+	MaxValue  int64     `json:"max_value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TransferReversal struct {
+	ID         int64 `json:"id"`
+	TransferID int64 `json:"transfer_id"`
+	// must be positive, refunded to from_account in its currency
+	Amount int64 `json:"amount"`
+	// must be positive, taken back from to_account in its currency
+	ToAmount   int64     `json:"to_amount"`
+	Reason     string    `json:"reason"`
+	ReversedBy string    `json:"reversed_by"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type User struct {
@@ -40,4 +224,29 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	IsEmailVerified   bool      `json:"is_email_verified"`
+	// depositor or banker
+	Role string `json:"role"`
+}
+
+type VerifyEmail struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// the address the code was sent to, the user is only verified if it still matches
+	Email      string    `json:"email"`
+	SecretCode string    `json:"secret_code"`
+	IsUsed     bool      `json:"is_used"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiredAt  time.Time `json:"expired_at"`
+}
+
+type Withdrawal struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// must be positive, in the currency of the account
+	Amount int64 `json:"amount"`
+	// the reference of the withdrawal in the external payment system
+	ExternalReference string    `json:"external_reference"`
+	CreatedBy         string    `json:"created_by"`
+	CreatedAt         time.Time `json:"created_at"`
 }
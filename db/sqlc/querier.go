@@ -6,23 +6,136 @@ package db
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	// 累加已退款的金额，全部退款之后交易的状态为 reversed ，否则为 partially_reversed
+	AddTransferReversedAmount(ctx context.Context, arg AddTransferReversedAmountParams) (Transfer, error)
+	// 封禁之后该会话的刷新令牌立即失效，不能再用于获取新的访问令牌
+	BlockSession(ctx context.Context, id uuid.UUID) (Session, error)
+	// 封禁用户所有未封禁的会话，用于修改密码之后让之前签发的刷新令牌全部失效
+	BlockUserSessions(ctx context.Context, username string) (int64, error)
+	// 领取一个已经到期的待处理任务，或者租约已经到期的处理中任务，被其他处理器锁定的行会被跳过
+	// 领取时即增加一次尝试次数，run_at 设置为租约的到期时间，处理器崩溃或者处理超时也会计入尝试次数
+	// 租约到期的任务若已经达到最大尝试次数，则不再领取，直接进入死信状态
+	ClaimDueTask(ctx context.Context, arg ClaimDueTaskParams) (Task, error)
+	CountAccounts(ctx context.Context) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	// 写入一行未封存的审计日志，哈希值由封存程序在之后计算
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
+	CreateBalanceDrift(ctx context.Context, arg CreateBalanceDriftParams) (BalanceDrift, error)
+	CreateDeposit(ctx context.Context, arg CreateDepositParams) (Deposit, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	// 同一个用户的 key 已经存在时不插入，除非旧的 key 已经过期，此时覆盖旧的记录
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (JournalEntry, error)
+	CreateReconciliationRun(ctx context.Context, arg CreateReconciliationRunParams) (ReconciliationRun, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
+	CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferLimitRule(ctx context.Context, arg CreateTransferLimitRuleParams) (TransferLimitRule, error)
+	CreateTransferReversal(ctx context.Context, arg CreateTransferReversalParams) (TransferReversal, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
-	DeleteAccount(ctx context.Context, id int64) error
+	CreateVerifyEmail(ctx context.Context, arg CreateVerifyEmailParams) (VerifyEmail, error)
+	CreateWithdrawal(ctx context.Context, arg CreateWithdrawalParams) (Withdrawal, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	DeleteTransferLimitRule(ctx context.Context, id string) error
+	// 只读取账户不加锁，用于之后按照账户 ID 的顺序加锁的事务
+	GetAccount(ctx context.Context, id int64) (Account, error)
+	// 用账户当前余额减去指定时间之后的所有条目金额，得到账户在该时间点的余额
+	GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetDeposit(ctx context.Context, id int64) (Deposit, error)
+	GetDepositForUpdate(ctx context.Context, id int64) (Deposit, error)
+	// 锁定一条已经到期的计划交易，被其他服务器实例锁定的行会被跳过，因此多个实例不会重复执行同一条计划交易
+	GetDueScheduledTransferForUpdate(ctx context.Context, now time.Time) (ScheduledTransfer, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetJournalEntry(ctx context.Context, id int64) (JournalEntry, error)
+	GetLastSealedAuditLog(ctx context.Context) (AuditLog, error)
+	GetLatestReconciliationRun(ctx context.Context) (ReconciliationRun, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
+	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetSystemAccount(ctx context.Context, arg GetSystemAccountParams) (Account, error)
+	GetTask(ctx context.Context, id int64) (Task, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
+	// 冲正时锁定原交易，同一笔交易的冲正依次执行，避免重复冲正
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
+	// 认证时检查访问令牌是否在最近一次修改密码之前签发
+	GetUserPasswordChangedAt(ctx context.Context, username string) (time.Time, error)
+	GetWithdrawal(ctx context.Context, id int64) (Withdrawal, error)
+	// 余额与所有条目之和不一致的账户，没有条目的账户的条目之和为 0
+	ListAccountBalanceDrifts(ctx context.Context) ([]ListAccountBalanceDriftsRow, error)
+	// 查询多个账户的货币类型，用于将交易的金额格式化为对应货币的金额
+	ListAccountCurrencies(ctx context.Context, ids []int64) ([]ListAccountCurrenciesRow, error)
+	// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	// 按照哈希链的顺序分页读取已经封存的审计日志，从 after_seq 之后开始
+	ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuditLog, error)
+	// 同时返回账户的所有者和货币，便于定位出现差异的账户
+	ListBalanceDrifts(ctx context.Context, runID int64) ([]ListBalanceDriftsRow, error)
+	// 查询已经超过最大尝试次数的任务，便于排查问题后重新处理
+	ListDeadTasks(ctx context.Context, arg ListDeadTasksParams) ([]Task, error)
+	// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListJournalEntryLines(ctx context.Context, journalEntryID *int64) ([]Entry, error)
+	// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询
+	ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error)
+	// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询
+	ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error)
+	// 查询账户在时间范围内的一页条目，并计算每条条目入账之后的账户余额以及交易的对方账户
+	// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 page_offset 为 0 并从上一页最后一条记录的 id 之后开始查询
+	// 同一笔交易中另一条条目所属的账户即为对方账户，冲正产生的条目只与同一次冲正的另一条条目对应
+	ListStatementEntries(ctx context.Context, arg ListStatementEntriesParams) ([]ListStatementEntriesRow, error)
+	// 导出对账单时从 after_id 之后分批查询账户在时间范围内的条目以及交易的对方账户
+	// 不计算入账之后的账户余额，由调用方从期初余额开始逐条累加，避免每一批都重新汇总之后的所有条目
+	// 同一笔交易中另一条条目所属的账户即为对方账户，冲正产生的条目只与同一次冲正的另一条条目对应
+	ListStatementExportEntries(ctx context.Context, arg ListStatementExportEntriesParams) ([]ListStatementExportEntriesRow, error)
+	// 返回适用于指定货币和角色的所有限额规则
+	ListTransferLimitRules(ctx context.Context, arg ListTransferLimitRulesParams) ([]TransferLimitRule, error)
+	ListTransferReversals(ctx context.Context, transferID int64) ([]TransferReversal, error)
+	// 查询与指定账户相关的所有交易（转出和转入），可选的过滤条件为 NULL 时不进行过滤
+	// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询
+	// 金额范围按照该账户的货币计算：转出交易使用 amount ，转入交易使用 to_amount
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	// 按照 id 的顺序读取已经提交但还没有封存的审计日志
+	ListUnsealedAuditLogs(ctx context.Context, limit int32) ([]AuditLog, error)
+	// 在事务结束之前独占审计日志的哈希链尾部，保证同一时间只有一个封存程序在延长哈希链，哈希链不会分叉
+	LockAuditLog(ctx context.Context, lockID int64) error
+	// 将死信任务重新放回队列，并重置尝试次数
+	RequeueDeadTask(ctx context.Context, id int64) (Task, error)
+	// 只有等待入账的存款可以修改状态
+	ResolveDeposit(ctx context.Context, arg ResolveDepositParams) (Deposit, error)
+	// 将一行审计日志链接到哈希链的尾部，已经封存的行不能再次封存
+	SealAuditLog(ctx context.Context, arg SealAuditLogParams) (AuditLog, error)
+	// 汇总账户从 since 开始转出的交易和取款，已经退款的金额不计入，全部退款的交易不计入笔数
+	SumAccountTransfers(ctx context.Context, arg SumAccountTransfersParams) (SumAccountTransfersRow, error)
+	// 汇总用户所有该货币的账户从 since 开始转出的交易和取款，已经退款的金额不计入，全部退款的交易不计入笔数
+	SumUserTransfers(ctx context.Context, arg SumUserTransfersParams) (SumUserTransfersRow, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountOverdraftLimit(ctx context.Context, arg UpdateAccountOverdraftLimitParams) (Account, error)
+	// 冻结账户时记录冻结的用户，其他状态时清空
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
+	UpdateIdempotencyKeyResponse(ctx context.Context, arg UpdateIdempotencyKeyResponseParams) (IdempotencyKey, error)
+	// 只修改传入了值的字段，没有传入值（为 NULL ）的字段保持不变，已经完成或者取消的计划交易不能再修改
+	UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error)
+	// 记录一次处理的结果，尝试次数已经在领取任务时增加
+	// 只有任务没有被其他处理器重新领取时才能记录，重新领取会增加尝试次数，因此 attempts 与领取时不同
+	UpdateTaskResult(ctx context.Context, arg UpdateTaskResultParams) (Task, error)
+	// 只修改传入了值的字段，其他字段保持不变
+	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	// 将一个未使用并且没有过期的验证码标记为已使用，验证码不正确、已经使用或者已经过期时不返回任何行
+	UseVerifyEmail(ctx context.Context, arg UseVerifyEmailParams) (VerifyEmail, error)
+	// 只有验证码发送到的邮箱仍然是用户当前的邮箱时才会验证成功
+	VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) (User, error)
 }
 
 var _ Querier = (*Queries)(nil)
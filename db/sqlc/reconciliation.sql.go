@@ -0,0 +1,195 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: reconciliation.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const countAccounts = `-- name: CountAccounts :one
+SELECT COUNT(*) FROM accounts
+`
+
+func (q *Queries) CountAccounts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countAccounts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createBalanceDrift = `-- name: CreateBalanceDrift :one
+INSERT INTO balance_drifts (
+  run_id,
+  account_id,
+  balance,
+  entries_balance
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING id, run_id, account_id, balance, entries_balance
+`
+
+type CreateBalanceDriftParams struct {
+	RunID          int64 `json:"run_id"`
+	AccountID      int64 `json:"account_id"`
+	Balance        int64 `json:"balance"`
+	EntriesBalance int64 `json:"entries_balance"`
+}
+
+func (q *Queries) CreateBalanceDrift(ctx context.Context, arg CreateBalanceDriftParams) (BalanceDrift, error) {
+	row := q.db.QueryRow(ctx, createBalanceDrift,
+		arg.RunID,
+		arg.AccountID,
+		arg.Balance,
+		arg.EntriesBalance,
+	)
+	var i BalanceDrift
+	err := row.Scan(
+		&i.ID,
+		&i.RunID,
+		&i.AccountID,
+		&i.Balance,
+		&i.EntriesBalance,
+	)
+	return i, err
+}
+
+const createReconciliationRun = `-- name: CreateReconciliationRun :one
+INSERT INTO reconciliation_runs (
+  accounts_checked,
+  drift_count,
+  started_at
+) VALUES (
+  $1, $2, $3
+) RETURNING id, accounts_checked, drift_count, started_at, finished_at
+`
+
+type CreateReconciliationRunParams struct {
+	AccountsChecked int64     `json:"accounts_checked"`
+	DriftCount      int64     `json:"drift_count"`
+	StartedAt       time.Time `json:"started_at"`
+}
+
+func (q *Queries) CreateReconciliationRun(ctx context.Context, arg CreateReconciliationRunParams) (ReconciliationRun, error) {
+	row := q.db.QueryRow(ctx, createReconciliationRun, arg.AccountsChecked, arg.DriftCount, arg.StartedAt)
+	var i ReconciliationRun
+	err := row.Scan(
+		&i.ID,
+		&i.AccountsChecked,
+		&i.DriftCount,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const getLatestReconciliationRun = `-- name: GetLatestReconciliationRun :one
+SELECT id, accounts_checked, drift_count, started_at, finished_at FROM reconciliation_runs
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestReconciliationRun(ctx context.Context) (ReconciliationRun, error) {
+	row := q.db.QueryRow(ctx, getLatestReconciliationRun)
+	var i ReconciliationRun
+	err := row.Scan(
+		&i.ID,
+		&i.AccountsChecked,
+		&i.DriftCount,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const listAccountBalanceDrifts = `-- name: ListAccountBalanceDrifts :many
+SELECT
+  accounts.id AS account_id,
+  accounts.balance,
+  COALESCE(SUM(entries.amount), 0)::bigint AS entries_balance
+FROM accounts
+LEFT JOIN entries ON entries.account_id = accounts.id
+GROUP BY accounts.id
+HAVING accounts.balance <> COALESCE(SUM(entries.amount), 0)
+ORDER BY accounts.id
+`
+
+type ListAccountBalanceDriftsRow struct {
+	AccountID      int64 `json:"account_id"`
+	Balance        int64 `json:"balance"`
+	EntriesBalance int64 `json:"entries_balance"`
+}
+
+// 余额与所有条目之和不一致的账户，没有条目的账户的条目之和为 0
+func (q *Queries) ListAccountBalanceDrifts(ctx context.Context) ([]ListAccountBalanceDriftsRow, error) {
+	rows, err := q.db.Query(ctx, listAccountBalanceDrifts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountBalanceDriftsRow{}
+	for rows.Next() {
+		var i ListAccountBalanceDriftsRow
+		if err := rows.Scan(&i.AccountID, &i.Balance, &i.EntriesBalance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBalanceDrifts = `-- name: ListBalanceDrifts :many
+SELECT
+  balance_drifts.id, balance_drifts.run_id, balance_drifts.account_id, balance_drifts.balance, balance_drifts.entries_balance,
+  accounts.owner,
+  accounts.currency
+FROM balance_drifts
+JOIN accounts ON accounts.id = balance_drifts.account_id
+WHERE balance_drifts.run_id = $1
+ORDER BY balance_drifts.account_id
+`
+
+type ListBalanceDriftsRow struct {
+	ID             int64  `json:"id"`
+	RunID          int64  `json:"run_id"`
+	AccountID      int64  `json:"account_id"`
+	Balance        int64  `json:"balance"`
+	EntriesBalance int64  `json:"entries_balance"`
+	Owner          string `json:"owner"`
+	Currency       string `json:"currency"`
+}
+
+// 同时返回账户的所有者和货币，便于定位出现差异的账户
+func (q *Queries) ListBalanceDrifts(ctx context.Context, runID int64) ([]ListBalanceDriftsRow, error) {
+	rows, err := q.db.Query(ctx, listBalanceDrifts, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBalanceDriftsRow{}
+	for rows.Next() {
+		var i ListBalanceDriftsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RunID,
+			&i.AccountID,
+			&i.Balance,
+			&i.EntriesBalance,
+			&i.Owner,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
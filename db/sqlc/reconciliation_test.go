@@ -0,0 +1,51 @@
+package db
+
+import (
+	"SimpleBank/util"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileBalancesTx(t *testing.T) {
+	// 直接修改余额而没有条目的账户会出现差异
+	drifted := fundAccount(t, createAccountInCurrency(t, util.USD), 100)
+
+	// 通过存款入账的账户的余额与条目之和一致
+	deposited, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+	_, err = testStore.DepositTx(context.Background(), DepositTxParams{
+		AccountID:         deposited.ID,
+		Amount:            100,
+		ExternalReference: util.RandomString(12),
+		CreatedBy:         deposited.Owner,
+		Settled:           true,
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.ReconcileBalancesTx(context.Background())
+	require.NoError(t, err)
+	require.NotZero(t, result.Run.ID)
+	require.Positive(t, result.Run.AccountsChecked)
+	require.Equal(t, result.Run.DriftCount, int64(len(result.Drifts)))
+
+	drifts := make(map[int64]ListBalanceDriftsRow)
+	for _, drift := range result.Drifts {
+		drifts[drift.AccountID] = drift
+	}
+	require.Contains(t, drifts, drifted.ID)
+	require.Equal(t, drifted.Balance, drifts[drifted.ID].Balance)
+	require.Equal(t, int64(0), drifts[drifted.ID].EntriesBalance)
+	require.Equal(t, drifted.Owner, drifts[drifted.ID].Owner)
+	require.NotContains(t, drifts, deposited.ID)
+
+	// 最近一次对账的结果与刚刚的对账结果一致
+	latest, err := testStore.LatestReconciliation(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, result.Run.ID, latest.Run.ID)
+	require.Equal(t, result.Drifts, latest.Drifts)
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// ReconciliationResult 包含一次对账的结果
+type ReconciliationResult struct {
+	Run    ReconciliationRun      `json:"run"`
+	Drifts []ListBalanceDriftsRow `json:"drifts"`
+}
+
+// ReconcileBalancesTx 在一个事务中根据所有条目重新计算每个账户的余额，记录余额与条目之和不一致的账户
+// 余额和条目总是在同一个事务中修改，因此同一个快照中两者应当始终一致
+func (store *SQLStore) ReconcileBalancesTx(ctx context.Context) (ReconciliationResult, error) {
+	var result ReconciliationResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		startedAt := time.Now()
+
+		accountsChecked, err := q.CountAccounts(ctx)
+		if err != nil {
+			return err
+		}
+
+		drifts, err := q.ListAccountBalanceDrifts(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.Run, err = q.CreateReconciliationRun(ctx, CreateReconciliationRunParams{
+			AccountsChecked: accountsChecked,
+			DriftCount:      int64(len(drifts)),
+			StartedAt:       startedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, drift := range drifts {
+			_, err = q.CreateBalanceDrift(ctx, CreateBalanceDriftParams{
+				RunID:          result.Run.ID,
+				AccountID:      drift.AccountID,
+				Balance:        drift.Balance,
+				EntriesBalance: drift.EntriesBalance,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Drifts, err = q.ListBalanceDrifts(ctx, result.Run.ID)
+		return err
+	})
+
+	return result, err
+}
+
+// LatestReconciliation 返回最近一次对账的结果，还没有对账时返回 pgx.ErrNoRows
+func (store *SQLStore) LatestReconciliation(ctx context.Context) (ReconciliationResult, error) {
+	var result ReconciliationResult
+
+	run, err := store.GetLatestReconciliationRun(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	result.Run = run
+	result.Drifts, err = store.ListBalanceDrifts(ctx, run.ID)
+	return result, err
+}
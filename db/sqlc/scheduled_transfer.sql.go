@@ -0,0 +1,312 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: scheduled_transfer.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createScheduledTransfer = `-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  schedule,
+  next_run_at,
+  end_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, owner, from_account_id, to_account_id, amount, currency, schedule, next_run_at, end_at, status, created_at
+`
+
+type CreateScheduledTransferParams struct {
+	Owner         string     `json:"owner"`
+	FromAccountID int64      `json:"from_account_id"`
+	ToAccountID   int64      `json:"to_account_id"`
+	Amount        int64      `json:"amount"`
+	Currency      string     `json:"currency"`
+	Schedule      string     `json:"schedule"`
+	NextRunAt     time.Time  `json:"next_run_at"`
+	EndAt         *time.Time `json:"end_at"`
+}
+
+func (q *Queries) CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, createScheduledTransfer,
+		arg.Owner,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.Schedule,
+		arg.NextRunAt,
+		arg.EndAt,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Schedule,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createScheduledTransferRun = `-- name: CreateScheduledTransferRun :one
+INSERT INTO scheduled_transfer_runs (
+  scheduled_transfer_id,
+  scheduled_at,
+  transfer_id,
+  status,
+  failure_reason
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id, scheduled_transfer_id, scheduled_at, transfer_id, status, failure_reason, created_at
+`
+
+type CreateScheduledTransferRunParams struct {
+	ScheduledTransferID int64     `json:"scheduled_transfer_id"`
+	ScheduledAt         time.Time `json:"scheduled_at"`
+	TransferID          *int64    `json:"transfer_id"`
+	Status              string    `json:"status"`
+	FailureReason       string    `json:"failure_reason"`
+}
+
+func (q *Queries) CreateScheduledTransferRun(ctx context.Context, arg CreateScheduledTransferRunParams) (ScheduledTransferRun, error) {
+	row := q.db.QueryRow(ctx, createScheduledTransferRun,
+		arg.ScheduledTransferID,
+		arg.ScheduledAt,
+		arg.TransferID,
+		arg.Status,
+		arg.FailureReason,
+	)
+	var i ScheduledTransferRun
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduledTransferID,
+		&i.ScheduledAt,
+		&i.TransferID,
+		&i.Status,
+		&i.FailureReason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDueScheduledTransferForUpdate = `-- name: GetDueScheduledTransferForUpdate :one
+SELECT id, owner, from_account_id, to_account_id, amount, currency, schedule, next_run_at, end_at, status, created_at FROM scheduled_transfers
+WHERE status = 'active'
+  AND next_run_at <= $1
+ORDER BY next_run_at
+LIMIT 1
+FOR UPDATE SKIP LOCKED
+`
+
+// 锁定一条已经到期的计划交易，被其他服务器实例锁定的行会被跳过，因此多个实例不会重复执行同一条计划交易
+func (q *Queries) GetDueScheduledTransferForUpdate(ctx context.Context, now time.Time) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, getDueScheduledTransferForUpdate, now)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Schedule,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getScheduledTransfer = `-- name: GetScheduledTransfer :one
+SELECT id, owner, from_account_id, to_account_id, amount, currency, schedule, next_run_at, end_at, status, created_at FROM scheduled_transfers
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, getScheduledTransfer, id)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Schedule,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listScheduledTransferRuns = `-- name: ListScheduledTransferRuns :many
+SELECT id, scheduled_transfer_id, scheduled_at, transfer_id, status, failure_reason, created_at FROM scheduled_transfer_runs
+WHERE scheduled_transfer_id = $1
+  AND id > $2
+ORDER BY id
+LIMIT $4
+OFFSET $3
+`
+
+type ListScheduledTransferRunsParams struct {
+	ScheduledTransferID int64 `json:"scheduled_transfer_id"`
+	AfterID             int64 `json:"after_id"`
+	Offset              int32 `json:"offset"`
+	Limit               int32 `json:"limit"`
+}
+
+// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询
+func (q *Queries) ListScheduledTransferRuns(ctx context.Context, arg ListScheduledTransferRunsParams) ([]ScheduledTransferRun, error) {
+	rows, err := q.db.Query(ctx, listScheduledTransferRuns,
+		arg.ScheduledTransferID,
+		arg.AfterID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransferRun{}
+	for rows.Next() {
+		var i ScheduledTransferRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduledTransferID,
+			&i.ScheduledAt,
+			&i.TransferID,
+			&i.Status,
+			&i.FailureReason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledTransfers = `-- name: ListScheduledTransfers :many
+SELECT id, owner, from_account_id, to_account_id, amount, currency, schedule, next_run_at, end_at, status, created_at FROM scheduled_transfers
+WHERE owner = $1
+  AND id > $2
+ORDER BY id
+LIMIT $4
+OFFSET $3
+`
+
+type ListScheduledTransfersParams struct {
+	Owner   string `json:"owner"`
+	AfterID int64  `json:"after_id"`
+	Offset  int32  `json:"offset"`
+	Limit   int32  `json:"limit"`
+}
+
+// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询
+func (q *Queries) ListScheduledTransfers(ctx context.Context, arg ListScheduledTransfersParams) ([]ScheduledTransfer, error) {
+	rows, err := q.db.Query(ctx, listScheduledTransfers,
+		arg.Owner,
+		arg.AfterID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScheduledTransfer{}
+	for rows.Next() {
+		var i ScheduledTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Schedule,
+			&i.NextRunAt,
+			&i.EndAt,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateScheduledTransfer = `-- name: UpdateScheduledTransfer :one
+UPDATE scheduled_transfers
+SET amount = COALESCE($1, amount),
+    schedule = COALESCE($2, schedule),
+    next_run_at = COALESCE($3, next_run_at),
+    end_at = COALESCE($4, end_at),
+    status = COALESCE($5, status)
+WHERE id = $6
+  AND status IN ('active', 'paused')
+RETURNING id, owner, from_account_id, to_account_id, amount, currency, schedule, next_run_at, end_at, status, created_at
+`
+
+type UpdateScheduledTransferParams struct {
+	Amount    *int64         `json:"amount"`
+	Schedule  sql.NullString `json:"schedule"`
+	NextRunAt *time.Time     `json:"next_run_at"`
+	EndAt     *time.Time     `json:"end_at"`
+	Status    sql.NullString `json:"status"`
+	ID        int64          `json:"id"`
+}
+
+// 只修改传入了值的字段，没有传入值（为 NULL ）的字段保持不变，已经完成或者取消的计划交易不能再修改
+func (q *Queries) UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error) {
+	row := q.db.QueryRow(ctx, updateScheduledTransfer,
+		arg.Amount,
+		arg.Schedule,
+		arg.NextRunAt,
+		arg.EndAt,
+		arg.Status,
+		arg.ID,
+	)
+	var i ScheduledTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Schedule,
+		&i.NextRunAt,
+		&i.EndAt,
+		&i.Status,
+		&i.CreatedAt,
+	)
+	return i, err
+}
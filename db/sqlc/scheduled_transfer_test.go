@@ -0,0 +1,162 @@
+package db
+
+import (
+	"SimpleBank/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// randomDueTime 返回一个很早之前的随机时间，测试时以此作为计划交易的执行时间，避免执行到其他测试创建的计划交易
+func randomDueTime() time.Time {
+	return time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(util.RandomInt(0, 1<<30)) * time.Second)
+}
+
+func createRandomScheduledTransfer(t *testing.T, from, to Account, amount int64, nextRunAt time.Time, endAt *time.Time) ScheduledTransfer {
+	arg := CreateScheduledTransferParams{
+		Owner:         from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+		Currency:      from.Currency,
+		Schedule:      "@every 24h",
+		NextRunAt:     nextRunAt,
+		EndAt:         endAt,
+	}
+
+	scheduledTransfer, err := testQueries.CreateScheduledTransfer(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, scheduledTransfer.ID)
+	require.Equal(t, arg.Owner, scheduledTransfer.Owner)
+	require.Equal(t, arg.Amount, scheduledTransfer.Amount)
+	require.Equal(t, ScheduledTransferStatusActive, scheduledTransfer.Status)
+	require.WithinDuration(t, arg.NextRunAt, scheduledTransfer.NextRunAt, time.Second)
+
+	return scheduledTransfer
+}
+
+func TestExecuteDueScheduledTransferTx(t *testing.T) {
+	account1 := fundAccount(t, createRandomAccount(t), 1000)
+	account2 := createRandomAccount(t)
+
+	dueAt := randomDueTime()
+	scheduled := createRandomScheduledTransfer(t, account1, account2, 10, dueAt, nil)
+	now := dueAt.Add(time.Second)
+
+	result, err := testStore.ExecuteDueScheduledTransferTx(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+
+	// 交易成功，执行记录中保存了交易的 ID
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, account1.Balance-10, result.Transfer.FromAccount.Balance)
+	require.Equal(t, ScheduledTransferRunSucceeded, result.Run.Status)
+	require.Equal(t, &result.Transfer.Transfer.ID, result.Run.TransferID)
+	require.WithinDuration(t, dueAt, result.Run.ScheduledAt, time.Second)
+
+	// 下一次执行的时间从 now 开始计算
+	require.Equal(t, ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.WithinDuration(t, now.Add(24*time.Hour), result.ScheduledTransfer.NextRunAt, time.Second)
+
+	// 计划交易已经执行，不会在同一时间再次执行
+	_, err = testStore.ExecuteDueScheduledTransferTx(context.Background(), now)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestExecuteDueScheduledTransferTxFailed(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	// 转账金额超过了转出账户的余额
+	dueAt := randomDueTime()
+	scheduled := createRandomScheduledTransfer(t, account1, account2, account1.Balance+1, dueAt, nil)
+	now := dueAt.Add(time.Second)
+
+	result, err := testStore.ExecuteDueScheduledTransferTx(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, scheduled.ID, result.ScheduledTransfer.ID)
+
+	// 交易失败时记录失败原因，并且交易被回滚
+	require.Nil(t, result.Transfer)
+	require.Equal(t, ScheduledTransferRunFailed, result.Run.Status)
+	require.Nil(t, result.Run.TransferID)
+	require.Contains(t, result.Run.FailureReason, ErrInsufficientFunds.Error())
+
+	account, err := testQueries.GetAccountForUpdate(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, account.Balance)
+
+	// 失败之后仍然会在下一次执行的时间重试
+	require.Equal(t, ScheduledTransferStatusActive, result.ScheduledTransfer.Status)
+	require.WithinDuration(t, now.Add(24*time.Hour), result.ScheduledTransfer.NextRunAt, time.Second)
+
+	runs, err := testQueries.ListScheduledTransferRuns(context.Background(), ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduled.ID,
+		Limit:               5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ScheduledTransferRun{result.Run}, runs)
+}
+
+func TestExecuteDueScheduledTransferTxCompleted(t *testing.T) {
+	account1 := fundAccount(t, createRandomAccount(t), 1000)
+	account2 := createRandomAccount(t)
+
+	// 下一次执行的时间晚于结束时间，执行之后计划交易完成
+	dueAt := randomDueTime()
+	endAt := dueAt.Add(time.Hour)
+	createRandomScheduledTransfer(t, account1, account2, 10, dueAt, &endAt)
+
+	result, err := testStore.ExecuteDueScheduledTransferTx(context.Background(), dueAt.Add(time.Second))
+	require.NoError(t, err)
+	require.NotNil(t, result.Transfer)
+	require.Equal(t, ScheduledTransferStatusCompleted, result.ScheduledTransfer.Status)
+
+	// 已经完成的计划交易不能再修改
+	_, err = testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:     result.ScheduledTransfer.ID,
+		Amount: &account1.Balance,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestExecuteDueScheduledTransferTxConcurrent(t *testing.T) {
+	account1 := fundAccount(t, createRandomAccount(t), 1000)
+	account2 := createRandomAccount(t)
+
+	// 创建多条同时到期的计划交易，模拟多个服务器实例同时执行
+	n := 5
+	dueAt := randomDueTime()
+	for i := 0; i < n; i++ {
+		createRandomScheduledTransfer(t, account1, account2, 10, dueAt, nil)
+	}
+
+	errs := make(chan error)
+	results := make(chan ExecuteScheduledTransferTxResult)
+	for i := 0; i < n; i++ {
+		go func() {
+			result, err := testStore.ExecuteDueScheduledTransferTx(context.Background(), dueAt.Add(time.Second))
+			errs <- err
+			results <- result
+		}()
+	}
+
+	// 被锁定的计划交易会被跳过，每条计划交易只会被执行一次
+	executed := make(map[int64]bool)
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+
+		result := <-results
+		require.NotNil(t, result.Transfer)
+		require.NotContains(t, executed, result.ScheduledTransfer.ID)
+		executed[result.ScheduledTransfer.ID] = true
+	}
+
+	account, err := testQueries.GetAccountForUpdate(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-int64(n)*10, account.Balance)
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"SimpleBank/util"
+	"context"
+	"database/sql"
+	"time"
+)
+
+// 定义计划交易的所有状态
+const (
+	ScheduledTransferStatusActive    = "active"
+	ScheduledTransferStatusPaused    = "paused"
+	ScheduledTransferStatusCompleted = "completed"
+	ScheduledTransferStatusCancelled = "cancelled"
+)
+
+// 定义计划交易每次执行的结果
+const (
+	ScheduledTransferRunSucceeded = "succeeded"
+	ScheduledTransferRunFailed    = "failed"
+)
+
+// ExecuteScheduledTransferTxResult 包含执行一次计划交易的结果
+type ExecuteScheduledTransferTxResult struct {
+	// 执行之后的计划交易，包含下一次执行的时间和最新的状态
+	ScheduledTransfer ScheduledTransfer    `json:"scheduled_transfer"`
+	Run               ScheduledTransferRun `json:"run"`
+	// 交易失败时为 nil ，失败原因记录在 Run 中
+	Transfer *TransferTxResult `json:"transfer"`
+}
+
+// ExecuteDueScheduledTransferTx 在一个事务中锁定一条在 now 之前到期的计划交易，执行交易、记录执行结果并计算下一次执行的时间
+// 被其他服务器实例锁定的计划交易会被跳过，没有可以执行的计划交易时返回 pgx.ErrNoRows
+// 交易、执行记录和下一次执行时间在同一个事务中提交，服务器在执行过程中崩溃时不会重复或者遗漏交易
+func (store *SQLStore) ExecuteDueScheduledTransferTx(ctx context.Context, now time.Time) (ExecuteScheduledTransferTxResult, error) {
+	var result ExecuteScheduledTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		scheduled, err := q.GetDueScheduledTransferForUpdate(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		runArg := CreateScheduledTransferRunParams{
+			ScheduledTransferID: scheduled.ID,
+			ScheduledAt:         scheduled.NextRunAt,
+			Status:              ScheduledTransferRunSucceeded,
+		}
+
+		// 交易在保存点中执行，交易失败时只回滚交易本身，执行记录和下一次执行时间仍然会被保存
+		err = execSavepoint(ctx, q, func(q *Queries) error {
+			transfer, err := transferTx(ctx, q, TransferTxParams{
+				FromAccountID: scheduled.FromAccountID,
+				ToAccountID:   scheduled.ToAccountID,
+				Amount:        scheduled.Amount,
+			}, true)
+			if err != nil {
+				return err
+			}
+			result.Transfer = &transfer
+			return nil
+		})
+		if err != nil {
+			runArg.Status = ScheduledTransferRunFailed
+			runArg.FailureReason = err.Error()
+		} else {
+			runArg.TransferID = &result.Transfer.Transfer.ID
+		}
+
+		result.Run, err = q.CreateScheduledTransferRun(ctx, runArg)
+		if err != nil {
+			return err
+		}
+
+		result.ScheduledTransfer, err = q.UpdateScheduledTransfer(ctx, nextScheduledRun(scheduled, now))
+		return err
+	})
+
+	return result, err
+}
+
+// nextScheduledRun 计算计划交易下一次执行的时间，超过结束时间时将计划交易标记为已完成
+// 下一次执行的时间从 now 开始计算，服务器停机期间错过的多次执行只会补执行一次
+func nextScheduledRun(scheduled ScheduledTransfer, now time.Time) UpdateScheduledTransferParams {
+	arg := UpdateScheduledTransferParams{ID: scheduled.ID}
+
+	schedule, err := util.ParseSchedule(scheduled.Schedule)
+	if err != nil {
+		// 创建时已经检验过执行计划，无法解析时暂停计划交易，避免每次轮询都重复失败
+		arg.Status = sql.NullString{String: ScheduledTransferStatusPaused, Valid: true}
+		return arg
+	}
+
+	next := schedule.Next(now)
+	arg.NextRunAt = &next
+	if scheduled.EndAt != nil && next.After(*scheduled.EndAt) {
+		arg.Status = sql.NullString{String: ScheduledTransferStatusCompleted, Valid: true}
+	}
+	return arg
+}
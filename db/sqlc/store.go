@@ -2,7 +2,9 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -11,7 +13,24 @@ import (
 // Store 提供所有方法单独或者在所有交易中组合执行数据库查询
 type Store interface {
 	Querier
+	PostJournal(ctx context.Context, arg PostJournalParams) (PostJournalResult, error)
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	DepositTx(ctx context.Context, arg DepositTxParams) (DepositTxResult, error)
+	ResolveDepositTx(ctx context.Context, arg ResolveDepositTxParams) (DepositTxResult, error)
+	WithdrawTx(ctx context.Context, arg WithdrawTxParams) (WithdrawTxResult, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountParams) (Account, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	IdempotentTransferTx(ctx context.Context, arg IdempotentTransferTxParams) (IdempotentTransferTxResult, error)
+	UpdateAccountStatusTx(ctx context.Context, arg UpdateAccountStatusTxParams) (Account, error)
+	CloseAccountTx(ctx context.Context, arg CloseAccountTxParams) (CloseAccountTxResult, error)
+	ExecuteDueScheduledTransferTx(ctx context.Context, now time.Time) (ExecuteScheduledTransferTxResult, error)
+	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error)
+	UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error)
+	ReconcileBalancesTx(ctx context.Context) (ReconciliationResult, error)
+	LatestReconciliation(ctx context.Context) (ReconciliationResult, error)
+	ProcessDueTask(ctx context.Context, arg ProcessDueTaskParams) (Task, error)
+	VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error)
+	SealAuditLogTx(ctx context.Context, limit int32) (int, error)
 }
 
 // SQLStore 提供所有方法单独或者在所有交易中组合执行 SQL查询
@@ -55,12 +74,41 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	return tx.Commit(ctx)
 }
 
+// execSavepoint 在 q 所在的事务中创建一个保存点执行操作，操作失败时只回滚到保存点，不影响事务中的其他操作
+func execSavepoint(ctx context.Context, q *Queries, fn func(*Queries) error) error {
+	tx, ok := q.db.(pgx.Tx)
+	if !ok {
+		return errors.New("savepoint requires a transaction")
+	}
+
+	// 在事务中调用 Begin 会创建一个保存点
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = fn(New(savepoint))
+	if err != nil {
+		if rbErr := savepoint.Rollback(ctx); rbErr != nil {
+			return fmt.Errorf("savepoint err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return savepoint.Commit(ctx)
+}
+
 // TransferTxParams 结构体包含在两个账户之间转账所需要的所有输入参数
 type TransferTxParams struct {
 	FromAccountID int64 `json:"from_account_id"`
 	ToAccountID   int64 `json:"to_account_id"`
-	// must be positive
+	// must be positive, in the currency of from_account
 	Amount int64 `json:"amount"`
+	// 以下字段只在跨币种交易时需要填写，同币种交易时留空，转入金额即为 Amount ，汇率为 1
+	// must be positive, in the currency of to_account
+	ToAmount     int64  `json:"to_amount"`
+	ExchangeRate string `json:"exchange_rate"`
+	QuoteID      string `json:"quote_id"`
 }
 
 // TransferTxResult 包含交易事务的结果
@@ -72,7 +120,7 @@ type TransferTxResult struct {
 	ToEntry     Entry    `json:"to_entry"`
 }
 
-// TransferTx 从一个账号到另一个账号执行一个交易，在一个事务中创建一条交易记录和账户条目并且更新账户余额
+// TransferTx 从一个账号到另一个账号执行一个交易，在一个事务中创建一条交易记录并记录对应的日记账分录
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	// 声明一个空的 TransferTxResult 的变量储存交易事务的结果
 	var result TransferTxResult
@@ -80,73 +128,75 @@ func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (Tr
 	// 调用之前的 execTx() 函数去运行一个事务，在事务内进行 CURD 操作
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = transferTx(ctx, q, arg, true)
+		return err
+	})
 
-		// 创建一条交易记录
-		result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID: arg.FromAccountID,
-			ToAccountID:   arg.ToAccountID,
-			Amount:        arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
+	return result, err
+}
 
-		// 创建一条 from_account 的账户金额操作记录
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
-		})
-		if err != nil {
-			return err
-		}
+// transferTx 使用传入的事务查询对象执行交易的所有操作，便于和其他操作组合在同一个事务中
+// checkLimits 为 false 时不检查限额规则，只用于关闭账户时转出余额等系统发起的交易
+func transferTx(ctx context.Context, q *Queries, arg TransferTxParams, checkLimits bool) (result TransferTxResult, err error) {
+	// 同币种交易时，转入金额等于转出金额，汇率为 1
+	toAmount := arg.ToAmount
+	exchangeRate := arg.ExchangeRate
+	if exchangeRate == "" {
+		toAmount = arg.Amount
+		exchangeRate = "1"
+	}
 
-		// 创建一条 to_account 的账户金额操作记录
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
-		})
+	// 锁定转出账户的所有者直到事务结束，同一个用户的交易依次检查限额，并发的交易不能同时通过检查
+	// 所有转出资金的事务都先锁定所有者再锁定账户，避免死锁
+	fromAccount, err := q.GetAccount(ctx, arg.FromAccountID)
+	if err != nil {
+		return
+	}
+	owner, err := q.GetUserForUpdate(ctx, fromAccount.Owner)
+	if err != nil {
+		return
+	}
+	if checkLimits {
+		err = checkTransferLimits(ctx, q, fromAccount, owner.Role, arg.Amount, time.Now())
 		if err != nil {
-			return err
-		}
-
-		// 为了避免死锁，保证程序以相同的顺序获取锁，哪个 AccountID 更小就先修改哪个 账号的金额
-		if arg.FromAccountID < arg.ToAccountID {
-			// 调用 addMoney() 更改两个账户的余额
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, -arg.Amount, arg.ToAccountID, arg.Amount)
-		} else {
-			// 调用 addMoney() 更改两个账户的余额
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, arg.Amount, arg.FromAccountID, -arg.Amount)
+			return
 		}
+	}
 
-		// 以上过程都没有错误则返回 nil
-		return nil
+	// 创建一条交易记录
+	result.Transfer, err = q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		ToAmount:      toAmount,
+		ExchangeRate:  exchangeRate,
+		QuoteID:       arg.QuoteID,
 	})
+	if err != nil {
+		return
+	}
 
-	return result, err
-}
+	// 转出和转入的条目都关联交易记录，跨币种交易时经过外汇头寸账户结算的条目不关联交易，不会出现在对账单中
+	lines, err := exchangeLines(ctx, q,
+		JournalLine{AccountID: arg.FromAccountID, Amount: -arg.Amount, TransferID: &result.Transfer.ID},
+		JournalLine{AccountID: arg.ToAccountID, Amount: toAmount, TransferID: &result.Transfer.ID},
+	)
+	if err != nil {
+		return
+	}
 
-// addMoney 一次性修改两个账号的余额
-func addMoney(
-	ctx context.Context,
-	q *Queries,
-	accountID1 int64,
-	amount1 int64,
-	accountID2 int64,
-	amount2 int64,
-) (account1 Account, account2 Account, err error) {
-	// 更新 account1 的账户余额
-	account1, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID1,
-		Amount: amount1,
+	// 已冻结或者关闭的账户不能转出或者转入，转出账户的余额不能低于允许的透支额度
+	journal, err := postJournal(ctx, q, PostJournalParams{
+		Kind:        JournalKindTransfer,
+		Description: fmt.Sprintf("transfer %d", result.Transfer.ID),
+		Lines:       lines,
 	})
 	if err != nil {
 		return
 	}
 
-	// 更新 account2 的账户余额
-	account2, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
-		ID:     accountID2,
-		Amount: amount2,
-	})
+	last := len(lines) - 1
+	result.FromEntry, result.FromAccount = journal.Entries[0], journal.Accounts[0]
+	result.ToEntry, result.ToAccount = journal.Entries[last], journal.Accounts[last]
 	return
 }
@@ -1,24 +1,26 @@
 package db
 
 import (
+	"SimpleBank/util"
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
 func TestTransferTx(t *testing.T) {
-	// 创建两个新建的账户，进行交易
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
-	// 打印交易前每个账号的余额
-	fmt.Println(">> before:", account1.Balance, account2.Balance)
-
 	// 指定创建交易条数和每次交易的金额（这里分别是 5 和 10）
 	n := 5
 	amount := int64(10)
 
+	// 创建两个新建的账户，并保证转出账户的余额足够完成所有交易
+	account1 := fundAccount(t, createRandomAccount(t), int64(n)*amount)
+	account2 := createRandomAccount(t)
+	// 打印交易前每个账号的余额
+	fmt.Println(">> before:", account1.Balance, account2.Balance)
+
 	// 声明两个 chan 类型，接收 goroutine 并发过程中的结果
 	errs := make(chan error)
 	results := make(chan TransferTxResult)
@@ -63,6 +65,9 @@ func TestTransferTx(t *testing.T) {
 		require.Equal(t, account1.ID, transfer.FromAccountID)
 		require.Equal(t, account2.ID, transfer.ToAccountID)
 		require.Equal(t, amount, transfer.Amount)
+		// 同币种交易的转入金额等于转出金额，汇率为 1
+		require.Equal(t, amount, transfer.ToAmount)
+		require.Equal(t, "1", transfer.ExchangeRate)
 
 		// 根据 transfer 的 ID 去数据库查询是否创建记录成功
 		_, err = testStore.GetTransfer(context.Background(), transfer.ID)
@@ -77,6 +82,7 @@ func TestTransferTx(t *testing.T) {
 		require.NotZero(t, fromEntry.CreatedAt)
 		require.Equal(t, account1.ID, fromEntry.AccountID)
 		require.Equal(t, -amount, fromEntry.Amount)
+		require.Equal(t, &result.Transfer.ID, fromEntry.TransferID)
 
 		// 根据 fromEntry 的 ID 去数据库查询是否创建记录成功
 		_, err = testStore.GetEntry(context.Background(), fromEntry.ID)
@@ -91,6 +97,7 @@ func TestTransferTx(t *testing.T) {
 		require.NotZero(t, toEntry.CreatedAt)
 		require.Equal(t, account2.ID, toEntry.AccountID)
 		require.Equal(t, amount, toEntry.Amount)
+		require.Equal(t, &result.Transfer.ID, toEntry.TransferID)
 
 		// 根据 toEntry 的 ID 去数据库查询是否创建记录成功
 		_, err = testStore.GetEntry(context.Background(), toEntry.ID)
@@ -144,16 +151,16 @@ func TestTransferTx(t *testing.T) {
 }
 
 func TestTransferTxDeadlock(t *testing.T) {
-	// 创建两个新建的账户，进行交易
-	account1 := createRandomAccount(t)
-	account2 := createRandomAccount(t)
-	// 打印交易前每个账号的余额
-	fmt.Println(">> before:", account1.Balance, account2.Balance)
-
-	// 指定创建交易条数和每次交易的金额（这里分别是 5 和 10）
+	// 指定创建交易条数和每次交易的金额（这里分别是 10 和 10）
 	n := 10
 	amount := int64(10)
 
+	// 创建两个新建的账户，并保证两个账户的余额都足够完成所有交易
+	account1 := fundAccount(t, createRandomAccount(t), int64(n)*amount)
+	account2 := fundAccount(t, createRandomAccount(t), int64(n)*amount)
+	// 打印交易前每个账号的余额
+	fmt.Println(">> before:", account1.Balance, account2.Balance)
+
 	// 声明两个 chan 类型，接收 goroutine 并发过程中的结果
 	errs := make(chan error)
 
@@ -204,3 +211,138 @@ func TestTransferTxDeadlock(t *testing.T) {
 	// 打印最终交易后每个账号的余额
 	fmt.Println(">> after:", updatedAccount1.Balance, updatedAccount2.Balance)
 }
+
+func TestTransferTxInsufficientFunds(t *testing.T) {
+	// 创建两个新建的账户，转出金额比转出账户的余额多 1
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	amount := account1.Balance + 1
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+	})
+	// 判断是否返回了 ErrInsufficientFunds 错误
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// 事务应该被回滚，两个账户的余额都没有发生变化
+	updatedAccount1, err := testQueries.GetAccountForUpdate(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+
+	updatedAccount2, err := testQueries.GetAccountForUpdate(context.Background(), account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
+
+func TestTransferTxOverdraftLimit(t *testing.T) {
+	// 创建两个新建的账户，并为转出账户设置透支额度
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	overdraftLimit := int64(100)
+
+	account1, err := testQueries.UpdateAccountOverdraftLimit(context.Background(), UpdateAccountOverdraftLimitParams{
+		ID:             account1.ID,
+		OverdraftLimit: overdraftLimit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, overdraftLimit, account1.OverdraftLimit)
+
+	// 转出金额刚好使余额等于透支额度的下限，交易应该成功
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        account1.Balance + overdraftLimit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, -overdraftLimit, result.FromAccount.Balance)
+
+	// 再转出 1 就会超过透支额度，交易应该失败
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        1,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestTransferTxCrossCurrency(t *testing.T) {
+	amount := int64(100)
+	account1 := fundAccount(t, createRandomAccount(t), amount)
+	account2 := createRandomAccount(t)
+
+	// 按照报价中锁定的汇率进行跨币种交易
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		ToAmount:      92,
+		ExchangeRate:  "0.92",
+		QuoteID:       "quote-id",
+	})
+	require.NoError(t, err)
+
+	// 交易记录中保存了汇率、两个金额以及报价 ID
+	transfer := result.Transfer
+	require.Equal(t, amount, transfer.Amount)
+	require.Equal(t, int64(92), transfer.ToAmount)
+	require.Equal(t, "0.92", transfer.ExchangeRate)
+	require.Equal(t, "quote-id", transfer.QuoteID)
+
+	// 转出账户按照转出金额扣款，转入账户按照换算后的金额入账
+	require.Equal(t, -amount, result.FromEntry.Amount)
+	require.Equal(t, int64(92), result.ToEntry.Amount)
+	require.Equal(t, account1.Balance-amount, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+92, result.ToAccount.Balance)
+}
+
+func TestIdempotentTransferTx(t *testing.T) {
+	amount := int64(10)
+	account1 := fundAccount(t, createRandomAccount(t), 2*amount)
+	account2 := createRandomAccount(t)
+
+	arg := IdempotentTransferTxParams{
+		TransferTxParams: TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        amount,
+		},
+		Username:    account1.Owner,
+		Key:         util.RandomString(16),
+		RequestHash: util.RandomString(64),
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	// 第一次使用幂等键，应该执行交易
+	result1, err := testStore.IdempotentTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, result1.Replayed)
+	require.NotZero(t, result1.Transfer.ID)
+
+	// 使用相同的幂等键和请求重试，应该返回第一次的结果而不会再次扣款
+	result2, err := testStore.IdempotentTransferTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result2.Replayed)
+	require.Equal(t, result1.Transfer.ID, result2.Transfer.ID)
+	require.Equal(t, result1.FromAccount.Balance, result2.FromAccount.Balance)
+
+	updatedAccount1, err := testQueries.GetAccountForUpdate(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-amount, updatedAccount1.Balance)
+
+	// 使用相同的幂等键但是不同的请求，应该返回 ErrIdempotencyKeyReused
+	arg.RequestHash = util.RandomString(64)
+	_, err = testStore.IdempotentTransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+}
+
+// fundAccount 为账户增加指定的余额，保证之后的交易不会因为余额不足而失败
+func fundAccount(t *testing.T, account Account, amount int64) Account {
+	account, err := testQueries.AddAccountBalance(context.Background(), AddAccountBalanceParams{
+		ID:     account.ID,
+		Amount: amount,
+	})
+	require.NoError(t, err)
+	return account
+}
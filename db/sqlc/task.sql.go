@@ -0,0 +1,244 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: task.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgtype"
+)
+
+const claimDueTask = `-- name: ClaimDueTask :one
+WITH due AS (
+  SELECT t.id FROM tasks AS t
+  WHERE t.status IN ('pending', 'running')
+    AND t.run_at <= $2
+  ORDER BY t.run_at
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+UPDATE tasks
+SET status = CASE WHEN tasks.attempts >= tasks.max_attempts THEN 'dead' ELSE 'running' END,
+    attempts = CASE WHEN tasks.attempts >= tasks.max_attempts THEN tasks.attempts ELSE tasks.attempts + 1 END,
+    run_at = CASE WHEN tasks.attempts >= tasks.max_attempts THEN tasks.run_at ELSE $1::timestamptz END,
+    last_error = CASE WHEN tasks.attempts >= tasks.max_attempts THEN 'task lease expired' ELSE tasks.last_error END,
+    updated_at = now()
+FROM due
+WHERE tasks.id = due.id
+RETURNING tasks.id, tasks.type, tasks.payload, tasks.status, tasks.attempts, tasks.max_attempts, tasks.run_at, tasks.last_error, tasks.updated_at, tasks.created_at
+`
+
+type ClaimDueTaskParams struct {
+	LeaseUntil time.Time `json:"lease_until"`
+	Now        time.Time `json:"now"`
+}
+
+// 领取一个已经到期的待处理任务，或者租约已经到期的处理中任务，被其他处理器锁定的行会被跳过
+// 领取时即增加一次尝试次数，run_at 设置为租约的到期时间，处理器崩溃或者处理超时也会计入尝试次数
+// 租约到期的任务若已经达到最大尝试次数，则不再领取，直接进入死信状态
+func (q *Queries) ClaimDueTask(ctx context.Context, arg ClaimDueTaskParams) (Task, error) {
+	row := q.db.QueryRow(ctx, claimDueTask, arg.LeaseUntil, arg.Now)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LastError,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createTask = `-- name: CreateTask :one
+INSERT INTO tasks (
+  type,
+  payload,
+  max_attempts,
+  run_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, updated_at, created_at
+`
+
+type CreateTaskParams struct {
+	Type        string       `json:"type"`
+	Payload     pgtype.JSONB `json:"payload"`
+	MaxAttempts int32        `json:"max_attempts"`
+	RunAt       time.Time    `json:"run_at"`
+}
+
+func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error) {
+	row := q.db.QueryRow(ctx, createTask,
+		arg.Type,
+		arg.Payload,
+		arg.MaxAttempts,
+		arg.RunAt,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LastError,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTask = `-- name: GetTask :one
+SELECT id, type, payload, status, attempts, max_attempts, run_at, last_error, updated_at, created_at FROM tasks
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetTask(ctx context.Context, id int64) (Task, error) {
+	row := q.db.QueryRow(ctx, getTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LastError,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listDeadTasks = `-- name: ListDeadTasks :many
+SELECT id, type, payload, status, attempts, max_attempts, run_at, last_error, updated_at, created_at FROM tasks
+WHERE status = 'dead'
+  AND id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListDeadTasksParams struct {
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
+}
+
+// 查询已经超过最大尝试次数的任务，便于排查问题后重新处理
+func (q *Queries) ListDeadTasks(ctx context.Context, arg ListDeadTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listDeadTasks, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.MaxAttempts,
+			&i.RunAt,
+			&i.LastError,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const requeueDeadTask = `-- name: RequeueDeadTask :one
+UPDATE tasks
+SET status = 'pending',
+    attempts = 0,
+    run_at = now(),
+    updated_at = now()
+WHERE id = $1
+  AND status = 'dead'
+RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, updated_at, created_at
+`
+
+// 将死信任务重新放回队列，并重置尝试次数
+func (q *Queries) RequeueDeadTask(ctx context.Context, id int64) (Task, error) {
+	row := q.db.QueryRow(ctx, requeueDeadTask, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LastError,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateTaskResult = `-- name: UpdateTaskResult :one
+UPDATE tasks
+SET status = $1,
+    run_at = $2,
+    last_error = $3,
+    updated_at = now()
+WHERE id = $4
+  AND status = 'running'
+  AND attempts = $5
+RETURNING id, type, payload, status, attempts, max_attempts, run_at, last_error, updated_at, created_at
+`
+
+type UpdateTaskResultParams struct {
+	Status    string    `json:"status"`
+	RunAt     time.Time `json:"run_at"`
+	LastError string    `json:"last_error"`
+	ID        int64     `json:"id"`
+	Attempts  int32     `json:"attempts"`
+}
+
+// 记录一次处理的结果，尝试次数已经在领取任务时增加
+// 只有任务没有被其他处理器重新领取时才能记录，重新领取会增加尝试次数，因此 attempts 与领取时不同
+func (q *Queries) UpdateTaskResult(ctx context.Context, arg UpdateTaskResultParams) (Task, error) {
+	row := q.db.QueryRow(ctx, updateTaskResult,
+		arg.Status,
+		arg.RunAt,
+		arg.LastError,
+		arg.ID,
+		arg.Attempts,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.MaxAttempts,
+		&i.RunAt,
+		&i.LastError,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,221 @@
+package db
+
+import (
+	"SimpleBank/util"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTask(t *testing.T, maxAttempts int32, runAt time.Time) Task {
+	arg := CreateTaskParams{
+		Type:        "task:" + util.RandomString(6),
+		Payload:     pgtype.JSONB{Bytes: []byte(`{"username":"` + util.RandomOwner() + `"}`), Status: pgtype.Present},
+		MaxAttempts: maxAttempts,
+		RunAt:       runAt,
+	}
+
+	task, err := testQueries.CreateTask(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, task.ID)
+	require.Equal(t, arg.Type, task.Type)
+	require.JSONEq(t, string(arg.Payload.Bytes), string(task.Payload.Bytes))
+	require.Equal(t, TaskStatusPending, task.Status)
+	require.Zero(t, task.Attempts)
+	require.WithinDuration(t, arg.RunAt, task.RunAt, time.Second)
+
+	return task
+}
+
+func TestProcessDueTask(t *testing.T) {
+	runAt := randomDueTime()
+	task := createRandomTask(t, 3, runAt)
+	now := runAt.Add(time.Second)
+
+	var handled Task
+	result, err := testStore.ProcessDueTask(context.Background(), ProcessDueTaskParams{
+		Now:   now,
+		Lease: time.Minute,
+		Handle: func(ctx context.Context, task Task) error {
+			// 处理任务时任务已经被标记为处理中，并且不在事务之中
+			stored, err := testQueries.GetTask(ctx, task.ID)
+			require.NoError(t, err)
+			require.Equal(t, TaskStatusRunning, stored.Status)
+			handled = task
+			return nil
+		},
+		RetryDelay: func(attempts int32) time.Duration { return time.Minute },
+	})
+	require.NoError(t, err)
+	require.Equal(t, task.ID, handled.ID)
+	require.Equal(t, task.ID, result.ID)
+	require.Equal(t, TaskStatusSucceeded, result.Status)
+	require.Equal(t, int32(1), result.Attempts)
+	require.Empty(t, result.LastError)
+
+	// 处理成功的任务不会被再次处理
+	_, err = testStore.ProcessDueTask(context.Background(), ProcessDueTaskParams{
+		Now:    now,
+		Lease:  time.Minute,
+		Handle: func(ctx context.Context, task Task) error { return nil },
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestProcessDueTaskRetry(t *testing.T) {
+	runAt := randomDueTime()
+	task := createRandomTask(t, 2, runAt)
+	now := runAt.Add(time.Second)
+
+	arg := ProcessDueTaskParams{
+		Now:   now,
+		Lease: time.Minute,
+		Handle: func(ctx context.Context, task Task) error {
+			return errors.New("smtp unavailable")
+		},
+		RetryDelay: func(attempts int32) time.Duration { return time.Duration(attempts) * time.Minute },
+	}
+
+	// 第一次处理失败，任务在重试间隔之后才会再次到期
+	result, err := testStore.ProcessDueTask(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, task.ID, result.ID)
+	require.Equal(t, TaskStatusPending, result.Status)
+	require.Equal(t, int32(1), result.Attempts)
+	require.Equal(t, "smtp unavailable", result.LastError)
+	require.WithinDuration(t, now.Add(time.Minute), result.RunAt, time.Second)
+
+	_, err = testStore.ProcessDueTask(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// 达到最大尝试次数之后进入死信状态
+	arg.Now = result.RunAt
+	result, err = testStore.ProcessDueTask(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, task.ID, result.ID)
+	require.Equal(t, TaskStatusDead, result.Status)
+	require.Equal(t, int32(2), result.Attempts)
+
+	// 死信任务可以被重新放回队列
+	requeued, err := testQueries.RequeueDeadTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.Equal(t, TaskStatusPending, requeued.Status)
+	require.Zero(t, requeued.Attempts)
+
+	_, err = testQueries.RequeueDeadTask(context.Background(), task.ID)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestProcessDueTaskLeaseExpired(t *testing.T) {
+	runAt := randomDueTime()
+	task := createRandomTask(t, 3, runAt)
+	now := runAt.Add(time.Second)
+
+	// 处理超过租约时长时，任务被其他处理器重新领取，本次的处理结果不会被记录
+	_, err := testStore.ProcessDueTask(context.Background(), ProcessDueTaskParams{
+		Now:   now,
+		Lease: time.Millisecond,
+		Handle: func(ctx context.Context, task Task) error {
+			<-ctx.Done()
+			// 模拟其他处理器在租约到期之后重新领取该任务
+			simulateClaimedTask(t, task.ID, task.Attempts+1, time.Now().Add(time.Minute))
+			return ctx.Err()
+		},
+		RetryDelay: func(attempts int32) time.Duration { return time.Minute },
+	})
+	require.ErrorIs(t, err, ErrTaskLeaseExpired)
+
+	stored, err := testQueries.GetTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	require.Equal(t, TaskStatusRunning, stored.Status)
+	require.Equal(t, int32(2), stored.Attempts)
+}
+
+// simulateClaimedTask 将任务设置为已经被领取了 attempts 次、租约在 leaseUntil 到期的处理中状态
+func simulateClaimedTask(t *testing.T, id int64, attempts int32, leaseUntil time.Time) {
+	_, err := testQueries.db.Exec(context.Background(),
+		"UPDATE tasks SET status = 'running', attempts = $2, run_at = $3 WHERE id = $1", id, attempts, leaseUntil)
+	require.NoError(t, err)
+}
+
+func TestProcessDueTaskCrashedProcessor(t *testing.T) {
+	runAt := randomDueTime()
+	task := createRandomTask(t, 2, runAt)
+	now := runAt.Add(time.Second)
+
+	// 处理器领取任务之后崩溃，租约到期之后任务被重新领取，崩溃的那一次也计入尝试次数
+	simulateClaimedTask(t, task.ID, 1, runAt)
+	arg := ProcessDueTaskParams{
+		Now:        now,
+		Lease:      time.Minute,
+		Handle:     func(ctx context.Context, task Task) error { return errors.New("smtp unavailable") },
+		RetryDelay: func(attempts int32) time.Duration { return time.Minute },
+	}
+	result, err := testStore.ProcessDueTask(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, task.ID, result.ID)
+	require.Equal(t, TaskStatusDead, result.Status)
+	require.Equal(t, int32(2), result.Attempts)
+
+	// 在最后一次尝试中再次崩溃的任务不会被再次处理，直接进入死信状态
+	task = createRandomTask(t, 2, runAt)
+	simulateClaimedTask(t, task.ID, 2, runAt)
+	arg.Handle = func(ctx context.Context, task Task) error {
+		require.FailNow(t, "task with no attempts left must not be handled")
+		return nil
+	}
+	result, err = testStore.ProcessDueTask(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, task.ID, result.ID)
+	require.Equal(t, TaskStatusDead, result.Status)
+	require.Equal(t, int32(2), result.Attempts)
+	require.Equal(t, "task lease expired", result.LastError)
+}
+
+func TestCreateUserTx(t *testing.T) {
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	arg := CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomOwner(),
+			HashedPassword: hashedPassword,
+			FullName:       util.RandomOwner(),
+			Email:          util.RandomEmail(),
+		},
+	}
+
+	// AfterCreate 失败时用户不会被创建
+	arg.AfterCreate = func(q Querier, user User) error {
+		return errors.New("cannot enqueue task")
+	}
+	_, err = testStore.CreateUserTx(context.Background(), arg)
+	require.Error(t, err)
+
+	_, err = testQueries.GetUser(context.Background(), arg.Username)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// AfterCreate 和创建用户在同一个事务中提交
+	var task Task
+	arg.AfterCreate = func(q Querier, user User) error {
+		var err error
+		task, err = q.CreateTask(context.Background(), CreateTaskParams{
+			Type:        "task:test",
+			Payload:     pgtype.JSONB{Bytes: []byte(`{"username":"` + user.Username + `"}`), Status: pgtype.Present},
+			MaxAttempts: 1,
+			RunAt:       time.Now(),
+		})
+		return err
+	}
+	result, err := testStore.CreateUserTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, result.User.Username)
+
+	_, err = testQueries.GetTask(context.Background(), task.ID)
+	require.NoError(t, err)
+}
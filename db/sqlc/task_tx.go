@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 定义任务的所有状态，超过最大尝试次数的任务进入 dead 状态，作为死信保留在表中
+const (
+	TaskStatusPending = "pending"
+	// 任务已经被一个处理器领取，在租约到期之前其他处理器不会处理该任务
+	TaskStatusRunning   = "running"
+	TaskStatusSucceeded = "succeeded"
+	TaskStatusDead      = "dead"
+)
+
+// ProcessDueTaskParams 包含处理一个到期任务所需要的输入参数
+type ProcessDueTaskParams struct {
+	Now time.Time
+	// Lease 是处理一个任务的租约时长，Handle 超过租约仍未返回时会被取消，任务会被其他处理器重新领取
+	Lease time.Duration
+	// Handle 处理任务，返回错误时任务会在一段时间之后重试，或者在超过最大尝试次数之后进入死信状态
+	Handle func(ctx context.Context, task Task) error
+	// RetryDelay 返回任务第 attempts 次处理失败之后，到下一次重试之间需要等待的时间
+	RetryDelay func(attempts int32) time.Duration
+}
+
+// ProcessDueTask 领取一个在 now 之前到期的任务并处理，处理之后记录处理的结果
+// 领取任务和记录结果分别是两个很短的事务，Handle 在事务之外执行，发送邮件等耗时的操作不会长时间占用数据库连接和行锁
+// 被其他处理器领取的任务会被跳过，没有可以处理的任务时返回 pgx.ErrNoRows
+// 处理器在处理过程中崩溃时，任务会在租约到期之后被重新处理，因此 Handle 需要能够承受重复执行
+// 领取任务时即计入一次尝试，租约到期时已经达到最大尝试次数的任务不会再被处理，直接返回进入死信状态的任务
+func (store *SQLStore) ProcessDueTask(ctx context.Context, arg ProcessDueTaskParams) (Task, error) {
+	leaseUntil := time.Now().Add(arg.Lease)
+
+	// 领取任务只有一条语句，提交之后行锁立即释放
+	task, err := store.ClaimDueTask(ctx, ClaimDueTaskParams{
+		LeaseUntil: leaseUntil,
+		Now:        arg.Now,
+	})
+	if err != nil {
+		return Task{}, err
+	}
+	if task.Status == TaskStatusDead {
+		return task, nil
+	}
+
+	// 在租约到期之前结束处理，避免与重新领取该任务的处理器同时处理
+	handleCtx, cancel := context.WithDeadline(ctx, leaseUntil)
+	defer cancel()
+
+	update := UpdateTaskResultParams{
+		ID:       task.ID,
+		Status:   TaskStatusSucceeded,
+		RunAt:    arg.Now,
+		Attempts: task.Attempts,
+	}
+	if err := arg.Handle(handleCtx, task); err != nil {
+		update.LastError = err.Error()
+		// task.Attempts 已经包括本次处理
+		if task.Attempts >= task.MaxAttempts {
+			update.Status = TaskStatusDead
+		} else {
+			update.Status = TaskStatusPending
+			update.RunAt = arg.Now.Add(arg.RetryDelay(task.Attempts))
+		}
+	}
+
+	result, err := store.UpdateTaskResult(ctx, update)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// 租约已经到期，任务被其他处理器重新领取，由其记录处理的结果
+		return Task{}, ErrTaskLeaseExpired
+	}
+	return result, err
+}
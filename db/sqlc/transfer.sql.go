@@ -7,26 +7,77 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
+const addTransferReversedAmount = `-- name: AddTransferReversedAmount :one
+UPDATE transfers
+SET
+  reversed_amount = reversed_amount + $1,
+  status = CASE
+    WHEN reversed_amount + $1 = amount THEN 'reversed'
+    ELSE 'partially_reversed'
+  END
+WHERE id = $2
+RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id, status, reversed_amount
+`
+
+type AddTransferReversedAmountParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+// 累加已退款的金额，全部退款之后交易的状态为 reversed ，否则为 partially_reversed
+func (q *Queries) AddTransferReversedAmount(ctx context.Context, arg AddTransferReversedAmountParams) (Transfer, error) {
+	row := q.db.QueryRow(ctx, addTransferReversedAmount, arg.Amount, arg.ID)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
+		&i.Status,
+		&i.ReversedAmount,
+	)
+	return i, err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  to_amount,
+  exchange_rate,
+  quote_id
 ) VALUES (
-  $1, $2, $3
-) RETURNING id, from_account_id, to_account_id, amount, created_at
+  $1, $2, $3, $4, $5, $6
+) RETURNING id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id, status, reversed_amount
 `
 
 type CreateTransferParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	Amount        int64  `json:"amount"`
+	ToAmount      int64  `json:"to_amount"`
+	ExchangeRate  string `json:"exchange_rate"`
+	QuoteID       string `json:"quote_id"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
-	row := q.db.QueryRow(ctx, createTransfer, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRow(ctx, createTransfer,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.ExchangeRate,
+		arg.QuoteID,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -34,12 +85,17 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
+		&i.Status,
+		&i.ReversedAmount,
 	)
 	return i, err
 }
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id, status, reversed_amount FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
@@ -52,31 +108,89 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (Transfer, error) {
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
+		&i.Status,
+		&i.ReversedAmount,
+	)
+	return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id, status, reversed_amount FROM transfers
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+// 冲正时锁定原交易，同一笔交易的冲正依次执行，避免重复冲正
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.ToAmount,
+		&i.ExchangeRate,
+		&i.QuoteID,
+		&i.Status,
+		&i.ReversedAmount,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
-WHERE from_account_id = $1 OR to_account_id = $2
+SELECT id, from_account_id, to_account_id, amount, created_at, to_amount, exchange_rate, quote_id, status, reversed_amount FROM transfers
+WHERE (
+    (from_account_id = $1 AND $2::varchar IS DISTINCT FROM 'incoming')
+    OR (to_account_id = $1 AND $2::varchar IS DISTINCT FROM 'outgoing')
+  )
+  AND ($3::bigint IS NULL
+    OR from_account_id = $3
+    OR to_account_id = $3)
+  AND ($4::bigint IS NULL
+    OR (CASE WHEN from_account_id = $1 THEN amount ELSE to_amount END) >= $4)
+  AND ($5::bigint IS NULL
+    OR (CASE WHEN from_account_id = $1 THEN amount ELSE to_amount END) <= $5)
+  AND ($6::timestamptz IS NULL OR created_at >= $6)
+  AND ($7::timestamptz IS NULL OR created_at < $7)
+  AND id > $8
 ORDER BY id
-LIMIT $3 /* 进行分页显示，设置想要获取的行数 */
-OFFSET $4 /* 在开始返回结果之前跳过指定的行数 */
+LIMIT $10 /* 进行分页显示，设置想要获取的行数 */
+OFFSET $9 /* 在开始返回结果之前跳过指定的行数 */
 `
 
 type ListTransfersParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Limit         int32 `json:"limit"`
-	Offset        int32 `json:"offset"`
+	AccountID             int64          `json:"account_id"`
+	Direction             sql.NullString `json:"direction"`
+	CounterpartyAccountID *int64         `json:"counterparty_account_id"`
+	MinAmount             *int64         `json:"min_amount"`
+	MaxAmount             *int64         `json:"max_amount"`
+	StartTime             *time.Time     `json:"start_time"`
+	EndTime               *time.Time     `json:"end_time"`
+	AfterID               int64          `json:"after_id"`
+	Offset                int32          `json:"offset"`
+	Limit                 int32          `json:"limit"`
 }
 
+// 查询与指定账户相关的所有交易（转出和转入），可选的过滤条件为 NULL 时不进行过滤
+// 支持两种分页方式：页码分页时 after_id 为 0 ，游标分页时 offset 为 0 并从上一页最后一条记录的 id 之后开始查询
+// 金额范围按照该账户的货币计算：转出交易使用 amount ，转入交易使用 to_amount
 func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
 	rows, err := q.db.Query(ctx, listTransfers,
-		arg.FromAccountID,
-		arg.ToAccountID,
-		arg.Limit,
+		arg.AccountID,
+		arg.Direction,
+		arg.CounterpartyAccountID,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.StartTime,
+		arg.EndTime,
+		arg.AfterID,
 		arg.Offset,
+		arg.Limit,
 	)
 	if err != nil {
 		return nil, err
@@ -91,6 +205,11 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.ToAmount,
+			&i.ExchangeRate,
+			&i.QuoteID,
+			&i.Status,
+			&i.ReversedAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,173 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 定义交易限额规则的统计范围
+const (
+	// 统计转出账户的交易
+	TransferLimitScopeAccount = "account"
+	// 统计转出账户的所有者所有该货币的账户的交易
+	TransferLimitScopeUser = "user"
+)
+
+// 定义交易限额规则的时间窗口
+const (
+	// 单笔交易
+	TransferLimitPeriodTransfer = "transfer"
+	// 最近一小时
+	TransferLimitPeriodHour = "hour"
+	// 当前的 UTC 自然日
+	TransferLimitPeriodDay = "day"
+	// 当前的 UTC 自然月
+	TransferLimitPeriodMonth = "month"
+)
+
+// 定义交易限额规则限制的指标
+const (
+	// 转出的金额，以最小货币单位表示
+	TransferLimitMetricAmount = "amount"
+	// 转出的交易笔数
+	TransferLimitMetricCount = "count"
+)
+
+// TransferLimitViolation 描述交易违反的一条限额规则
+type TransferLimitViolation struct {
+	RuleID   string `json:"rule_id"`
+	Scope    string `json:"scope"`
+	Period   string `json:"period"`
+	Metric   string `json:"metric"`
+	Currency string `json:"currency"`
+	// 规则允许的最大值
+	Limit int64 `json:"limit"`
+	// 当前窗口内已经使用的金额或者笔数，不包括本次交易
+	Used int64 `json:"used"`
+	// 本次交易的金额或者笔数
+	Requested int64 `json:"requested"`
+}
+
+// TransferLimitError 表示交易违反了一条或者多条限额规则，可以使用 errors.Is 与 ErrTransferLimitExceeded 比较
+type TransferLimitError struct {
+	Violations []TransferLimitViolation
+}
+
+func (e *TransferLimitError) Error() string {
+	ruleIDs := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		ruleIDs[i] = violation.RuleID
+	}
+	return fmt.Sprintf("%s: %s", ErrTransferLimitExceeded, strings.Join(ruleIDs, ", "))
+}
+
+func (e *TransferLimitError) Unwrap() error {
+	return ErrTransferLimitExceeded
+}
+
+// transferLimitWindowStart 返回时间窗口在 now 时的开始时间
+func transferLimitWindowStart(period string, now time.Time) (time.Time, error) {
+	now = now.UTC()
+	switch period {
+	case TransferLimitPeriodHour:
+		return now.Add(-time.Hour), nil
+	case TransferLimitPeriodDay:
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	case TransferLimitPeriodMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Time{}, fmt.Errorf("unsupported transfer limit period %q", period)
+}
+
+// checkTransferLimits 检查从 account 转出 amount 是否违反适用于该货币和所有者角色的限额规则，违反时返回 *TransferLimitError
+// 调用之前必须在同一个事务中锁定账户的所有者，保证并发的交易不能同时通过检查而超出限额
+func checkTransferLimits(ctx context.Context, q *Queries, account Account, role string, amount int64, now time.Time) error {
+	rules, err := q.ListTransferLimitRules(ctx, ListTransferLimitRulesParams{
+		Currency: account.Currency,
+		Role:     role,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 多条规则可能统计同一个范围和时间窗口，每个窗口只查询一次
+	type window struct {
+		scope  string
+		period string
+	}
+	usages := make(map[window]SumAccountTransfersRow)
+
+	var violations []TransferLimitViolation
+	for _, rule := range rules {
+		var used SumAccountTransfersRow
+		if rule.Period != TransferLimitPeriodTransfer {
+			key := window{scope: rule.Scope, period: rule.Period}
+			var ok bool
+			if used, ok = usages[key]; !ok {
+				used, err = transferLimitUsage(ctx, q, account, rule.Scope, rule.Period, now)
+				if err != nil {
+					return fmt.Errorf("cannot evaluate transfer limit %s: %w", rule.ID, err)
+				}
+				usages[key] = used
+			}
+		}
+
+		if violation, violated := evaluateTransferLimitRule(rule, used, amount); violated {
+			violations = append(violations, violation)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &TransferLimitError{Violations: violations}
+	}
+	return nil
+}
+
+// transferLimitUsage 汇总 scope 范围内在 period 时间窗口中已经转出的交易
+func transferLimitUsage(ctx context.Context, q *Queries, account Account, scope, period string, now time.Time) (SumAccountTransfersRow, error) {
+	since, err := transferLimitWindowStart(period, now)
+	if err != nil {
+		return SumAccountTransfersRow{}, err
+	}
+
+	switch scope {
+	case TransferLimitScopeAccount:
+		return q.SumAccountTransfers(ctx, SumAccountTransfersParams{
+			AccountID: account.ID,
+			Since:     since,
+		})
+	case TransferLimitScopeUser:
+		used, err := q.SumUserTransfers(ctx, SumUserTransfersParams{
+			Owner:    account.Owner,
+			Currency: account.Currency,
+			Since:    since,
+		})
+		return SumAccountTransfersRow(used), err
+	}
+	return SumAccountTransfersRow{}, fmt.Errorf("unsupported transfer limit scope %q", scope)
+}
+
+// evaluateTransferLimitRule 检查在已经使用 used 的情况下再转出 amount 是否违反规则
+func evaluateTransferLimitRule(rule TransferLimitRule, used SumAccountTransfersRow, amount int64) (TransferLimitViolation, bool) {
+	violation := TransferLimitViolation{
+		RuleID:   rule.ID,
+		Scope:    rule.Scope,
+		Period:   rule.Period,
+		Metric:   rule.Metric,
+		Currency: rule.Currency,
+		Limit:    rule.MaxValue,
+	}
+
+	if rule.Metric == TransferLimitMetricCount {
+		violation.Used = used.TransferCount
+		violation.Requested = 1
+	} else {
+		violation.Used = used.TotalAmount
+		violation.Requested = amount
+	}
+
+	// 使用减法比较，避免金额相加溢出
+	return violation, violation.Requested > violation.Limit-violation.Used
+}
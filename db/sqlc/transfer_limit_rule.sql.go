@@ -0,0 +1,185 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: transfer_limit_rule.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const createTransferLimitRule = `-- name: CreateTransferLimitRule :one
+INSERT INTO transfer_limit_rules (
+  id,
+  scope,
+  period,
+  metric,
+  currency,
+  role,
+  max_value
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, scope, period, metric, currency, role, max_value, created_at
+`
+
+type CreateTransferLimitRuleParams struct {
+	ID       string `json:"id"`
+	Scope    string `json:"scope"`
+	Period   string `json:"period"`
+	Metric   string `json:"metric"`
+	Currency string `json:"currency"`
+	Role     string `json:"role"`
+	MaxValue int64  `json:"max_value"`
+}
+
+func (q *Queries) CreateTransferLimitRule(ctx context.Context, arg CreateTransferLimitRuleParams) (TransferLimitRule, error) {
+	row := q.db.QueryRow(ctx, createTransferLimitRule,
+		arg.ID,
+		arg.Scope,
+		arg.Period,
+		arg.Metric,
+		arg.Currency,
+		arg.Role,
+		arg.MaxValue,
+	)
+	var i TransferLimitRule
+	err := row.Scan(
+		&i.ID,
+		&i.Scope,
+		&i.Period,
+		&i.Metric,
+		&i.Currency,
+		&i.Role,
+		&i.MaxValue,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteTransferLimitRule = `-- name: DeleteTransferLimitRule :exec
+DELETE FROM transfer_limit_rules
+WHERE id = $1
+`
+
+func (q *Queries) DeleteTransferLimitRule(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, deleteTransferLimitRule, id)
+	return err
+}
+
+const listTransferLimitRules = `-- name: ListTransferLimitRules :many
+SELECT id, scope, period, metric, currency, role, max_value, created_at FROM transfer_limit_rules
+WHERE currency = $1 AND role = $2
+ORDER BY id
+`
+
+type ListTransferLimitRulesParams struct {
+	Currency string `json:"currency"`
+	Role     string `json:"role"`
+}
+
+// 返回适用于指定货币和角色的所有限额规则
+func (q *Queries) ListTransferLimitRules(ctx context.Context, arg ListTransferLimitRulesParams) ([]TransferLimitRule, error) {
+	rows, err := q.db.Query(ctx, listTransferLimitRules, arg.Currency, arg.Role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferLimitRule{}
+	for rows.Next() {
+		var i TransferLimitRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.Scope,
+			&i.Period,
+			&i.Metric,
+			&i.Currency,
+			&i.Role,
+			&i.MaxValue,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumAccountTransfers = `-- name: SumAccountTransfers :one
+SELECT
+  COALESCE(SUM(outflows.amount), 0)::bigint AS total_amount,
+  COUNT(*) FILTER (WHERE outflows.counted) AS transfer_count
+FROM (
+  SELECT (transfers.amount - transfers.reversed_amount)::bigint AS amount, transfers.status <> 'reversed' AS counted
+  FROM transfers
+  WHERE transfers.from_account_id = $1
+    AND transfers.created_at >= $2
+  UNION ALL
+  SELECT withdrawals.amount, true AS counted
+  FROM withdrawals
+  WHERE withdrawals.account_id = $1
+    AND withdrawals.created_at >= $2
+) AS outflows
+`
+
+type SumAccountTransfersParams struct {
+	AccountID int64     `json:"account_id"`
+	Since     time.Time `json:"since"`
+}
+
+type SumAccountTransfersRow struct {
+	TotalAmount   int64 `json:"total_amount"`
+	TransferCount int64 `json:"transfer_count"`
+}
+
+// 汇总账户从 since 开始转出的交易和取款，已经退款的金额不计入，全部退款的交易不计入笔数
+func (q *Queries) SumAccountTransfers(ctx context.Context, arg SumAccountTransfersParams) (SumAccountTransfersRow, error) {
+	row := q.db.QueryRow(ctx, sumAccountTransfers, arg.AccountID, arg.Since)
+	var i SumAccountTransfersRow
+	err := row.Scan(&i.TotalAmount, &i.TransferCount)
+	return i, err
+}
+
+const sumUserTransfers = `-- name: SumUserTransfers :one
+SELECT
+  COALESCE(SUM(outflows.amount), 0)::bigint AS total_amount,
+  COUNT(*) FILTER (WHERE outflows.counted) AS transfer_count
+FROM (
+  SELECT (transfers.amount - transfers.reversed_amount)::bigint AS amount, transfers.status <> 'reversed' AS counted
+  FROM transfers
+  JOIN accounts ON accounts.id = transfers.from_account_id
+  WHERE accounts.owner = $1
+    AND accounts.currency = $2
+    AND transfers.created_at >= $3
+  UNION ALL
+  SELECT withdrawals.amount, true AS counted
+  FROM withdrawals
+  JOIN accounts ON accounts.id = withdrawals.account_id
+  WHERE accounts.owner = $1
+    AND accounts.currency = $2
+    AND withdrawals.created_at >= $3
+) AS outflows
+`
+
+type SumUserTransfersParams struct {
+	Owner    string    `json:"owner"`
+	Currency string    `json:"currency"`
+	Since    time.Time `json:"since"`
+}
+
+type SumUserTransfersRow struct {
+	TotalAmount   int64 `json:"total_amount"`
+	TransferCount int64 `json:"transfer_count"`
+}
+
+// 汇总用户所有该货币的账户从 since 开始转出的交易和取款，已经退款的金额不计入，全部退款的交易不计入笔数
+func (q *Queries) SumUserTransfers(ctx context.Context, arg SumUserTransfersParams) (SumUserTransfersRow, error) {
+	row := q.db.QueryRow(ctx, sumUserTransfers, arg.Owner, arg.Currency, arg.Since)
+	var i SumUserTransfersRow
+	err := row.Scan(&i.TotalAmount, &i.TransferCount)
+	return i, err
+}
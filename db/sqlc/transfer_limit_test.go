@@ -0,0 +1,285 @@
+package db
+
+import (
+	"SimpleBank/util"
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createBankerAccount 创建一个银行职员的账户，默认的限额规则只适用于客户，测试中的规则不会受到默认规则的影响
+func createBankerAccount(t *testing.T, currency string) Account {
+	user := createRandomUser(t)
+	_, err := testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		Username: user.Username,
+		Role:     sql.NullString{String: util.BankerRole, Valid: true},
+	})
+	require.NoError(t, err)
+
+	account, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    user.Username,
+		Currency: currency,
+	})
+	require.NoError(t, err)
+	return fundAccount(t, account, 1000)
+}
+
+// addTransferLimitRule 创建一条适用于银行职员的限额规则，测试结束时删除
+func addTransferLimitRule(t *testing.T, currency, scope, period, metric string, maxValue int64) TransferLimitRule {
+	rule, err := testQueries.CreateTransferLimitRule(context.Background(), CreateTransferLimitRuleParams{
+		ID:       "test-" + util.RandomString(12),
+		Scope:    scope,
+		Period:   period,
+		Metric:   metric,
+		Currency: currency,
+		Role:     util.BankerRole,
+		MaxValue: maxValue,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, testQueries.DeleteTransferLimitRule(context.Background(), rule.ID))
+	})
+	return rule
+}
+
+// requireTransferLimitViolation 检查错误是否为违反了 ruleID 的限额错误
+func requireTransferLimitViolation(t *testing.T, err error, ruleID string) TransferLimitViolation {
+	require.ErrorIs(t, err, ErrTransferLimitExceeded)
+
+	var limitErr *TransferLimitError
+	require.True(t, errors.As(err, &limitErr))
+	for _, violation := range limitErr.Violations {
+		if violation.RuleID == ruleID {
+			return violation
+		}
+	}
+	require.FailNow(t, "rule is not violated", ruleID)
+	return TransferLimitViolation{}
+}
+
+func TestTransferTxSingleLimit(t *testing.T) {
+	account1 := createBankerAccount(t, util.USD)
+	account2 := createAccountInCurrency(t, util.USD)
+	rule := addTransferLimitRule(t, util.USD, TransferLimitScopeAccount, TransferLimitPeriodTransfer, TransferLimitMetricAmount, 50)
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 50})
+	require.NoError(t, err)
+
+	// 单笔交易的限额与之前的交易无关
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 51})
+	violation := requireTransferLimitViolation(t, err, rule.ID)
+	require.Equal(t, int64(50), violation.Limit)
+	require.Equal(t, int64(0), violation.Used)
+	require.Equal(t, int64(51), violation.Requested)
+
+	// 违反限额的交易被回滚，转出账户的余额不变
+	account, err := testQueries.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-50, account.Balance)
+}
+
+func TestTransferTxDailyUserLimit(t *testing.T) {
+	account1 := createBankerAccount(t, util.EUR)
+	account2 := createAccountInCurrency(t, util.EUR)
+	rule := addTransferLimitRule(t, util.EUR, TransferLimitScopeUser, TransferLimitPeriodDay, TransferLimitMetricAmount, 100)
+
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 60})
+	require.NoError(t, err)
+
+	// 当天已经转出的金额加上本次交易超过了限额
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 60})
+	violation := requireTransferLimitViolation(t, err, rule.ID)
+	require.Equal(t, int64(60), violation.Used)
+	require.Equal(t, int64(60), violation.Requested)
+
+	// 不超过剩余额度的交易仍然可以成功
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 40})
+	require.NoError(t, err)
+}
+
+func TestTransferTxHourlyCountLimit(t *testing.T) {
+	account1 := createBankerAccount(t, util.CAD)
+	account2 := createAccountInCurrency(t, util.CAD)
+	rule := addTransferLimitRule(t, util.CAD, TransferLimitScopeAccount, TransferLimitPeriodHour, TransferLimitMetricCount, 3)
+
+	// 并发的交易依次检查限额，只有 3 笔交易可以成功
+	n := 6
+	errs := make(chan error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := testStore.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 1})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		violation := requireTransferLimitViolation(t, err, rule.ID)
+		require.Equal(t, int64(3), violation.Used)
+	}
+	require.Equal(t, 3, succeeded)
+}
+
+func TestTransferLimitExcludesReversed(t *testing.T) {
+	account1 := createBankerAccount(t, util.USD)
+	account2 := fundAccount(t, createAccountInCurrency(t, util.USD), 100)
+	amountRule := addTransferLimitRule(t, util.USD, TransferLimitScopeAccount, TransferLimitPeriodDay, TransferLimitMetricAmount, 100)
+	addTransferLimitRule(t, util.USD, TransferLimitScopeAccount, TransferLimitPeriodHour, TransferLimitMetricCount, 1)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 100})
+	require.NoError(t, err)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 1})
+	requireTransferLimitViolation(t, err, amountRule.ID)
+
+	// 全部退款之后，退款的金额和交易的笔数都不再计入限额
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: result.Transfer.ID,
+		Reason:     "refund",
+		ReversedBy: account1.Owner,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 100})
+	require.NoError(t, err)
+}
+
+func TestWithdrawTxTransferLimits(t *testing.T) {
+	account1 := createBankerAccount(t, util.USD)
+	account2 := createAccountInCurrency(t, util.USD)
+	rule := addTransferLimitRule(t, util.USD, TransferLimitScopeAccount, TransferLimitPeriodDay, TransferLimitMetricAmount, 100)
+
+	_, err := testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID:         account1.ID,
+		Amount:            60,
+		ExternalReference: util.RandomString(12),
+		CreatedBy:         account1.Owner,
+	})
+	require.NoError(t, err)
+
+	// 取款计入限额的已使用金额，之后的交易和取款都不能超出限额
+	_, err = testStore.TransferTx(context.Background(), TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 50})
+	violation := requireTransferLimitViolation(t, err, rule.ID)
+	require.Equal(t, int64(60), violation.Used)
+
+	_, err = testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID:         account1.ID,
+		Amount:            50,
+		ExternalReference: util.RandomString(12),
+		CreatedBy:         account1.Owner,
+	})
+	violation = requireTransferLimitViolation(t, err, rule.ID)
+	require.Equal(t, int64(60), violation.Used)
+	require.Equal(t, int64(50), violation.Requested)
+
+	_, err = testStore.WithdrawTx(context.Background(), WithdrawTxParams{
+		AccountID:         account1.ID,
+		Amount:            40,
+		ExternalReference: util.RandomString(12),
+		CreatedBy:         account1.Owner,
+	})
+	require.NoError(t, err)
+}
+
+func TestCloseAccountTxSkipsTransferLimits(t *testing.T) {
+	// 默认规则限制客户单笔交易不能超过 10,000.00 美元，关闭账户时转出的余额不受限制
+	account := fundAccount(t, createAccountInCurrency(t, util.USD), 2_000_000)
+	sweep, err := testQueries.CreateAccount(context.Background(), CreateAccountParams{
+		Owner:    createRandomUser(t).Username,
+		Currency: util.USD,
+	})
+	require.NoError(t, err)
+
+	result, err := testStore.CloseAccountTx(context.Background(), CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: &sweep.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountStatusClosed, result.Account.Status)
+	require.NotNil(t, result.SweepTransfer)
+	require.Equal(t, account.Balance, result.SweepTransfer.Transfer.Amount)
+}
+
+func TestEvaluateTransferLimitRule(t *testing.T) {
+	used := SumAccountTransfersRow{TotalAmount: 90, TransferCount: 2}
+
+	testCases := []struct {
+		name     string
+		rule     TransferLimitRule
+		amount   int64
+		violated bool
+	}{
+		{
+			name:   "AmountWithinLimit",
+			rule:   TransferLimitRule{Period: TransferLimitPeriodDay, Metric: TransferLimitMetricAmount, MaxValue: 100},
+			amount: 10,
+		},
+		{
+			name:     "AmountOverLimit",
+			rule:     TransferLimitRule{Period: TransferLimitPeriodDay, Metric: TransferLimitMetricAmount, MaxValue: 100},
+			amount:   11,
+			violated: true,
+		},
+		{
+			name:   "CountWithinLimit",
+			rule:   TransferLimitRule{Period: TransferLimitPeriodHour, Metric: TransferLimitMetricCount, MaxValue: 3},
+			amount: 1000,
+		},
+		{
+			name:     "CountOverLimit",
+			rule:     TransferLimitRule{Period: TransferLimitPeriodHour, Metric: TransferLimitMetricCount, MaxValue: 2},
+			amount:   1,
+			violated: true,
+		},
+		{
+			// 已经使用的金额接近 int64 的上限时不会溢出
+			name:     "NoOverflow",
+			rule:     TransferLimitRule{Period: TransferLimitPeriodDay, Metric: TransferLimitMetricAmount, MaxValue: 100},
+			amount:   1<<63 - 1,
+			violated: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			_, violated := evaluateTransferLimitRule(tc.rule, used, tc.amount)
+			require.Equal(t, tc.violated, violated)
+		})
+	}
+}
+
+func TestTransferLimitWindowStart(t *testing.T) {
+	now := time.Date(2024, 3, 15, 13, 45, 0, 0, time.UTC)
+
+	start, err := transferLimitWindowStart(TransferLimitPeriodHour, now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-time.Hour), start)
+
+	start, err = transferLimitWindowStart(TransferLimitPeriodDay, now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), start)
+
+	start, err = transferLimitWindowStart(TransferLimitPeriodMonth, now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), start)
+
+	_, err = transferLimitWindowStart(TransferLimitPeriodTransfer, now)
+	require.Error(t, err)
+}
@@ -0,0 +1,85 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: transfer_reversal.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createTransferReversal = `-- name: CreateTransferReversal :one
+INSERT INTO transfer_reversals (
+  transfer_id,
+  amount,
+  to_amount,
+  reason,
+  reversed_by
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id, transfer_id, amount, to_amount, reason, reversed_by, created_at
+`
+
+type CreateTransferReversalParams struct {
+	TransferID int64  `json:"transfer_id"`
+	Amount     int64  `json:"amount"`
+	ToAmount   int64  `json:"to_amount"`
+	Reason     string `json:"reason"`
+	ReversedBy string `json:"reversed_by"`
+}
+
+func (q *Queries) CreateTransferReversal(ctx context.Context, arg CreateTransferReversalParams) (TransferReversal, error) {
+	row := q.db.QueryRow(ctx, createTransferReversal,
+		arg.TransferID,
+		arg.Amount,
+		arg.ToAmount,
+		arg.Reason,
+		arg.ReversedBy,
+	)
+	var i TransferReversal
+	err := row.Scan(
+		&i.ID,
+		&i.TransferID,
+		&i.Amount,
+		&i.ToAmount,
+		&i.Reason,
+		&i.ReversedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listTransferReversals = `-- name: ListTransferReversals :many
+SELECT id, transfer_id, amount, to_amount, reason, reversed_by, created_at FROM transfer_reversals
+WHERE transfer_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListTransferReversals(ctx context.Context, transferID int64) ([]TransferReversal, error) {
+	rows, err := q.db.Query(ctx, listTransferReversals, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TransferReversal{}
+	for rows.Next() {
+		var i TransferReversal
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransferID,
+			&i.Amount,
+			&i.ToAmount,
+			&i.Reason,
+			&i.ReversedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
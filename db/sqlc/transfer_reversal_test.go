@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createReversibleTransfer 在两个新账户之间执行一笔交易，返回交易的结果，执行冲正的用户为转入账户的所有者
+func createReversibleTransfer(t *testing.T, amount, toAmount int64, exchangeRate string) TransferTxResult {
+	account1 := fundAccount(t, createRandomAccount(t), amount)
+	account2 := createRandomAccount(t)
+
+	result, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        amount,
+		ToAmount:      toAmount,
+		ExchangeRate:  exchangeRate,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCompleted, result.Transfer.Status)
+	require.Zero(t, result.Transfer.ReversedAmount)
+	return result
+}
+
+func TestReverseTransferTx(t *testing.T) {
+	transferred := createReversibleTransfer(t, 100, 0, "")
+
+	result, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferred.Transfer.ID,
+		Reason:     "mistaken transfer",
+		ReversedBy: transferred.ToAccount.Owner,
+	})
+	require.NoError(t, err)
+
+	// 没有指定金额时退还全部金额，原交易被标记为已冲正
+	require.Equal(t, TransferStatusReversed, result.Transfer.Status)
+	require.Equal(t, int64(100), result.Transfer.ReversedAmount)
+	require.Equal(t, int64(100), result.Reversal.Amount)
+	require.Equal(t, int64(100), result.Reversal.ToAmount)
+	require.Equal(t, "mistaken transfer", result.Reversal.Reason)
+
+	// 补偿条目同时关联原交易和冲正记录
+	require.Equal(t, transferred.ToAccount.ID, result.ToEntry.AccountID)
+	require.Equal(t, int64(-100), result.ToEntry.Amount)
+	require.Equal(t, transferred.FromAccount.ID, result.FromEntry.AccountID)
+	require.Equal(t, int64(100), result.FromEntry.Amount)
+	for _, entry := range []Entry{result.FromEntry, result.ToEntry} {
+		require.Equal(t, &transferred.Transfer.ID, entry.TransferID)
+		require.Equal(t, &result.Reversal.ID, entry.ReversalID)
+	}
+
+	// 两个账户的余额恢复到交易之前
+	require.Equal(t, transferred.FromAccount.Balance+100, result.FromAccount.Balance)
+	require.Equal(t, transferred.ToAccount.Balance-100, result.ToAccount.Balance)
+
+	// 已经全部退款的交易不能再次冲正
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferred.Transfer.ID,
+		ReversedBy: transferred.ToAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+}
+
+func TestReverseTransferTxPartial(t *testing.T) {
+	// 跨币种交易，按照原交易的汇率扣回转入账户的金额
+	transferred := createReversibleTransfer(t, 100, 92, "0.92")
+
+	result, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferred.Transfer.ID,
+		Amount:     30,
+		ReversedBy: transferred.ToAccount.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusPartiallyReversed, result.Transfer.Status)
+	require.Equal(t, int64(30), result.Transfer.ReversedAmount)
+	require.Equal(t, int64(27), result.Reversal.ToAmount)
+
+	// 退款金额超过剩余可以退款的金额
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferred.Transfer.ID,
+		Amount:     71,
+		ReversedBy: transferred.ToAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrReversalExceedsTransfer)
+
+	// 退还剩余的全部金额，多次扣回的金额之和等于原交易的转入金额
+	result, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferred.Transfer.ID,
+		ReversedBy: transferred.ToAccount.Owner,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusReversed, result.Transfer.Status)
+	require.Equal(t, int64(70), result.Reversal.Amount)
+	require.Equal(t, int64(65), result.Reversal.ToAmount)
+	require.Equal(t, transferred.ToAccount.Balance-92, result.ToAccount.Balance)
+
+	reversals, err := testQueries.ListTransferReversals(context.Background(), transferred.Transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, reversals, 2)
+}
+
+func TestReverseTransferTxInsufficientFunds(t *testing.T) {
+	transferred := createReversibleTransfer(t, 100, 0, "")
+
+	// 转入账户已经转出了收到的款项，冲正会使余额低于允许的透支额度
+	_, err := testStore.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID: transferred.ToAccount.ID,
+		ToAccountID:   transferred.FromAccount.ID,
+		Amount:        transferred.ToAccount.Balance,
+	})
+	require.NoError(t, err)
+
+	_, err = testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transferred.Transfer.ID,
+		ReversedBy: transferred.ToAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// 冲正失败时整个事务被回滚
+	transfer, err := testQueries.GetTransfer(context.Background(), transferred.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCompleted, transfer.Status)
+	require.Zero(t, transfer.ReversedAmount)
+}
+
+func TestReverseTransferTxConcurrent(t *testing.T) {
+	transferred := createReversibleTransfer(t, 100, 0, "")
+
+	// 同时多次冲正同一笔交易，只有一次可以成功
+	n := 5
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := testStore.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+				TransferID: transferred.Transfer.ID,
+				ReversedBy: transferred.ToAccount.Owner,
+			})
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+	}
+	require.Equal(t, 1, succeeded)
+
+	account, err := testQueries.GetAccountForUpdate(context.Background(), transferred.FromAccount.ID)
+	require.NoError(t, err)
+	require.Equal(t, transferred.FromAccount.Balance+100, account.Balance)
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// 定义交易的所有状态
+const (
+	TransferStatusCompleted         = "completed"
+	TransferStatusPartiallyReversed = "partially_reversed"
+	TransferStatusReversed          = "reversed"
+)
+
+// ReverseTransferTxParams 包含冲正一笔交易所需要的输入参数
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// 退还给转出账户的金额，使用转出账户的货币，为 0 时退还剩余的全部金额
+	Amount int64  `json:"amount"`
+	Reason string `json:"reason"`
+	// 执行冲正操作的用户
+	ReversedBy string `json:"reversed_by"`
+}
+
+// ReverseTransferTxResult 包含冲正事务的结果
+// FromAccount 和 ToAccount 分别为原交易的转出账户和转入账户
+type ReverseTransferTxResult struct {
+	Transfer    Transfer         `json:"transfer"`
+	Reversal    TransferReversal `json:"reversal"`
+	FromAccount Account          `json:"from_account"`
+	ToAccount   Account          `json:"to_account"`
+	FromEntry   Entry            `json:"from_entry"`
+	ToEntry     Entry            `json:"to_entry"`
+}
+
+// ReverseTransferTx 在一个事务中冲正一笔交易，从原交易的转入账户扣回金额并退还给转出账户
+// 补偿条目同时关联原交易和冲正记录，原交易的状态更新为部分冲正或者已冲正，多次部分退款的总金额不超过原交易的金额
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// 锁定原交易，同一笔交易的多次冲正依次执行
+		transfer, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		remaining := transfer.Amount - transfer.ReversedAmount
+		if remaining == 0 {
+			return ErrTransferAlreadyReversed
+		}
+		amount := arg.Amount
+		if amount == 0 {
+			amount = remaining
+		}
+		if amount > remaining {
+			return fmt.Errorf("%w: at most %d can be refunded", ErrReversalExceedsTransfer, remaining)
+		}
+
+		// 按照原交易的汇率计算需要从转入账户扣回的金额
+		// 使用累计的退款金额计算，多次部分退款的扣回金额之和与原交易的转入金额完全一致
+		toAmount := proportion(transfer.ToAmount, transfer.ReversedAmount+amount, transfer.Amount) -
+			proportion(transfer.ToAmount, transfer.ReversedAmount, transfer.Amount)
+
+		result.Reversal, err = q.CreateTransferReversal(ctx, CreateTransferReversalParams{
+			TransferID: transfer.ID,
+			Amount:     amount,
+			ToAmount:   toAmount,
+			Reason:     arg.Reason,
+			ReversedBy: arg.ReversedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		// 冻结的账户仍然可以冲正，以便追回被冻结账户收到的款项，已关闭的账户不能再产生条目
+		// 转入账户的余额不能低于允许的透支额度
+		lines, err := exchangeLines(ctx, q,
+			JournalLine{AccountID: transfer.ToAccountID, Amount: -toAmount, TransferID: &transfer.ID, ReversalID: &result.Reversal.ID},
+			JournalLine{AccountID: transfer.FromAccountID, Amount: amount, TransferID: &transfer.ID, ReversalID: &result.Reversal.ID},
+		)
+		if err != nil {
+			return err
+		}
+		journal, err := postJournal(ctx, q, PostJournalParams{
+			Kind:        JournalKindReversal,
+			Description: fmt.Sprintf("reversal %d of transfer %d", result.Reversal.ID, transfer.ID),
+			Lines:       lines,
+			AllowFrozen: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		last := len(lines) - 1
+		result.ToEntry, result.ToAccount = journal.Entries[0], journal.Accounts[0]
+		result.FromEntry, result.FromAccount = journal.Entries[last], journal.Accounts[last]
+
+		result.Transfer, err = q.AddTransferReversedAmount(ctx, AddTransferReversedAmountParams{
+			ID:     transfer.ID,
+			Amount: amount,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// proportion 计算 total * part / whole ，向下取整，使用 big.Int 避免乘法溢出
+func proportion(total, part, whole int64) int64 {
+	product := new(big.Int).Mul(big.NewInt(total), big.NewInt(part))
+	return product.Quo(product, big.NewInt(whole)).Int64()
+}
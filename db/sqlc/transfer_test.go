@@ -3,17 +3,22 @@ package db
 import (
 	"SimpleBank/util"
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
 func createRandomTransfer(t *testing.T, from_account, to_account Account) Transfer {
 	// 指定将要创建的条目中的项目的值
+	amount := util.RandomMoney()
 	arg := CreateTransferParams{
 		FromAccountID: from_account.ID,
 		ToAccountID:   to_account.ID,
-		Amount:        util.RandomMoney(),
+		Amount:        amount,
+		ToAmount:      amount,
+		ExchangeRate:  "1",
 	}
 
 	// 根据配置创建记录
@@ -29,6 +34,8 @@ func createRandomTransfer(t *testing.T, from_account, to_account Account) Transf
 	require.Equal(t, transfer.ToAccountID, to_account.ID)
 	// 调用 testify 包中的子包 require 的 Equal() ，判断返回的 transfer 的 Amount 是否与 arg 相等
 	require.Equal(t, transfer.Amount, arg.Amount)
+	require.Equal(t, transfer.ToAmount, arg.ToAmount)
+	require.Equal(t, transfer.ExchangeRate, arg.ExchangeRate)
 
 	// 调用 testify 包中的子包 require 的 NotZero() ，判断得到的 transfer 中的 ID、CreatedAt 是否为非空
 	require.NotZero(t, transfer.ID)
@@ -62,19 +69,19 @@ func TestGetTransfer(t *testing.T) {
 }
 
 func TestListTransfers(t *testing.T) {
-	// 创建两个账号分别充当转账账号和进账账号，通过这两个账号创建多条交易记录（这里是 10 条）
-	from_account := createRandomAccount(t)
-	to_account := createRandomAccount(t)
-	for i := 0; i < 10; i++ {
-		createRandomTransfer(t, from_account, to_account)
+	// 创建两个账号，account1 向 account2 转账 5 次，account2 向 account1 转账 5 次
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	for i := 0; i < 5; i++ {
+		createRandomTransfer(t, account1, account2)
+		createRandomTransfer(t, account2, account1)
 	}
 
-	// 指定查询参数
+	// 指定查询参数，只使用一个账户过滤
 	arg := ListTransfersParams{
-		FromAccountID: from_account.ID,
-		ToAccountID:   to_account.ID,
-		Limit:         5,
-		Offset:        5,
+		AccountID: account1.ID,
+		Limit:     5,
+		Offset:    5,
 	}
 
 	// 根据指定的查询参数进行查询
@@ -88,8 +95,98 @@ func TestListTransfers(t *testing.T) {
 	for _, transfer := range transfers {
 		// 调用 testify 包中的子包 require 的 NotEmpty() ，判断每条记录是否不为空
 		require.NotEmpty(t, transfer)
-		// 调用 testify 包中的子包 require 的 Equal() ，判断 transfer 的 FromAccountID 是否与 arg 相等
-		require.Equal(t, transfer.FromAccountID, arg.FromAccountID)
-		require.Equal(t, transfer.ToAccountID, arg.ToAccountID)
+		// 每条记录的转出账户或者转入账户必须是 account1
+		require.True(t, transfer.FromAccountID == account1.ID || transfer.ToAccountID == account1.ID)
+	}
+}
+
+func TestListTransfersCursor(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	var transfers []Transfer
+	for i := 0; i < 6; i++ {
+		transfers = append(transfers, createRandomTransfer(t, account1, account2))
+	}
+
+	// 从第 3 条交易的 id 之后开始查询，只返回之后的交易
+	arg := ListTransfersParams{
+		AccountID: account1.ID,
+		AfterID:   transfers[2].ID,
+		Limit:     10,
+	}
+	result, err := testQueries.ListTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, transfers[3:], result)
+}
+
+func TestListTransfersFilters(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+	account3 := createRandomAccount(t)
+
+	outgoing := createRandomTransfer(t, account1, account2)
+	incoming := createRandomTransfer(t, account2, account1)
+	other := createRandomTransfer(t, account3, account1)
+	// 晚于所有交易的开始时间
+	startTime := other.CreatedAt.Add(time.Second)
+
+	testCases := []struct {
+		name     string
+		arg      ListTransfersParams
+		expected []Transfer
+	}{
+		{
+			name: "Outgoing",
+			arg: ListTransfersParams{
+				AccountID: account1.ID,
+				Direction: sql.NullString{String: "outgoing", Valid: true},
+			},
+			expected: []Transfer{outgoing},
+		},
+		{
+			name: "Incoming",
+			arg: ListTransfersParams{
+				AccountID: account1.ID,
+				Direction: sql.NullString{String: "incoming", Valid: true},
+			},
+			expected: []Transfer{incoming, other},
+		},
+		{
+			name: "Counterparty",
+			arg: ListTransfersParams{
+				AccountID:             account1.ID,
+				CounterpartyAccountID: &account3.ID,
+			},
+			expected: []Transfer{other},
+		},
+		{
+			name: "AmountRange",
+			arg: ListTransfersParams{
+				AccountID: account1.ID,
+				MinAmount: &outgoing.Amount,
+				MaxAmount: &outgoing.Amount,
+				Direction: sql.NullString{String: "outgoing", Valid: true},
+			},
+			expected: []Transfer{outgoing},
+		},
+		{
+			name: "TimeWindow",
+			arg: ListTransfersParams{
+				AccountID: account1.ID,
+				StartTime: &startTime,
+			},
+			expected: []Transfer{},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tc.arg.Limit = 10
+			transfers, err := testQueries.ListTransfers(context.Background(), tc.arg)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, transfers)
+		})
 	}
 }
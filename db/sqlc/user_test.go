@@ -3,6 +3,7 @@ package db
 import (
 	"SimpleBank/util"
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -33,6 +34,8 @@ func createRandomUser(t *testing.T) User {
 	require.Equal(t, arg.HashedPassword, user.HashedPassword)
 	require.Equal(t, arg.FullName, user.FullName)
 	require.Equal(t, arg.Email, user.Email)
+	// 新创建的用户默认为客户
+	require.Equal(t, util.DepositorRole, user.Role)
 
 	// 调用 testify 包中的子包 require 的 NotZero() ，判断 PasswordChangedAt、CreatedAt 是否由 Postgres 自动生成
 	require.True(t, user.PasswordChangedAt.IsZero())
@@ -66,3 +69,107 @@ func TestGetUser(t *testing.T) {
 	require.WithinDuration(t, user1.CreatedAt, user2.CreatedAt, time.Second)
 
 }
+
+func TestUpdateUserOnlyFullName(t *testing.T) {
+	oldUser := createRandomUser(t)
+
+	newFullName := util.RandomOwner()
+	updatedUser, err := testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		Username: oldUser.Username,
+		FullName: sql.NullString{String: newFullName, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// 只有姓名被修改，其他字段保持不变
+	require.Equal(t, newFullName, updatedUser.FullName)
+	require.Equal(t, oldUser.Email, updatedUser.Email)
+	require.Equal(t, oldUser.HashedPassword, updatedUser.HashedPassword)
+	require.Equal(t, oldUser.IsEmailVerified, updatedUser.IsEmailVerified)
+	require.WithinDuration(t, oldUser.PasswordChangedAt, updatedUser.PasswordChangedAt, time.Second)
+}
+
+func TestUpdateUserAllFields(t *testing.T) {
+	oldUser := createRandomUser(t)
+
+	newHashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	passwordChangedAt := time.Now()
+	arg := UpdateUserParams{
+		Username:          oldUser.Username,
+		HashedPassword:    sql.NullString{String: newHashedPassword, Valid: true},
+		PasswordChangedAt: &passwordChangedAt,
+		FullName:          sql.NullString{String: util.RandomOwner(), Valid: true},
+		Email:             sql.NullString{String: util.RandomEmail(), Valid: true},
+		IsEmailVerified:   sql.NullBool{Bool: false, Valid: true},
+	}
+
+	updatedUser, err := testQueries.UpdateUser(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.HashedPassword.String, updatedUser.HashedPassword)
+	require.Equal(t, arg.FullName.String, updatedUser.FullName)
+	require.Equal(t, arg.Email.String, updatedUser.Email)
+	require.False(t, updatedUser.IsEmailVerified)
+	require.WithinDuration(t, passwordChangedAt, updatedUser.PasswordChangedAt, time.Second)
+
+	changedAt, err := testQueries.GetUserPasswordChangedAt(context.Background(), oldUser.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, passwordChangedAt, changedAt, time.Second)
+}
+
+func TestUpdateUserTxBlocksSessions(t *testing.T) {
+	user := createRandomUser(t)
+	session1 := createRandomSession(t, user)
+	session2 := createRandomSession(t, user)
+
+	// 只修改姓名时不会封禁会话
+	result, err := testStore.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username: user.Username,
+			FullName: sql.NullString{String: util.RandomOwner(), Valid: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.BlockedSessions)
+
+	// 修改密码之后该用户所有的会话都被封禁
+	newHashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+	passwordChangedAt := time.Now()
+
+	result, err = testStore.UpdateUserTx(context.Background(), UpdateUserTxParams{
+		UpdateUserParams: UpdateUserParams{
+			Username:          user.Username,
+			HashedPassword:    sql.NullString{String: newHashedPassword, Valid: true},
+			PasswordChangedAt: &passwordChangedAt,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.BlockedSessions)
+	require.Equal(t, newHashedPassword, result.User.HashedPassword)
+
+	for _, session := range []Session{session1, session2} {
+		blocked, err := testQueries.GetSession(context.Background(), session.ID)
+		require.NoError(t, err)
+		require.True(t, blocked.IsBlocked)
+	}
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	oldUser := createRandomUser(t)
+
+	updatedUser, err := testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		Username: oldUser.Username,
+		Role:     sql.NullString{String: util.BankerRole, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, util.BankerRole, updatedUser.Role)
+	require.Equal(t, oldUser.FullName, updatedUser.FullName)
+
+	// 不支持的角色违反数据库的约束
+	_, err = testQueries.UpdateUser(context.Background(), UpdateUserParams{
+		Username: oldUser.Username,
+		Role:     sql.NullString{String: "admin", Valid: true},
+	})
+	require.Error(t, err)
+}
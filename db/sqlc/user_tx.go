@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// CreateUserTxParams 包含创建用户所需要的输入参数
+type CreateUserTxParams struct {
+	CreateUserParams
+	// AfterCreate 在创建用户的同一个事务中执行，q 使用该事务进行查询，返回错误时用户也不会被创建
+	// 用于将发送邮件等异步任务放入队列，保证任务不会丢失，也不会为回滚了的用户执行
+	AfterCreate func(q Querier, user User) error
+}
+
+// CreateUserTxResult 包含创建用户事务的结果
+type CreateUserTxResult struct {
+	User User
+}
+
+// CreateUserTx 在一个事务中创建用户并执行 AfterCreate
+func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (CreateUserTxResult, error) {
+	var result CreateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.User, err = q.CreateUser(ctx, arg.CreateUserParams)
+		if err != nil {
+			return err
+		}
+
+		err = appendAuditLog(ctx, q, AuditActionUserCreate, fmt.Sprintf("user:%s", result.User.Username), nil, newAuditUser(result.User))
+		if err != nil {
+			return err
+		}
+
+		if arg.AfterCreate == nil {
+			return nil
+		}
+		return arg.AfterCreate(q, result.User)
+	})
+
+	return result, err
+}
+
+// UpdateUserTxParams 包含修改用户所需要的输入参数
+type UpdateUserTxParams struct {
+	UpdateUserParams
+	// AfterUpdate 在修改用户的同一个事务中执行，q 使用该事务进行查询，返回错误时修改也会被回滚
+	AfterUpdate func(q Querier, user User) error
+}
+
+// UpdateUserTxResult 包含修改用户事务的结果
+type UpdateUserTxResult struct {
+	User User
+	// 因为修改密码而被封禁的会话数量
+	BlockedSessions int64
+}
+
+// UpdateUserTx 在一个事务中修改用户，修改了密码时同时封禁该用户所有的会话，之前签发的刷新令牌全部失效
+func (store *SQLStore) UpdateUserTx(ctx context.Context, arg UpdateUserTxParams) (UpdateUserTxResult, error) {
+	var result UpdateUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// 锁定用户，审计日志中记录修改之前的用户信息
+		before, err := q.GetUserForUpdate(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UpdateUser(ctx, arg.UpdateUserParams)
+		if err != nil {
+			return err
+		}
+
+		err = appendAuditLog(ctx, q, AuditActionUserUpdate, fmt.Sprintf("user:%s", result.User.Username), newAuditUser(before), newAuditUser(result.User))
+		if err != nil {
+			return err
+		}
+
+		if arg.HashedPassword.Valid {
+			result.BlockedSessions, err = q.BlockUserSessions(ctx, result.User.Username)
+			if err != nil {
+				return err
+			}
+		}
+
+		if arg.AfterUpdate == nil {
+			return nil
+		}
+		return arg.AfterUpdate(q, result.User)
+	})
+
+	return result, err
+}
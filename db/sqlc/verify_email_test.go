@@ -0,0 +1,77 @@
+package db
+
+import (
+	"SimpleBank/util"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomVerifyEmail(t *testing.T, user User) VerifyEmail {
+	arg := CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: util.RandomString(32),
+	}
+
+	verifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, verifyEmail.ID)
+	require.Equal(t, arg.Username, verifyEmail.Username)
+	require.Equal(t, arg.Email, verifyEmail.Email)
+	require.Equal(t, arg.SecretCode, verifyEmail.SecretCode)
+	require.False(t, verifyEmail.IsUsed)
+	require.True(t, verifyEmail.ExpiredAt.After(verifyEmail.CreatedAt))
+
+	return verifyEmail
+}
+
+func TestVerifyEmailTx(t *testing.T) {
+	user := createRandomUser(t)
+	require.False(t, user.IsEmailVerified)
+	verifyEmail := createRandomVerifyEmail(t, user)
+
+	// 验证码不正确时不会验证成功
+	_, err := testStore.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: util.RandomString(32),
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	arg := VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	}
+	result, err := testStore.VerifyEmailTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, result.VerifyEmail.IsUsed)
+	require.Equal(t, user.Username, result.User.Username)
+	require.True(t, result.User.IsEmailVerified)
+
+	// 验证码只能使用一次
+	_, err = testStore.VerifyEmailTx(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestVerifyEmailTxEmailChanged(t *testing.T) {
+	user := createRandomUser(t)
+	verifyEmail, err := testQueries.CreateVerifyEmail(context.Background(), CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      util.RandomEmail(),
+		SecretCode: util.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	// 验证码发送到的邮箱不是用户当前的邮箱，验证失败并且验证码不会被标记为已使用
+	_, err = testStore.VerifyEmailTx(context.Background(), VerifyEmailTxParams{
+		EmailID:    verifyEmail.ID,
+		SecretCode: verifyEmail.SecretCode,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	got, err := testQueries.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.False(t, got.IsEmailVerified)
+}
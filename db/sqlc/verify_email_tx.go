@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// VerifyEmailTxParams 包含验证用户邮箱所需要的输入参数
+type VerifyEmailTxParams struct {
+	EmailID    int64
+	SecretCode string
+}
+
+// VerifyEmailTxResult 包含验证邮箱事务的结果
+type VerifyEmailTxResult struct {
+	User        User
+	VerifyEmail VerifyEmail
+}
+
+// VerifyEmailTx 在一个事务中将验证码标记为已使用，并将对应用户的邮箱标记为已验证
+// 验证码不存在、不正确、已经使用或者已经过期，以及用户的邮箱已经修改时返回 pgx.ErrNoRows
+func (store *SQLStore) VerifyEmailTx(ctx context.Context, arg VerifyEmailTxParams) (VerifyEmailTxResult, error) {
+	var result VerifyEmailTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.VerifyEmail, err = q.UseVerifyEmail(ctx, UseVerifyEmailParams{
+			ID:         arg.EmailID,
+			SecretCode: arg.SecretCode,
+		})
+		if err != nil {
+			return err
+		}
+
+		before, err := q.GetUserForUpdate(ctx, result.VerifyEmail.Username)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.VerifyUserEmail(ctx, VerifyUserEmailParams{
+			Username: result.VerifyEmail.Username,
+			Email:    result.VerifyEmail.Email,
+		})
+		if err != nil {
+			return err
+		}
+
+		return appendAuditLog(ctx, q, AuditActionUserUpdate, fmt.Sprintf("user:%s", result.User.Username), newAuditUser(before), newAuditUser(result.User))
+	})
+
+	return result, err
+}
@@ -0,0 +1,66 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: withdrawal.sql
+
+package db
+
+import (
+	"context"
+)
+
+const createWithdrawal = `-- name: CreateWithdrawal :one
+INSERT INTO withdrawals (
+  account_id,
+  amount,
+  external_reference,
+  created_by
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING id, account_id, amount, external_reference, created_by, created_at
+`
+
+type CreateWithdrawalParams struct {
+	AccountID         int64  `json:"account_id"`
+	Amount            int64  `json:"amount"`
+	ExternalReference string `json:"external_reference"`
+	CreatedBy         string `json:"created_by"`
+}
+
+func (q *Queries) CreateWithdrawal(ctx context.Context, arg CreateWithdrawalParams) (Withdrawal, error) {
+	row := q.db.QueryRow(ctx, createWithdrawal,
+		arg.AccountID,
+		arg.Amount,
+		arg.ExternalReference,
+		arg.CreatedBy,
+	)
+	var i Withdrawal
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.ExternalReference,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWithdrawal = `-- name: GetWithdrawal :one
+SELECT id, account_id, amount, external_reference, created_by, created_at FROM withdrawals
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetWithdrawal(ctx context.Context, id int64) (Withdrawal, error) {
+	row := q.db.QueryRow(ctx, getWithdrawal, id)
+	var i Withdrawal
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.ExternalReference,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,21 @@
+package doc
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// swagger 目录中保存由 proto 文件生成的 OpenAPI 文档以及浏览该文档的页面，编译时嵌入到二进制文件中
+//
+//go:embed swagger
+var swaggerFS embed.FS
+
+// SwaggerFS 返回以 swagger 目录为根目录的文件系统
+func SwaggerFS() fs.FS {
+	sub, err := fs.Sub(swaggerFS, "swagger")
+	if err != nil {
+		// swagger 目录在编译时已经嵌入，不会出现错误
+		panic(err)
+	}
+	return sub
+}
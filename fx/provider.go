@@ -0,0 +1,76 @@
+package fx
+
+import (
+	"SimpleBank/util"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ErrUnsupportedCurrencyPair 表示没有指定货币对的汇率
+var ErrUnsupportedCurrencyPair = errors.New("unsupported currency pair")
+
+// ExchangeRateProvider 是获取汇率报价的接口，便于替换为不同的汇率来源
+type ExchangeRateProvider interface {
+	// GetQuote 获取将 from 货币兑换为 to 货币的报价
+	GetQuote(ctx context.Context, from string, to string) (Quote, error)
+}
+
+// Quote 是一次锁定的汇率报价，交易使用报价中的汇率进行换算
+type Quote struct {
+	ID   string `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	// 十进制字符串形式的汇率，表示 1 单位 From 货币可以兑换多少单位 To 货币
+	Rate      string    `json:"rate"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Expired 检查报价是否已经过期
+func (quote Quote) Expired() bool {
+	return time.Now().After(quote.ExpiresAt)
+}
+
+// Convert 使用报价中的汇率将 From 货币的金额换算为 To 货币的金额，结果向下取整
+// 金额都以最小货币单位表示，两种货币的小数位数不同时按照 10^(To 的小数位数 - From 的小数位数) 换算，
+// 例如汇率为 150 时，1.00 美元（100 美分）换算为 150 日元
+func (quote Quote) Convert(amount int64) (int64, error) {
+	rate, ok := new(big.Rat).SetString(quote.Rate)
+	if !ok || rate.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid exchange rate %q", quote.Rate)
+	}
+
+	from, err := util.LookupCurrency(quote.From)
+	if err != nil {
+		return 0, err
+	}
+	to, err := util.LookupCurrency(quote.To)
+	if err != nil {
+		return 0, err
+	}
+
+	converted := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), rate)
+	// 将最小货币单位的比例换算到 To 货币
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(to.Exponent-from.Exponent))), nil)
+	if to.Exponent >= from.Exponent {
+		converted.Mul(converted, new(big.Rat).SetInt(scale))
+	} else {
+		converted.Quo(converted, new(big.Rat).SetInt(scale))
+	}
+	// 使用整数除法向下取整，避免银行多付出金额
+	result := new(big.Int).Quo(converted.Num(), converted.Denom())
+	if !result.IsInt64() {
+		return 0, fmt.Errorf("converted amount overflows: %s", result.String())
+	}
+	return result.Int64(), nil
+}
+
+// abs 返回 n 的绝对值
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,70 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StaticProvider 是使用固定汇率表实现的 ExchangeRateProvider
+type StaticProvider struct {
+	// 以 "FROM/TO" 为键的汇率表，例如 "USD/EUR": "0.92"
+	rates map[string]string
+	// 每个报价的有效时长
+	quoteTTL time.Duration
+}
+
+// NewStaticProvider 使用指定的汇率表创建一个 StaticProvider
+func NewStaticProvider(rates map[string]string, quoteTTL time.Duration) (*StaticProvider, error) {
+	for pair, rate := range rates {
+		if r, ok := new(big.Rat).SetString(rate); !ok || r.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate %q for %s", rate, pair)
+		}
+	}
+
+	return &StaticProvider{
+		rates:    rates,
+		quoteTTL: quoteTTL,
+	}, nil
+}
+
+// LoadStaticProvider 从 JSON 文件中读取汇率表并创建一个 StaticProvider
+func LoadStaticProvider(path string, quoteTTL time.Duration) (*StaticProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read exchange rates file: %w", err)
+	}
+
+	var rates map[string]string
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("cannot parse exchange rates file: %w", err)
+	}
+
+	return NewStaticProvider(rates, quoteTTL)
+}
+
+// GetQuote 获取将 from 货币兑换为 to 货币的报价，相同货币之间的汇率始终为 1
+func (provider *StaticProvider) GetQuote(ctx context.Context, from string, to string) (Quote, error) {
+	rate := "1"
+	if from != to {
+		var ok bool
+		rate, ok = provider.rates[from+"/"+to]
+		if !ok {
+			return Quote{}, fmt.Errorf("%w: %s/%s", ErrUnsupportedCurrencyPair, from, to)
+		}
+	}
+
+	quote := Quote{
+		ID:        uuid.NewString(),
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		ExpiresAt: time.Now().Add(provider.quoteTTL),
+	}
+	return quote, nil
+}
@@ -0,0 +1,102 @@
+package fx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticProvider(t *testing.T) {
+	provider, err := NewStaticProvider(map[string]string{"USD/EUR": "0.92"}, time.Minute)
+	require.NoError(t, err)
+
+	// 获取 USD 兑换为 EUR 的报价
+	quote, err := provider.GetQuote(context.Background(), "USD", "EUR")
+	require.NoError(t, err)
+	require.NotEmpty(t, quote.ID)
+	require.Equal(t, "0.92", quote.Rate)
+	require.False(t, quote.Expired())
+	require.WithinDuration(t, time.Now().Add(time.Minute), quote.ExpiresAt, time.Second)
+
+	// 每次报价的 ID 应该不同
+	quote2, err := provider.GetQuote(context.Background(), "USD", "EUR")
+	require.NoError(t, err)
+	require.NotEqual(t, quote.ID, quote2.ID)
+
+	// 相同货币之间的汇率为 1
+	quote, err = provider.GetQuote(context.Background(), "CAD", "CAD")
+	require.NoError(t, err)
+	require.Equal(t, "1", quote.Rate)
+
+	// 没有配置的货币对应该返回 ErrUnsupportedCurrencyPair
+	_, err = provider.GetQuote(context.Background(), "EUR", "USD")
+	require.ErrorIs(t, err, ErrUnsupportedCurrencyPair)
+}
+
+func TestInvalidStaticProviderRate(t *testing.T) {
+	_, err := NewStaticProvider(map[string]string{"USD/EUR": "abc"}, time.Minute)
+	require.Error(t, err)
+
+	_, err = NewStaticProvider(map[string]string{"USD/EUR": "-1"}, time.Minute)
+	require.Error(t, err)
+}
+
+func TestLoadStaticProvider(t *testing.T) {
+	// 将汇率表写入临时文件
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`{"EUR/CAD": "1.47"}`), 0o600)
+	require.NoError(t, err)
+
+	provider, err := LoadStaticProvider(path, time.Minute)
+	require.NoError(t, err)
+
+	quote, err := provider.GetQuote(context.Background(), "EUR", "CAD")
+	require.NoError(t, err)
+	require.Equal(t, "1.47", quote.Rate)
+}
+
+func TestQuoteConvert(t *testing.T) {
+	testCases := []struct {
+		name   string
+		from   string
+		to     string
+		rate   string
+		amount int64
+		want   int64
+	}{
+		{name: "SameCurrency", from: "USD", to: "USD", rate: "1", amount: 1000, want: 1000},
+		{name: "Exact", from: "USD", to: "EUR", rate: "0.92", amount: 1000, want: 920},
+		// 换算结果向下取整
+		{name: "RoundDown", from: "USD", to: "CAD", rate: "1.36", amount: 7, want: 9},
+		{name: "SmallAmount", from: "USD", to: "EUR", rate: "0.5", amount: 1, want: 0},
+		// 1.00 美元（100 美分）换算为 150 日元
+		{name: "FewerDecimalPlaces", from: "USD", to: "JPY", rate: "150", amount: 100, want: 150},
+		// 150 日元换算为 1.00 美元（100 美分）
+		{name: "MoreDecimalPlaces", from: "JPY", to: "USD", rate: "0.0066667", amount: 150, want: 100},
+		// 1.00 美元（100 美分）换算为 0.307 科威特第纳尔（307 费尔）
+		{name: "ThreeDecimalPlaces", from: "USD", to: "KWD", rate: "0.3075", amount: 100, want: 307},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			quote := Quote{From: tc.from, To: tc.to, Rate: tc.rate}
+			got, err := quote.Convert(tc.amount)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	// 无效的汇率应该返回错误
+	_, err := Quote{From: "USD", To: "EUR", Rate: "0"}.Convert(100)
+	require.Error(t, err)
+
+	// 未知的货币应该返回错误
+	_, err = Quote{From: "USD", To: "XYZ", Rate: "1"}.Convert(100)
+	require.Error(t, err)
+}
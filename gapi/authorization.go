@@ -0,0 +1,111 @@
+package gapi
+
+import (
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// 与 HTTP 的认证请求头保持一致，gRPC 的元数据键都是小写的
+const (
+	authorizationHeader = "authorization"
+	authorizationBearer = "bearer"
+)
+
+// 方法允许访问的角色
+var (
+	// 客户和银行职员都可以访问
+	anyRole = []string{util.DepositorRole, util.BankerRole}
+	// 只有客户可以访问，用于开户、转账等操作客户自己资金的方法
+	depositorOnly = []string{util.DepositorRole}
+	// 只有银行职员可以访问，用于跨客户查询等运维操作
+	bankerOnly = []string{util.BankerRole}
+)
+
+// authorizeUser 从请求的元数据中读取并验证访问令牌，令牌中的角色必须在 accessibleRoles 中，验证成功时返回令牌的 payload
+func (server *Server) authorizeUser(ctx context.Context, accessibleRoles []string) (*token.Payload, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization header is not provided")
+	}
+
+	// 认证信息的格式为 "bearer <access_token>"
+	fields := strings.Fields(values[0])
+	if len(fields) != 2 {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid authorization header format")
+	}
+	if authType := strings.ToLower(fields[0]); authType != authorizationBearer {
+		return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization type %s", authType)
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(fields[1])
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid access token: %s", err)
+	}
+	// 刷新令牌不能作为访问令牌使用，否则会话被封禁之后刷新令牌仍然可以访问 API
+	if payload.TokenType != token.TokenTypeAccess {
+		return nil, status.Errorf(codes.Unauthenticated, "token is not an access token")
+	}
+
+	// 与 HTTP 的认证中间件保持一致，用户修改密码之前签发的访问令牌不再有效
+	passwordChangedAt, err := server.store.GetUserPasswordChangedAt(ctx, payload.Username)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.Unauthenticated, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find user: %s", err)
+	}
+	if payload.IssuedAt.Before(passwordChangedAt) {
+		return nil, status.Errorf(codes.Unauthenticated, "token was issued before the password was changed")
+	}
+
+	if !hasRole(accessibleRoles, payload.Role) {
+		return nil, status.Errorf(codes.PermissionDenied, "role %q is not allowed to call this method", payload.Role)
+	}
+	return payload, nil
+}
+
+// hasRole 判断 role 是否在 roles 中
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// idempotencyKeyHeader 是携带幂等键的元数据键
+const idempotencyKeyHeader = "idempotency-key"
+
+// maxIdempotencyKeyLength 是幂等键的最大长度
+const maxIdempotencyKeyLength = 255
+
+// getIdempotencyKey 从请求的元数据中读取可选的幂等键，没有提供时返回空字符串
+func getIdempotencyKey(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	values := md.Get(idempotencyKeyHeader)
+	if len(values) == 0 {
+		return "", nil
+	}
+	if len(values[0]) > maxIdempotencyKeyLength {
+		return "", fmt.Errorf("must be at most %d characters", maxIdempotencyKeyLength)
+	}
+	return values[0], nil
+}
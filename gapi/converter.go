@@ -0,0 +1,221 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// convertMoney 将以最小货币单位表示的金额转换为十进制字符串表示的 pb.Money
+func convertMoney(amount int64, currency string) *pb.Money {
+	return &pb.Money{
+		Amount:   util.NewMoney(amount, currency).Decimal(),
+		Currency: currency,
+	}
+}
+
+// convertOptionalTime 将可能为空的时间转换为 timestamppb.Timestamp ，为空时返回 nil
+func convertOptionalTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// convertUser 将 db.User 转换为不包含敏感字段的 pb.User
+func convertUser(user db.User) *pb.User {
+	return &pb.User{
+		Username:          user.Username,
+		FullName:          user.FullName,
+		Email:             user.Email,
+		IsEmailVerified:   user.IsEmailVerified,
+		Role:              user.Role,
+		PasswordChangedAt: timestamppb.New(user.PasswordChangedAt),
+		CreatedAt:         timestamppb.New(user.CreatedAt),
+	}
+}
+
+// convertAccount 将 db.Account 转换为 pb.Account
+func convertAccount(account db.Account) *pb.Account {
+	return &pb.Account{
+		Id:             account.ID,
+		Owner:          account.Owner,
+		Balance:        convertMoney(account.Balance, account.Currency),
+		Currency:       account.Currency,
+		CreatedAt:      timestamppb.New(account.CreatedAt),
+		OverdraftLimit: convertMoney(account.OverdraftLimit, account.Currency),
+		Status:         account.Status,
+	}
+}
+
+// convertTransfer 将 db.Transfer 转换为 pb.Transfer ，from 和 to 分别是转出账户和转入账户的货币
+func convertTransfer(transfer db.Transfer, from string, to string) *pb.Transfer {
+	return &pb.Transfer{
+		Id:             transfer.ID,
+		FromAccountId:  transfer.FromAccountID,
+		ToAccountId:    transfer.ToAccountID,
+		Amount:         convertMoney(transfer.Amount, from),
+		ToAmount:       convertMoney(transfer.ToAmount, to),
+		ExchangeRate:   transfer.ExchangeRate,
+		QuoteId:        transfer.QuoteID,
+		CreatedAt:      timestamppb.New(transfer.CreatedAt),
+		Status:         transfer.Status,
+		ReversedAmount: convertMoney(transfer.ReversedAmount, from),
+	}
+}
+
+// convertEntry 将 db.Entry 转换为 pb.Entry ，currency 是记录所属账户的货币
+func convertEntry(entry db.Entry, currency string) *pb.Entry {
+	return &pb.Entry{
+		Id:             entry.ID,
+		AccountId:      entry.AccountID,
+		Amount:         convertMoney(entry.Amount, currency),
+		CreatedAt:      timestamppb.New(entry.CreatedAt),
+		TransferId:     entry.TransferID,
+		ReversalId:     entry.ReversalID,
+		JournalEntryId: entry.JournalEntryID,
+	}
+}
+
+// convertTransferResult 将 db.TransferTxResult 转换为 pb.TransferResult
+func convertTransferResult(result db.TransferTxResult) *pb.TransferResult {
+	return &pb.TransferResult{
+		Transfer:    convertTransfer(result.Transfer, result.FromAccount.Currency, result.ToAccount.Currency),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry, result.FromAccount.Currency),
+		ToEntry:     convertEntry(result.ToEntry, result.ToAccount.Currency),
+	}
+}
+
+// convertSession 将 db.Session 转换为不包含刷新令牌的 pb.Session
+func convertSession(session db.Session) *pb.Session {
+	return &pb.Session{
+		Id:        session.ID.String(),
+		Username:  session.Username,
+		UserAgent: session.UserAgent,
+		ClientIp:  session.ClientIp,
+		IsBlocked: session.IsBlocked,
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+		CreatedAt: timestamppb.New(session.CreatedAt),
+	}
+}
+
+// convertTransferReversal 将 db.TransferReversal 转换为 pb.TransferReversal ，from 和 to 分别是原交易转出账户和转入账户的货币
+func convertTransferReversal(reversal db.TransferReversal, from string, to string) *pb.TransferReversal {
+	return &pb.TransferReversal{
+		Id:         reversal.ID,
+		TransferId: reversal.TransferID,
+		Amount:     convertMoney(reversal.Amount, from),
+		ToAmount:   convertMoney(reversal.ToAmount, to),
+		Reason:     reversal.Reason,
+		ReversedBy: reversal.ReversedBy,
+		CreatedAt:  timestamppb.New(reversal.CreatedAt),
+	}
+}
+
+// convertStatementEntry 将对账单的条目转换为 pb.StatementEntry ，currency 是账户的货币
+func convertStatementEntry(entry db.ListStatementEntriesRow, currency string) *pb.StatementEntry {
+	return &pb.StatementEntry{
+		Id:                    entry.ID,
+		AccountId:             entry.AccountID,
+		Amount:                convertMoney(entry.Amount, currency),
+		TransferId:            entry.TransferID,
+		ReversalId:            entry.ReversalID,
+		CounterpartyAccountId: entry.CounterpartyAccountID,
+		RunningBalance:        convertMoney(entry.RunningBalance, currency),
+		CreatedAt:             timestamppb.New(entry.CreatedAt),
+	}
+}
+
+// convertDeposit 将 db.Deposit 转换为 pb.Deposit ，currency 是存款账户的货币
+func convertDeposit(deposit db.Deposit, currency string) *pb.Deposit {
+	return &pb.Deposit{
+		Id:                deposit.ID,
+		AccountId:         deposit.AccountID,
+		Amount:            convertMoney(deposit.Amount, currency),
+		ExternalReference: deposit.ExternalReference,
+		Status:            deposit.Status,
+		CreatedBy:         deposit.CreatedBy,
+		CreatedAt:         timestamppb.New(deposit.CreatedAt),
+		ResolvedAt:        convertOptionalTime(deposit.ResolvedAt),
+	}
+}
+
+// convertDepositResult 将 db.DepositTxResult 转换为 pb.DepositResult
+func convertDepositResult(result db.DepositTxResult) *pb.DepositResult {
+	currency := result.Account.Currency
+	rsp := &pb.DepositResult{
+		Deposit: convertDeposit(result.Deposit, currency),
+		Account: convertAccount(result.Account),
+	}
+	if result.Entry != nil {
+		rsp.Entry = convertEntry(*result.Entry, currency)
+	}
+	return rsp
+}
+
+// convertWithdrawal 将 db.Withdrawal 转换为 pb.Withdrawal ，currency 是取款账户的货币
+func convertWithdrawal(withdrawal db.Withdrawal, currency string) *pb.Withdrawal {
+	return &pb.Withdrawal{
+		Id:                withdrawal.ID,
+		AccountId:         withdrawal.AccountID,
+		Amount:            convertMoney(withdrawal.Amount, currency),
+		ExternalReference: withdrawal.ExternalReference,
+		CreatedBy:         withdrawal.CreatedBy,
+		CreatedAt:         timestamppb.New(withdrawal.CreatedAt),
+	}
+}
+
+// convertReconciliation 将 db.ReconciliationResult 转换为 pb.Reconciliation
+func convertReconciliation(result db.ReconciliationResult) *pb.Reconciliation {
+	rsp := &pb.Reconciliation{
+		Id:              result.Run.ID,
+		AccountsChecked: result.Run.AccountsChecked,
+		DriftCount:      result.Run.DriftCount,
+		StartedAt:       timestamppb.New(result.Run.StartedAt),
+		FinishedAt:      timestamppb.New(result.Run.FinishedAt),
+	}
+	for _, drift := range result.Drifts {
+		rsp.Drifts = append(rsp.Drifts, &pb.BalanceDrift{
+			AccountId:      drift.AccountID,
+			Owner:          drift.Owner,
+			Balance:        convertMoney(drift.Balance, drift.Currency),
+			EntriesBalance: convertMoney(drift.EntriesBalance, drift.Currency),
+			Difference:     convertMoney(drift.Balance-drift.EntriesBalance, drift.Currency),
+		})
+	}
+	return rsp
+}
+
+// convertScheduledTransfer 将 db.ScheduledTransfer 转换为 pb.ScheduledTransfer
+func convertScheduledTransfer(scheduledTransfer db.ScheduledTransfer) *pb.ScheduledTransfer {
+	return &pb.ScheduledTransfer{
+		Id:            scheduledTransfer.ID,
+		Owner:         scheduledTransfer.Owner,
+		FromAccountId: scheduledTransfer.FromAccountID,
+		ToAccountId:   scheduledTransfer.ToAccountID,
+		Amount:        convertMoney(scheduledTransfer.Amount, scheduledTransfer.Currency),
+		Schedule:      scheduledTransfer.Schedule,
+		NextRunAt:     timestamppb.New(scheduledTransfer.NextRunAt),
+		EndAt:         convertOptionalTime(scheduledTransfer.EndAt),
+		Status:        scheduledTransfer.Status,
+		CreatedAt:     timestamppb.New(scheduledTransfer.CreatedAt),
+	}
+}
+
+// convertScheduledTransferRun 将 db.ScheduledTransferRun 转换为 pb.ScheduledTransferRun
+func convertScheduledTransferRun(run db.ScheduledTransferRun) *pb.ScheduledTransferRun {
+	return &pb.ScheduledTransferRun{
+		Id:                  run.ID,
+		ScheduledTransferId: run.ScheduledTransferID,
+		ScheduledAt:         timestamppb.New(run.ScheduledAt),
+		TransferId:          run.TransferID,
+		Status:              run.Status,
+		FailureReason:       run.FailureReason,
+		CreatedAt:           timestamppb.New(run.CreatedAt),
+	}
+}
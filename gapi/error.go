@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fieldViolation 创建一个描述请求中某个字段不合法的错误详情
+func fieldViolation(field string, err error) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: err.Error(),
+	}
+}
+
+// invalidArgumentError 将所有不合法的字段整合为一个 InvalidArgument 错误返回给客户端
+func invalidArgumentError(violations []*errdetails.BadRequest_FieldViolation) error {
+	badRequest := &errdetails.BadRequest{FieldViolations: violations}
+	statusInvalid := status.New(codes.InvalidArgument, "invalid parameters")
+
+	statusDetails, err := statusInvalid.WithDetails(badRequest)
+	if err != nil {
+		return statusInvalid.Err()
+	}
+	return statusDetails.Err()
+}
+
+// transferLimitViolationType 是违反交易限额规则的错误详情的类型
+const transferLimitViolationType = "TRANSFER_LIMIT"
+
+// transferLimitError 将违反限额规则的错误转换为 FailedPrecondition 错误，每条违反的规则作为一个错误详情，Subject 为规则的 ID
+func transferLimitError(err *db.TransferLimitError) error {
+	failure := &errdetails.PreconditionFailure{}
+	for _, violation := range err.Violations {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:    transferLimitViolationType,
+			Subject: violation.RuleID,
+			Description: fmt.Sprintf(
+				"%s %s per %s is limited to %d, used %d, requested %d",
+				violation.Scope, violation.Metric, violation.Period, violation.Limit, violation.Used, violation.Requested,
+			),
+		})
+	}
+	statusFailed := status.New(codes.FailedPrecondition, err.Error())
+
+	statusDetails, detailsErr := statusFailed.WithDetails(failure)
+	if detailsErr != nil {
+		return statusFailed.Err()
+	}
+	return statusDetails.Err()
+}
@@ -0,0 +1,194 @@
+package gapi
+
+import (
+	"SimpleBank/pb"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// exportStatementPath 是导出对账单的路由，与 proto 文件中 ExportStatement 的 google.api.http 选项一致
+const exportStatementPath = "/v1/accounts/{id}/statement"
+
+// NewGatewayHandler 创建将 HTTP JSON 请求转换为 gRPC 方法调用的处理器，
+// 路由由 proto 文件中的 google.api.http 选项定义，请求直接在进程内交给 server 处理
+func NewGatewayHandler(ctx context.Context, server *Server) (http.Handler, error) {
+	grpcMux := runtime.NewServeMux(
+		// JSON 中的字段名使用 proto 中的蛇形命名
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+	)
+
+	err := pb.RegisterSimpleBankHandlerServer(ctx, grpcMux, server)
+	if err != nil {
+		return nil, fmt.Errorf("cannot register handler server: %w", err)
+	}
+
+	// 进程内的转换不支持服务端流式方法，导出对账单的路由直接调用 server 处理，
+	// 后注册的处理器优先匹配，会覆盖生成代码中返回 Unimplemented 错误的处理器
+	err = grpcMux.HandlePath(http.MethodGet, exportStatementPath, exportStatementHandler(grpcMux, server))
+	if err != nil {
+		return nil, fmt.Errorf("cannot register export statement handler: %w", err)
+	}
+	return requestIDHandler(grpcMux), nil
+}
+
+// incomingHeaderMatcher 决定哪些 HTTP 请求头会被转换为 gRPC 元数据，
+// 除了默认转换的请求头之外，还需要转换携带幂等键和请求 ID 的请求头
+func incomingHeaderMatcher(key string) (string, bool) {
+	for _, header := range []string{idempotencyKeyHeader, requestIDHeader} {
+		if strings.EqualFold(key, header) {
+			return header, true
+		}
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// requestIDHandler 为每个请求确定一个请求 ID 并写入响应头，客户端没有提供请求 ID 时生成一个新的 ID ，
+// 请求 ID 作为元数据传给 gRPC 方法，与操作者一起记录在审计日志中
+func requestIDHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+			r.Header.Set(requestIDHeader, requestID)
+		}
+		w.Header().Set(requestIDHeader, requestID)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// exportStatementHandler 将导出对账单的 HTTP 请求转换为 ExportStatement 方法的调用，
+// 方法发送的每个数据块直接写入响应，响应的 Content-Type 为数据块的类型
+func exportStatementHandler(mux *runtime.ServeMux, server *Server) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, r)
+		ctx, err := runtime.AnnotateIncomingContext(r.Context(), mux, r, pb.SimpleBank_ExportStatement_FullMethodName, runtime.WithHTTPPathPattern(exportStatementPath))
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		req, err := parseExportStatementRequest(r, pathParams)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		stream := &httpBodyStream{ctx: ctx, w: w}
+		if err := server.ExportStatement(req, stream); err != nil {
+			// 已经写入数据之后无法再修改状态码，只能中断响应，避免客户端把不完整的文件当作完整的文件
+			if stream.wroteHeader {
+				panic(http.ErrAbortHandler)
+			}
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+		}
+	}
+}
+
+// parseExportStatementRequest 从路径参数和查询参数中解析导出对账单的请求，路径中的 id 不能被查询参数覆盖
+func parseExportStatementRequest(r *http.Request, pathParams map[string]string) (*pb.ExportStatementRequest, error) {
+	req := &pb.ExportStatementRequest{}
+
+	id, err := runtime.Int64(pathParams["id"])
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "id", err)
+	}
+	req.Id = id
+
+	if err := r.ParseForm(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	filter := utilities.NewDoubleArray([][]string{{"id"}})
+	if err := runtime.PopulateQueryParameters(req, r.Form, filter); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return req, nil
+}
+
+// httpBodyStream 实现 pb.SimpleBank_ExportStatementServer ，将方法发送的数据块写入 HTTP 响应
+// 方法设置的头部元数据在写入第一个数据块之前作为响应头写入
+type httpBodyStream struct {
+	ctx         context.Context
+	w           http.ResponseWriter
+	header      metadata.MD
+	wroteHeader bool
+}
+
+func (s *httpBodyStream) Send(body *httpbody.HttpBody) error {
+	if !s.wroteHeader {
+		s.w.Header().Set("Content-Type", body.GetContentType())
+		if err := s.SendHeader(nil); err != nil {
+			return err
+		}
+	}
+	if _, err := s.w.Write(body.GetData()); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (s *httpBodyStream) SetHeader(md metadata.MD) error {
+	if s.wroteHeader {
+		return errors.New("header already sent")
+	}
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *httpBodyStream) SendHeader(md metadata.MD) error {
+	if err := s.SetHeader(md); err != nil {
+		return err
+	}
+	for key, values := range s.header {
+		for _, value := range values {
+			s.w.Header().Add(key, value)
+		}
+	}
+	s.w.WriteHeader(http.StatusOK)
+	s.wroteHeader = true
+	return nil
+}
+
+// SetTrailer 忽略尾部元数据，HTTP 响应不会携带尾部
+func (s *httpBodyStream) SetTrailer(metadata.MD) {}
+
+func (s *httpBodyStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *httpBodyStream) SendMsg(m interface{}) error {
+	body, ok := m.(*httpbody.HttpBody)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", m)
+	}
+	return s.Send(body)
+}
+
+// RecvMsg 总是返回 io.EOF ，服务端流式方法只有一个请求，已经在调用时传入
+func (s *httpBodyStream) RecvMsg(interface{}) error {
+	return io.EOF
+}
@@ -0,0 +1,302 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatewayGetAccount(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	testCases := []struct {
+		name     string
+		username string
+		// 检查网关的输出
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				// 响应中的字段名使用 proto 中的蛇形命名
+				var rsp struct {
+					Account map[string]interface{} `json:"account"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &rsp)
+				require.NoError(t, err)
+				require.Equal(t, account.Owner, rsp.Account["owner"])
+				require.Equal(t, account.Currency, rsp.Account["currency"])
+				require.Contains(t, rsp.Account, "overdraft_limit")
+			},
+		},
+		{
+			// gRPC 的 PermissionDenied 状态码被转换为 HTTP 的 403 状态码
+			name:     "PermissionDenied",
+			username: "unauthorized",
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+			server := newTestServer(t, store)
+			handler, err := NewGatewayHandler(context.Background(), server)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/accounts/%d", account.ID), nil)
+			require.NoError(t, err)
+			accessToken, _, err := server.tokenMaker.CreateToken(tc.username, util.DepositorRole, token.TokenTypeAccess, time.Minute)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGatewayIdempotencyKeyHeader(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+	store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+	// 请求头中的幂等键被转换为元数据，交易使用带有幂等键的事务执行
+	store.EXPECT().
+		IdempotentTransferTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.IdempotentTransferTxParams) (db.IdempotentTransferTxResult, error) {
+			require.Equal(t, "transfer-key", arg.Key)
+			require.Equal(t, user1.Username, arg.Username)
+			// 请求散列值使用规范格式计算，与直接调用 gRPC 方法时相同
+			require.Equal(t, db.TransferRequestHash(account1.ID, account2.ID, 10, util.USD), arg.RequestHash)
+			return db.IdempotentTransferTxResult{Replayed: true}, nil
+		})
+
+	server := newTestServer(t, store)
+	handler, err := NewGatewayHandler(context.Background(), server)
+	require.NoError(t, err)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"from_account_id": account1.ID,
+		"to_account_id":   account2.ID,
+		"amount":          map[string]string{"amount": "0.10", "currency": util.USD},
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPost, "/v1/transfers", bytes.NewReader(body))
+	require.NoError(t, err)
+	accessToken, _, err := server.tokenMaker.CreateToken(user1.Username, util.DepositorRole, token.TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	request.Header.Set("Idempotency-Key", "transfer-key")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var rsp map[string]interface{}
+	err = json.Unmarshal(recorder.Body.Bytes(), &rsp)
+	require.NoError(t, err)
+	require.Equal(t, true, rsp["replayed"])
+}
+
+func TestGatewayExportStatement(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = util.USD
+
+	endTime := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	startTime := endTime.Add(-24 * time.Hour)
+	query := fmt.Sprintf("start_time=%s&end_time=%s", startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+
+	testCases := []struct {
+		name       string
+		query      string
+		buildStubs func(store *mockdb.MockStore)
+		// 检查网关的输出
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			// 对账单文件直接写入响应，而不是编码为 JSON
+			name:  "OK",
+			query: query + "&format=csv",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountBalanceAt(gomock.Any(), gomock.Any()).Times(2).Return(int64(1000), nil)
+				entries := []db.ListStatementExportEntriesRow{
+					{ID: 1, AccountID: account.ID, Amount: 150, CreatedAt: startTime.Add(time.Hour)},
+				}
+				store.EXPECT().ListStatementExportEntries(gomock.Any(), gomock.Any()).Times(1).Return(entries, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Equal(t,
+					fmt.Sprintf(`attachment; filename="statement-%d-20240229-20240301.csv"`, account.ID),
+					recorder.Header().Get("Content-Disposition"))
+
+				body := recorder.Body.String()
+				require.True(t, strings.HasPrefix(body, fmt.Sprintf("account_id,%d\n", account.ID)))
+				require.Contains(t, body, "1.50,11.50")
+			},
+		},
+		{
+			// 请求不合法时返回 JSON 格式的错误
+			name:  "UnsupportedFormat",
+			query: query + "&format=xls",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+				require.Empty(t, recorder.Header().Get("Content-Disposition"))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			handler, err := NewGatewayHandler(context.Background(), server)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/v1/accounts/%d/statement?%s", account.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			accessToken, _, err := server.tokenMaker.CreateToken(user.Username, util.DepositorRole, token.TokenTypeAccess, time.Minute)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGatewayRequestIDHeader(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	testCases := []struct {
+		name      string
+		requestID string
+		// 检查响应头中的请求 ID
+		checkRequestID func(t *testing.T, requestID string)
+	}{
+		{
+			// 客户端提供的请求 ID 原样返回，并作为元数据传给 gRPC 方法
+			name:      "Provided",
+			requestID: "request-1",
+			checkRequestID: func(t *testing.T, requestID string) {
+				require.Equal(t, "request-1", requestID)
+			},
+		},
+		{
+			// 客户端没有提供请求 ID 时生成一个新的 ID
+			name:      "Generated",
+			requestID: "",
+			checkRequestID: func(t *testing.T, requestID string) {
+				require.Len(t, requestID, 36)
+			},
+		},
+		{
+			// 过长的请求 ID 被替换为新生成的 ID
+			name:      "TooLong",
+			requestID: strings.Repeat("a", maxRequestIDLength+1),
+			checkRequestID: func(t *testing.T, requestID string) {
+				require.Len(t, requestID, 36)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+			var auditRequestID string
+			store.EXPECT().
+				UpdateAccountStatusTx(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, arg db.UpdateAccountStatusTxParams) (db.Account, error) {
+					auditRequestID = db.AuditMetadataFromContext(ctx).RequestID
+					return account, nil
+				})
+
+			server := newTestServer(t, store)
+			handler, err := NewGatewayHandler(context.Background(), server)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/v1/accounts/%d/freeze", account.ID), nil)
+			require.NoError(t, err)
+			accessToken, _, err := server.tokenMaker.CreateToken(user.Username, util.DepositorRole, token.TokenTypeAccess, time.Minute)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+			if tc.requestID != "" {
+				request.Header.Set("X-Request-ID", tc.requestID)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			// 响应头中的请求 ID 与审计日志中记录的请求 ID 相同
+			requestID := recorder.Header().Get("X-Request-ID")
+			tc.checkRequestID(t, requestID)
+			require.Equal(t, requestID, auditRequestID)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/fx"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"SimpleBank/worker"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+// newTestServer 创建一个用于测试的 gRPC 服务器
+func newTestServer(t *testing.T, store db.Store) *Server {
+	config := util.Config{
+		TokenSymmetricKey:       util.RandomString(32),
+		AccessTokenDuration:     time.Minute,
+		RefreshTokenDuration:    time.Hour,
+		UnverifiedTransferLimit: 1000,
+	}
+
+	// 使用固定的汇率表，便于测试跨币种交易
+	exchangeRateProvider, err := fx.NewStaticProvider(map[string]string{
+		util.USD + "/" + util.EUR: "0.92",
+	}, time.Minute)
+	require.NoError(t, err)
+
+	// 认证时会查询用户修改密码的时间，没有在 buildStubs 中指定时，默认用户没有修改过密码
+	if mockStore, ok := store.(*mockdb.MockStore); ok {
+		mockStore.EXPECT().GetUserPasswordChangedAt(gomock.Any(), gomock.Any()).AnyTimes().Return(time.Time{}, nil)
+	}
+
+	server, err := NewServer(config, store, exchangeRateProvider, worker.NewPostgresTaskDistributor())
+	require.NoError(t, err)
+
+	return server
+}
+
+// newContextWithBearerToken 为指定的用户和角色创建访问令牌，并返回在元数据中携带该令牌的上下文
+func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, username string, role string, duration time.Duration) context.Context {
+	accessToken, _, err := tokenMaker.CreateToken(username, role, token.TokenTypeAccess, duration)
+	require.NoError(t, err)
+
+	md := metadata.MD{
+		authorizationHeader: []string{fmt.Sprintf("%s %s", authorizationBearer, accessToken)},
+	}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+// randomUser 生成一个随机的用户
+func randomUser(t *testing.T) (user db.User, password string) {
+	password = util.RandomString(6)
+	hashedPassword, err := util.HashPassword(password)
+	require.NoError(t, err)
+
+	user = db.User{
+		Username:       util.RandomOwner(),
+		HashedPassword: hashedPassword,
+		FullName:       util.RandomOwner(),
+		Email:          util.RandomEmail(),
+		Role:           util.DepositorRole,
+	}
+	return
+}
+
+// randomAccount 生成一个属于 owner 的随机账户
+func randomAccount(owner string) db.Account {
+	return db.Account{
+		ID:       util.RandomInt(1, 1000),
+		Owner:    owner,
+		Balance:  util.RandomMoney(),
+		Currency: util.RandomCurrency(),
+		Status:   db.AccountStatusActive,
+	}
+}
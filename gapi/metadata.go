@@ -0,0 +1,72 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// 通过 HTTP 网关调用时，网关会将客户端的信息放在以下元数据键中
+const (
+	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
+	userAgentHeader            = "user-agent"
+	xForwardedForHeader        = "x-forwarded-for"
+	// 携带请求 ID 的元数据键，通过 HTTP 网关调用时由 X-Request-ID 请求头转换得到
+	requestIDHeader = "x-request-id"
+)
+
+// maxRequestIDLength 是客户端提供的请求 ID 的最大长度，超过时生成一个新的 ID
+const maxRequestIDLength = 128
+
+// Metadata 是从请求中提取的客户端信息
+type Metadata struct {
+	UserAgent string
+	ClientIP  string
+}
+
+// extractMetadata 从请求的元数据中提取客户端的 User-Agent 和 IP 地址，
+// 同时支持直接通过 gRPC 调用和通过 HTTP 网关调用
+func (server *Server) extractMetadata(ctx context.Context) *Metadata {
+	mtdt := &Metadata{}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if userAgents := md.Get(grpcGatewayUserAgentHeader); len(userAgents) > 0 {
+			mtdt.UserAgent = userAgents[0]
+		} else if userAgents := md.Get(userAgentHeader); len(userAgents) > 0 {
+			mtdt.UserAgent = userAgents[0]
+		}
+
+		// X-Forwarded-For 中第一个地址是客户端的地址
+		if clientIPs := md.Get(xForwardedForHeader); len(clientIPs) > 0 {
+			mtdt.ClientIP = strings.TrimSpace(strings.Split(clientIPs[0], ",")[0])
+		}
+	}
+
+	if mtdt.ClientIP == "" {
+		if p, ok := peer.FromContext(ctx); ok {
+			mtdt.ClientIP = p.Addr.String()
+		}
+	}
+
+	return mtdt
+}
+
+// withAuditMetadata 返回一个带有审计信息的 ctx ，操作者为 actor ，请求 ID 从元数据中读取，没有时生成一个新的 ID
+func withAuditMetadata(ctx context.Context, actor string) context.Context {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if requestIDs := md.Get(requestIDHeader); len(requestIDs) > 0 && len(requestIDs[0]) <= maxRequestIDLength {
+			requestID = requestIDs[0]
+		}
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+
+	return db.WithAuditMetadata(ctx, db.AuditMetadata{Actor: actor, RequestID: requestID})
+}
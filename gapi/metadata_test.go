@@ -0,0 +1,31 @@
+package gapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestExtractMetadata(t *testing.T) {
+	server := &Server{}
+
+	// 通过 HTTP 网关调用时，使用网关转发的 User-Agent 和 X-Forwarded-For
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		grpcGatewayUserAgentHeader, "curl/8.0",
+		xForwardedForHeader, "203.0.113.1, 10.0.0.1",
+	))
+	mtdt := server.extractMetadata(ctx)
+	require.Equal(t, "curl/8.0", mtdt.UserAgent)
+	require.Equal(t, "203.0.113.1", mtdt.ClientIP)
+
+	// 直接通过 gRPC 调用时，使用 gRPC 客户端的 User-Agent 和连接的对端地址
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(userAgentHeader, "grpc-go/1.60"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 50051}})
+	mtdt = server.extractMetadata(ctx)
+	require.Equal(t, "grpc-go/1.60", mtdt.UserAgent)
+	require.Equal(t, "127.0.0.1:50051", mtdt.ClientIP)
+}
@@ -0,0 +1,52 @@
+package gapi
+
+import (
+	"SimpleBank/util"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// pageRequest 是分页查询的请求共有的分页参数
+// 指定 page_id 时使用页码分页，否则使用游标分页，cursor 为空时从第一页开始
+type pageRequest interface {
+	GetPageId() int32
+	GetPageSize() int32
+	GetCursor() string
+}
+
+// validatePageRequest 检验分页参数，返回所有不合法的字段
+func validatePageRequest(req pageRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if req.GetPageId() < 0 {
+		violations = append(violations, fieldViolation("page_id", fmt.Errorf("must not be negative")))
+	}
+	if req.GetPageId() > 0 && req.GetCursor() != "" {
+		violations = append(violations, fieldViolation("cursor", fmt.Errorf("cannot be used together with page_id")))
+	}
+	if err := validatePageSize(req.GetPageSize()); err != nil {
+		violations = append(violations, fieldViolation("page_size", err))
+	}
+	return violations
+}
+
+// pagePosition 将分页参数转换为数据库查询的 after_id 和 offset ，两种分页方式中总有一个为 0
+func pagePosition(req pageRequest) (afterID int64, offset int32, err error) {
+	if req.GetPageId() > 0 {
+		return 0, (req.GetPageId() - 1) * req.GetPageSize(), nil
+	}
+
+	cursor, err := util.DecodeCursor(req.GetCursor())
+	if err != nil {
+		return 0, 0, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("cursor", err)})
+	}
+	return cursor.ID, 0, nil
+}
+
+// nextPageCursor 在游标分页并且当前页已满时返回指向该页最后一条记录的游标，否则返回空字符串
+func nextPageCursor(req pageRequest, count int, lastID int64, lastCreatedAt time.Time) string {
+	if req.GetPageId() > 0 || count == 0 || count < int(req.GetPageSize()) {
+		return ""
+	}
+	return util.EncodeCursor(util.PageCursor{ID: lastID, CreatedAt: lastCreatedAt})
+}
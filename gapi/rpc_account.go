@@ -0,0 +1,163 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateAccount 为当前登录的用户创建一个账户
+func (server *Server) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.CreateAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, depositorOnly)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withAuditMetadata(ctx, authPayload.Username)
+
+	if err := validateCurrency(req.GetCurrency()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("currency", err)})
+	}
+
+	account, err := server.store.CreateAccountTx(ctx, db.CreateAccountParams{
+		Owner:    authPayload.Username,
+		Balance:  0,
+		Currency: req.GetCurrency(),
+	})
+	if err != nil {
+		// 该用户已经拥有这种货币的账户
+		if pqErr, ok := err.(*pgconn.PgError); ok && pqErr.Code == "23505" {
+			return nil, status.Errorf(codes.AlreadyExists, "account already exists: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create account: %s", err)
+	}
+
+	rsp := &pb.CreateAccountResponse{
+		Account: convertAccount(account),
+	}
+	return rsp, nil
+}
+
+// GetAccount 查询当前登录的用户的一个账户，银行职员可以查询任意账户
+func (server *Server) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, anyRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateID(req.GetId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	// 银行职员可以查询任意客户的账户
+	account, err := server.authorizedAccount(ctx, authPayload, req.GetId(), util.BankerRole)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.GetAccountResponse{
+		Account: convertAccount(account),
+	}
+	return rsp, nil
+}
+
+// ListAccounts 分页查询当前登录的用户的账户
+func (server *Server) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, depositorOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	if violations := validatePageRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	return server.listOwnerAccounts(ctx, authPayload.Username, req)
+}
+
+// ListUserAccounts 分页查询指定用户的账户，只允许银行职员访问，用于查询客户的账户
+func (server *Server) ListUserAccounts(ctx context.Context, req *pb.ListUserAccountsRequest) (*pb.ListAccountsResponse, error) {
+	_, err := server.authorizeUser(ctx, bankerOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validatePageRequest(req)
+	if err := validateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// 用户不存在时返回 NotFound ，而不是一个空的账户列表
+	if _, err := server.store.GetUser(ctx, req.GetUsername()); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find user: %s", err)
+	}
+
+	return server.listOwnerAccounts(ctx, req.GetUsername(), req)
+}
+
+// listOwnerAccounts 分页查询 owner 的账户
+func (server *Server) listOwnerAccounts(ctx context.Context, owner string, req pageRequest) (*pb.ListAccountsResponse, error) {
+	afterID, offset, err := pagePosition(req)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := server.store.ListAccounts(ctx, db.ListAccountsParams{
+		Owner:   owner,
+		AfterID: afterID,
+		Limit:   req.GetPageSize(),
+		Offset:  offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list accounts: %s", err)
+	}
+
+	rsp := &pb.ListAccountsResponse{}
+	for _, account := range accounts {
+		rsp.Accounts = append(rsp.Accounts, convertAccount(account))
+	}
+	// 游标分页时，当前页已满说明可能还有更多的账户，返回下一页的游标
+	if len(accounts) > 0 {
+		last := accounts[len(accounts)-1]
+		rsp.NextCursor = nextPageCursor(req, len(accounts), last.ID, last.CreatedAt)
+	}
+	return rsp, nil
+}
+
+// findAccount 查询指定 ID 的账户，并将错误转换为 gRPC 的状态码
+func (server *Server) findAccount(ctx context.Context, accountID int64) (db.Account, error) {
+	account, err := server.store.GetAccountForUpdate(ctx, accountID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return account, status.Errorf(codes.NotFound, "account [%d] not found", accountID)
+		}
+		return account, status.Errorf(codes.Internal, "failed to get account: %s", err)
+	}
+	return account, nil
+}
+
+// authorizedAccount 查询指定 ID 的账户，并检查账户是否属于当前登录的用户
+// 当前登录的用户的角色在 extraRoles 中时，也可以访问其他用户的账户
+func (server *Server) authorizedAccount(ctx context.Context, authPayload *token.Payload, accountID int64, extraRoles ...string) (db.Account, error) {
+	account, err := server.findAccount(ctx, accountID)
+	if err != nil {
+		return account, err
+	}
+	if account.Owner != authPayload.Username && !hasRole(extraRoles, authPayload.Role) {
+		return account, status.Errorf(codes.PermissionDenied, "account [%d] doesn't belong to the authenticated user", accountID)
+	}
+	return account, nil
+}
@@ -0,0 +1,149 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FreezeAccount 冻结账户，银行职员可以冻结任意客户的账户
+func (server *Server) FreezeAccount(ctx context.Context, req *pb.FreezeAccountRequest) (*pb.FreezeAccountResponse, error) {
+	account, err := server.updateAccountStatus(ctx, req.GetId(), anyRole, db.AccountStatusActive, db.AccountStatusFrozen)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.FreezeAccountResponse{Account: convertAccount(account)}, nil
+}
+
+// UnfreezeAccount 解冻账户，银行职员可以解冻任意客户的账户，客户只能解冻自己冻结的账户
+func (server *Server) UnfreezeAccount(ctx context.Context, req *pb.UnfreezeAccountRequest) (*pb.UnfreezeAccountResponse, error) {
+	account, err := server.updateAccountStatus(ctx, req.GetId(), anyRole, db.AccountStatusFrozen, db.AccountStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UnfreezeAccountResponse{Account: convertAccount(account)}, nil
+}
+
+// ReopenAccount 重新开启当前登录的用户已经关闭的账户
+func (server *Server) ReopenAccount(ctx context.Context, req *pb.ReopenAccountRequest) (*pb.ReopenAccountResponse, error) {
+	account, err := server.updateAccountStatus(ctx, req.GetId(), depositorOnly, db.AccountStatusClosed, db.AccountStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReopenAccountResponse{Account: convertAccount(account)}, nil
+}
+
+// updateAccountStatus 将账户的状态从 from 修改为 to ，accessibleRoles 决定了哪些角色可以执行这个状态转换
+// 银行职员可以修改任意账户的状态，客户只能修改自己的账户
+func (server *Server) updateAccountStatus(ctx context.Context, accountID int64, accessibleRoles []string, from, to string) (db.Account, error) {
+	authPayload, err := server.authorizeUser(ctx, accessibleRoles)
+	if err != nil {
+		return db.Account{}, err
+	}
+	ctx = withAuditMetadata(ctx, authPayload.Username)
+
+	if err := validateID(accountID); err != nil {
+		return db.Account{}, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	account, err := server.authorizedAccount(ctx, authPayload, accountID, util.BankerRole)
+	if err != nil {
+		return db.Account{}, err
+	}
+
+	// 在事务中锁定账户并检查状态转换是否合法，客户不能解冻银行职员冻结的账户
+	account, err = server.store.UpdateAccountStatusTx(ctx, db.UpdateAccountStatusTxParams{
+		AccountID:       account.ID,
+		FromStatus:      from,
+		Status:          to,
+		ChangedBy:       authPayload.Username,
+		ChangedByBanker: authPayload.Role == util.BankerRole,
+	})
+	if err != nil {
+		return db.Account{}, accountStatusError(err)
+	}
+	return account, nil
+}
+
+// CloseAccount 关闭当前登录的用户的账户，账户还有余额时将余额全部转入 sweep 账户
+func (server *Server) CloseAccount(ctx context.Context, req *pb.CloseAccountRequest) (*pb.CloseAccountResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, depositorOnly)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withAuditMetadata(ctx, authPayload.Username)
+
+	if violations := validateCloseAccountRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.authorizedAccount(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	// sweep 账户同样必须属于当前登录的用户，并且货币类型与关闭的账户一致
+	if req.SweepAccountId != nil {
+		sweepAccount, err := server.authorizedAccount(ctx, authPayload, req.GetSweepAccountId())
+		if err != nil {
+			return nil, err
+		}
+		if sweepAccount.Currency != account.Currency {
+			err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", sweepAccount.ID, sweepAccount.Currency, account.Currency)
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("sweep_account_id", err)})
+		}
+	}
+
+	result, err := server.store.CloseAccountTx(ctx, db.CloseAccountTxParams{
+		AccountID:      account.ID,
+		SweepAccountID: req.SweepAccountId,
+	})
+	if err != nil {
+		return nil, accountStatusError(err)
+	}
+
+	rsp := &pb.CloseAccountResponse{
+		Account: convertAccount(result.Account),
+	}
+	if result.SweepTransfer != nil {
+		rsp.SweepTransfer = convertTransferResult(*result.SweepTransfer)
+	}
+	return rsp, nil
+}
+
+// validateCloseAccountRequest 检验关闭账户请求中的所有字段，返回所有不合法的字段
+func validateCloseAccountRequest(req *pb.CloseAccountRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if req.SweepAccountId != nil {
+		if err := validateID(req.GetSweepAccountId()); err != nil {
+			violations = append(violations, fieldViolation("sweep_account_id", err))
+		} else if req.GetSweepAccountId() == req.GetId() {
+			violations = append(violations, fieldViolation("sweep_account_id", errors.New("must be different from the closed account")))
+		}
+	}
+	return violations
+}
+
+// accountStatusError 将修改账户状态时产生的错误转换为 gRPC 的状态码
+func accountStatusError(err error) error {
+	switch {
+	// 当前状态不允许转换为目标状态，账户还有余额或者欠款，或者 sweep 账户不是正常状态
+	case errors.Is(err, db.ErrInvalidAccountStatusTransition),
+		errors.Is(err, db.ErrAccountBalanceNotZero),
+		errors.Is(err, db.ErrAccountNotActive):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	// 客户解冻银行职员冻结的账户
+	case errors.Is(err, db.ErrAccountFrozenByOther):
+		return status.Errorf(codes.PermissionDenied, "%s", err)
+	}
+	return status.Errorf(codes.Internal, "failed to update account status: %s", err)
+}
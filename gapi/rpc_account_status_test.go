@@ -0,0 +1,242 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFreezeAccountRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	testCases := []struct {
+		name          string
+		req           *pb.FreezeAccountRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, rsp *pb.FreezeAccountResponse, err error)
+	}{
+		{
+			// 银行职员可以冻结任意客户的账户
+			name: "BankerOK",
+			req:  &pb.FreezeAccountRequest{Id: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				frozen := account
+				frozen.Status = db.AccountStatusFrozen
+				arg := db.UpdateAccountStatusTxParams{
+					AccountID:       account.ID,
+					FromStatus:      db.AccountStatusActive,
+					Status:          db.AccountStatusFrozen,
+					ChangedBy:       "banker",
+					ChangedByBanker: true,
+				}
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(frozen, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.FreezeAccountResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.AccountStatusFrozen, rsp.GetAccount().GetStatus())
+			},
+		},
+		{
+			// 客户不能冻结其他客户的账户
+			name: "PermissionDenied",
+			req:  &pb.FreezeAccountRequest{Id: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "unauthorized", util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.FreezeAccountResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			// 账户已经冻结或者关闭时不能再冻结
+			name: "InvalidTransition",
+			req:  &pb.FreezeAccountRequest{Id: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, db.ErrInvalidAccountStatusTransition)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.FreezeAccountResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidID",
+			req:  &pb.FreezeAccountRequest{Id: 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.FreezeAccountResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			rsp, err := server.FreezeAccount(ctx, tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+func TestUnfreezeAccountFrozenByOtherRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 客户不能解冻银行职员冻结的账户
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+	store.EXPECT().UpdateAccountStatusTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Account{}, db.ErrAccountFrozenByOther)
+
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, util.DepositorRole, time.Minute)
+	_, err := server.UnfreezeAccount(ctx, &pb.UnfreezeAccountRequest{Id: account.ID})
+	require.Error(t, err)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestCloseAccountRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	sweepAccount := randomAccount(user.Username)
+	sweepAccount.ID = account.ID + 1
+	sweepAccount.Currency = account.Currency
+
+	testCases := []struct {
+		name          string
+		req           *pb.CloseAccountRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, rsp *pb.CloseAccountResponse, err error)
+	}{
+		{
+			// 账户的余额全部转入 sweep 账户
+			name: "OK",
+			req:  &pb.CloseAccountRequest{Id: account.ID, SweepAccountId: &sweepAccount.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(sweepAccount.ID)).Times(1).Return(sweepAccount, nil)
+
+				closed := account
+				closed.Balance = 0
+				closed.Status = db.AccountStatusClosed
+				result := db.CloseAccountTxResult{
+					Account: closed,
+					SweepTransfer: &db.TransferTxResult{
+						Transfer: db.Transfer{ID: 1, FromAccountID: account.ID, ToAccountID: sweepAccount.ID, Amount: account.Balance},
+					},
+				}
+				arg := db.CloseAccountTxParams{AccountID: account.ID, SweepAccountID: &sweepAccount.ID}
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CloseAccountResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.AccountStatusClosed, rsp.GetAccount().GetStatus())
+				require.Equal(t, sweepAccount.ID, rsp.GetSweepTransfer().GetTransfer().GetToAccountId())
+			},
+		},
+		{
+			// 账户还有余额但没有指定 sweep 账户
+			name: "BalanceNotZero",
+			req:  &pb.CloseAccountRequest{Id: account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(1).Return(db.CloseAccountTxResult{}, db.ErrAccountBalanceNotZero)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CloseAccountResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			name: "SweepCurrencyMismatch",
+			req:  &pb.CloseAccountRequest{Id: account.ID, SweepAccountId: &sweepAccount.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				mismatched := sweepAccount
+				mismatched.Currency = util.USD
+				if account.Currency == util.USD {
+					mismatched.Currency = util.EUR
+				}
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(sweepAccount.ID)).Times(1).Return(mismatched, nil)
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CloseAccountResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			// sweep 账户不能是被关闭的账户本身
+			name: "SweepToItself",
+			req:  &pb.CloseAccountRequest{Id: account.ID, SweepAccountId: &account.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CloseAccountTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CloseAccountResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			rsp, err := server.CloseAccount(ctx, tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListUserAccountsRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	accounts := []db.Account{randomAccount(user.Username), randomAccount(user.Username)}
+
+	testCases := []struct {
+		name string
+		role string
+		// 构建 stubs 的方式
+		buildStubs func(store *mockdb.MockStore)
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, rsp *pb.ListAccountsResponse, err error)
+	}{
+		{
+			// 银行职员可以查询任意客户的账户
+			name: "OK",
+			role: util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+
+				arg := db.ListAccountsParams{Owner: user.Username, Limit: 5}
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(accounts, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ListAccountsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, rsp.GetAccounts(), len(accounts))
+				require.Empty(t, rsp.GetNextCursor())
+			},
+		},
+		{
+			// 用户不存在时返回 NotFound ，而不是一个空的账户列表
+			name: "UserNotFound",
+			role: util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(db.User{}, pgx.ErrNoRows)
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ListAccountsResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			// 客户不能查询其他用户的账户
+			name: "Depositor",
+			role: util.DepositorRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ListAccountsResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, "banker", tc.role, time.Minute)
+			rsp, err := server.ListUserAccounts(ctx, &pb.ListUserAccountsRequest{Username: user.Username, PageSize: 5})
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
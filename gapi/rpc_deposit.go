@@ -0,0 +1,230 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxExternalReferenceLength 是存款和取款在外部支付系统中的编号的最大长度
+const maxExternalReferenceLength = 255
+
+// CreateDeposit 向账户存款，客户可以向自己的账户存款，银行职员可以向任意客户的账户存款
+func (server *Server) CreateDeposit(ctx context.Context, req *pb.CreateDepositRequest) (*pb.CreateDepositResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, anyRole)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withAuditMetadata(ctx, authPayload.Username)
+
+	amount, violations := validateCashRequest(req.GetAccountId(), req.GetAmount(), req.GetExternalReference())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// 客户不能确认资金已经到账
+	if req.GetSettled() && authPayload.Role != util.BankerRole {
+		return nil, status.Errorf(codes.PermissionDenied, "only bankers can create settled deposits")
+	}
+
+	account, err := server.cashAccount(ctx, authPayload, req.GetAccountId(), amount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.DepositTx(ctx, db.DepositTxParams{
+		AccountID:         account.ID,
+		Amount:            amount.Amount,
+		ExternalReference: req.GetExternalReference(),
+		CreatedBy:         authPayload.Username,
+		Settled:           req.GetSettled(),
+	})
+	if err != nil {
+		return nil, cashError(err)
+	}
+
+	rsp := &pb.CreateDepositResponse{
+		Result: convertDepositResult(result),
+	}
+	return rsp, nil
+}
+
+// GetDeposit 查询一笔存款，客户只能查询自己账户的存款，银行职员可以查询任意存款
+func (server *Server) GetDeposit(ctx context.Context, req *pb.GetDepositRequest) (*pb.GetDepositResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, anyRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateID(req.GetId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	deposit, err := server.store.GetDeposit(ctx, req.GetId())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "deposit [%d] not found", req.GetId())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get deposit: %s", err)
+	}
+
+	account, err := server.authorizedAccount(ctx, authPayload, deposit.AccountID, util.BankerRole)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.GetDepositResponse{
+		Deposit: convertDeposit(deposit, account.Currency),
+	}
+	return rsp, nil
+}
+
+// SettleDeposit 确认等待入账的存款已经到账，将金额从清算账户存入客户账户，只允许银行职员访问
+func (server *Server) SettleDeposit(ctx context.Context, req *pb.SettleDepositRequest) (*pb.SettleDepositResponse, error) {
+	result, err := server.resolveDeposit(ctx, req.GetId(), db.DepositStatusSettled)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SettleDepositResponse{Result: convertDepositResult(result)}, nil
+}
+
+// FailDeposit 将等待入账的存款标记为失败，将金额从清算账户退回现金账户，只允许银行职员访问
+func (server *Server) FailDeposit(ctx context.Context, req *pb.FailDepositRequest) (*pb.FailDepositResponse, error) {
+	result, err := server.resolveDeposit(ctx, req.GetId(), db.DepositStatusFailed)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.FailDepositResponse{Result: convertDepositResult(result)}, nil
+}
+
+// resolveDeposit 将等待入账的存款标记为 depositStatus
+func (server *Server) resolveDeposit(ctx context.Context, depositID int64, depositStatus string) (db.DepositTxResult, error) {
+	authPayload, err := server.authorizeUser(ctx, bankerOnly)
+	if err != nil {
+		return db.DepositTxResult{}, err
+	}
+	ctx = withAuditMetadata(ctx, authPayload.Username)
+
+	if err := validateID(depositID); err != nil {
+		return db.DepositTxResult{}, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	result, err := server.store.ResolveDepositTx(ctx, db.ResolveDepositTxParams{
+		DepositID: depositID,
+		Status:    depositStatus,
+	})
+	if err != nil {
+		return db.DepositTxResult{}, cashError(err)
+	}
+	return result, nil
+}
+
+// CreateWithdrawal 从账户取款，客户可以从自己的账户取款，银行职员可以从任意客户的账户取款
+func (server *Server) CreateWithdrawal(ctx context.Context, req *pb.CreateWithdrawalRequest) (*pb.CreateWithdrawalResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, anyRole)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withAuditMetadata(ctx, authPayload.Username)
+
+	amount, violations := validateCashRequest(req.GetAccountId(), req.GetAmount(), req.GetExternalReference())
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	account, err := server.cashAccount(ctx, authPayload, req.GetAccountId(), amount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// 客户从自己的账户取款与转账相同，大额取款需要先验证邮箱，银行职员在柜台办理时已经核实了客户的身份
+	if authPayload.Username == account.Owner {
+		if err := server.requireVerifiedEmail(ctx, account.Owner, amount.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := server.store.WithdrawTx(ctx, db.WithdrawTxParams{
+		AccountID:         account.ID,
+		Amount:            amount.Amount,
+		ExternalReference: req.GetExternalReference(),
+		CreatedBy:         authPayload.Username,
+	})
+	if err != nil {
+		return nil, cashError(err)
+	}
+
+	rsp := &pb.CreateWithdrawalResponse{
+		Withdrawal: convertWithdrawal(result.Withdrawal, result.Account.Currency),
+		Account:    convertAccount(result.Account),
+		Entry:      convertEntry(result.Entry, result.Account.Currency),
+	}
+	return rsp, nil
+}
+
+// validateCashRequest 检验存款或者取款请求中的所有字段，返回以最小货币单位表示的金额和所有不合法的字段
+func validateCashRequest(accountID int64, value *pb.Money, externalReference string) (amount util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateID(accountID); err != nil {
+		violations = append(violations, fieldViolation("account_id", err))
+	}
+	amount, err := validateMoney(value)
+	if err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	if externalReference == "" {
+		violations = append(violations, fieldViolation("external_reference", errors.New("must not be empty")))
+	} else if len(externalReference) > maxExternalReferenceLength {
+		violations = append(violations, fieldViolation("external_reference", fmt.Errorf("must be at most %d characters", maxExternalReferenceLength)))
+	}
+	return amount, violations
+}
+
+// cashAccount 检验存款或者取款的账户是否存在、是否可以被当前登录的用户访问以及货币是否为 currency
+// 系统账户只能通过日记账分录记账，不能直接存款或者取款
+func (server *Server) cashAccount(ctx context.Context, authPayload *token.Payload, accountID int64, currency string) (db.Account, error) {
+	account, err := server.authorizedAccount(ctx, authPayload, accountID, util.BankerRole)
+	if err != nil {
+		return account, err
+	}
+	if err := requireCustomerAccount(account); err != nil {
+		return account, err
+	}
+	if account.Currency != currency {
+		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+		return account, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount.currency", err)})
+	}
+	return account, nil
+}
+
+// cashError 将存款和取款时产生的错误转换为 gRPC 的状态码
+func cashError(err error) error {
+	var limitErr *db.TransferLimitError
+	switch {
+	// 违反限额规则时与交易相同，返回违反的所有规则
+	case errors.As(err, &limitErr):
+		return transferLimitError(limitErr)
+	// 账户或者存款不存在
+	case errors.Is(err, pgx.ErrNoRows):
+		return status.Errorf(codes.NotFound, "%s", err)
+	// 存款已经处理过，账户不是正常状态或者余额不足
+	case errors.Is(err, db.ErrDepositNotPending),
+		errors.Is(err, db.ErrAccountNotActive),
+		errors.Is(err, db.ErrInsufficientFunds):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	}
+	// 外部编号已经被使用
+	if pqErr, ok := err.(*pgconn.PgError); ok && pqErr.Code == "23505" {
+		return status.Errorf(codes.AlreadyExists, "external reference already used: %s", err)
+	}
+	return status.Errorf(codes.Internal, "failed to process cash transaction: %s", err)
+}
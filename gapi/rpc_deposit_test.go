@@ -0,0 +1,394 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateDepositRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = util.USD
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateDepositRequest
+		buildStubs    func(store *mockdb.MockStore)
+		buildContext  func(t *testing.T, tokenMaker token.Maker) context.Context
+		checkResponse func(t *testing.T, rsp *pb.CreateDepositResponse, err error)
+	}{
+		{
+			// 客户向自己的账户存款，存款等待入账
+			name: "OK",
+			req: &pb.CreateDepositRequest{
+				AccountId:         account.ID,
+				Amount:            &pb.Money{Amount: "1.00", Currency: util.USD},
+				ExternalReference: "ref-1",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				arg := db.DepositTxParams{
+					AccountID:         account.ID,
+					Amount:            100,
+					ExternalReference: "ref-1",
+					CreatedBy:         user.Username,
+				}
+				result := db.DepositTxResult{
+					Deposit: db.Deposit{ID: 1, AccountID: account.ID, Amount: 100, ExternalReference: "ref-1", Status: db.DepositStatusPending},
+					Account: account,
+				}
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateDepositResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.DepositStatusPending, rsp.GetResult().GetDeposit().GetStatus())
+				require.Equal(t, "1.00", rsp.GetResult().GetDeposit().GetAmount().GetAmount())
+				require.Nil(t, rsp.GetResult().GetEntry())
+			},
+		},
+		{
+			// 客户不能确认资金已经到账
+			name: "SettledByDepositor",
+			req: &pb.CreateDepositRequest{
+				AccountId:         account.ID,
+				Amount:            &pb.Money{Amount: "1.00", Currency: util.USD},
+				ExternalReference: "ref-1",
+				Settled:           true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateDepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			req: &pb.CreateDepositRequest{
+				AccountId:         account.ID,
+				Amount:            &pb.Money{Amount: "1.00", Currency: util.EUR},
+				ExternalReference: "ref-1",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateDepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			// 外部编号已经被另一笔存款使用
+			name: "DuplicateExternalReference",
+			req: &pb.CreateDepositRequest{
+				AccountId:         account.ID,
+				Amount:            &pb.Money{Amount: "1.00", Currency: util.USD},
+				ExternalReference: "ref-1",
+				Settled:           true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(1).Return(db.DepositTxResult{}, &pgconn.PgError{Code: "23505"})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateDepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.AlreadyExists, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidArguments",
+			req: &pb.CreateDepositRequest{
+				AccountId: 0,
+				Amount:    &pb.Money{Amount: "-1.00", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateDepositResponse, err error) {
+				st := status.Convert(err)
+				require.Equal(t, codes.InvalidArgument, st.Code())
+				require.Len(t, st.Details(), 1)
+
+				// 所有不合法的字段都在同一个错误中返回
+				badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+				require.True(t, ok)
+				require.Len(t, badRequest.GetFieldViolations(), 3)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			rsp, err := server.CreateDeposit(ctx, tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+func TestGetDepositRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	deposit := db.Deposit{ID: 1, AccountID: account.ID, Amount: 100, Status: db.DepositStatusPending}
+
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, rsp *pb.GetDepositResponse, err error)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDeposit(gomock.Any(), gomock.Eq(deposit.ID)).Times(1).Return(deposit, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.GetDepositResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, deposit.ID, rsp.GetDeposit().GetId())
+				require.Equal(t, account.Currency, rsp.GetDeposit().GetAmount().GetCurrency())
+			},
+		},
+		{
+			name:     "NotFound",
+			username: user.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDeposit(gomock.Any(), gomock.Eq(deposit.ID)).Times(1).Return(db.Deposit{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.GetDepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			// 客户不能查询其他客户账户的存款
+			name:     "PermissionDenied",
+			username: "unauthorized",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetDeposit(gomock.Any(), gomock.Eq(deposit.ID)).Times(1).Return(deposit, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.GetDepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, util.DepositorRole, time.Minute)
+			rsp, err := server.GetDeposit(ctx, &pb.GetDepositRequest{Id: deposit.ID})
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+func TestSettleDepositRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+
+	testCases := []struct {
+		name          string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, rsp *pb.SettleDepositResponse, err error)
+	}{
+		{
+			name: "OK",
+			role: util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ResolveDepositTxParams{DepositID: 1, Status: db.DepositStatusSettled}
+				result := db.DepositTxResult{
+					Deposit: db.Deposit{ID: 1, AccountID: account.ID, Amount: 100, Status: db.DepositStatusSettled},
+					Account: account,
+					Entry:   &db.Entry{ID: 1, AccountID: account.ID, Amount: 100},
+				}
+				store.EXPECT().ResolveDepositTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.SettleDepositResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.DepositStatusSettled, rsp.GetResult().GetDeposit().GetStatus())
+				require.NotNil(t, rsp.GetResult().GetEntry())
+			},
+		},
+		{
+			// 只有银行职员可以确认存款到账
+			name: "Depositor",
+			role: util.DepositorRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResolveDepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.SettleDepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			// 存款已经入账或者失败
+			name: "NotPending",
+			role: util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResolveDepositTx(gomock.Any(), gomock.Any()).Times(1).Return(db.DepositTxResult{}, db.ErrDepositNotPending)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.SettleDepositResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, "banker", tc.role, time.Minute)
+			rsp, err := server.SettleDeposit(ctx, &pb.SettleDepositRequest{Id: 1})
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+func TestCreateWithdrawalRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = util.USD
+
+	testCases := []struct {
+		name          string
+		amount        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, rsp *pb.CreateWithdrawalResponse, err error)
+	}{
+		{
+			name:   "OK",
+			amount: "1.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				arg := db.WithdrawTxParams{
+					AccountID:         account.ID,
+					Amount:            100,
+					ExternalReference: "ref-1",
+					CreatedBy:         user.Username,
+				}
+				result := db.WithdrawTxResult{
+					Withdrawal: db.Withdrawal{ID: 1, AccountID: account.ID, Amount: 100, ExternalReference: "ref-1"},
+					Account:    account,
+					Entry:      db.Entry{ID: 1, AccountID: account.ID, Amount: -100},
+				}
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateWithdrawalResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "1.00", rsp.GetWithdrawal().GetAmount().GetAmount())
+				require.Equal(t, "-1.00", rsp.GetEntry().GetAmount().GetAmount())
+			},
+		},
+		{
+			// 邮箱未验证的客户不能取出超过限额的金额
+			name:   "EmailNotVerified",
+			amount: "10.01",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateWithdrawalResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:   "InsufficientFunds",
+			amount: "1.00",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(1).Return(db.WithdrawTxResult{}, db.ErrInsufficientFunds)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateWithdrawalResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			rsp, err := server.CreateWithdrawal(ctx, &pb.CreateWithdrawalRequest{
+				AccountId:         account.ID,
+				Amount:            &pb.Money{Amount: tc.amount, Currency: util.USD},
+				ExternalReference: "ref-1",
+			})
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
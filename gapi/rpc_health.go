@@ -0,0 +1,55 @@
+package gapi
+
+import (
+	"SimpleBank/pb"
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// 健康检查通过时返回的状态
+	healthStatusOK = "ok"
+	// 最近一次对账发现余额差异时错误详情的原因
+	healthReasonBalanceDrift = "BALANCE_DRIFT"
+)
+
+// CheckHealth 健康检查，不需要认证
+// 配置了 HEALTH_CHECK_FAIL_ON_DRIFT 时，最近一次对账发现余额差异则返回 Unavailable 错误，
+// 错误详情中包含这次对账的 ID 和存在差异的账户数
+func (server *Server) CheckHealth(ctx context.Context, req *pb.CheckHealthRequest) (*pb.CheckHealthResponse, error) {
+	rsp := &pb.CheckHealthResponse{Status: healthStatusOK}
+	if !server.config.HealthCheckFailOnDrift {
+		return rsp, nil
+	}
+
+	result, err := server.store.LatestReconciliation(ctx)
+	if err != nil {
+		// 还没有进行过对账时不认为存在差异
+		if errors.Is(err, pgx.ErrNoRows) {
+			return rsp, nil
+		}
+		return nil, status.Errorf(codes.Unavailable, "failed to get reconciliation: %s", err)
+	}
+
+	if result.Run.DriftCount > 0 {
+		statusDrift := status.New(codes.Unavailable, "balance drift found by the latest reconciliation")
+		statusDetails, err := statusDrift.WithDetails(&errdetails.ErrorInfo{
+			Reason: healthReasonBalanceDrift,
+			Metadata: map[string]string{
+				"reconciliation_id": strconv.FormatInt(result.Run.ID, 10),
+				"drift_count":       strconv.FormatInt(result.Run.DriftCount, 10),
+			},
+		})
+		if err != nil {
+			return nil, statusDrift.Err()
+		}
+		return nil, statusDetails.Err()
+	}
+	return rsp, nil
+}
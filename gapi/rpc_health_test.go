@@ -0,0 +1,114 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckHealthRPC(t *testing.T) {
+	testCases := []struct {
+		name        string
+		failOnDrift bool
+		buildStubs  func(store *mockdb.MockStore)
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, rsp *pb.CheckHealthResponse, err error)
+	}{
+		{
+			// 没有开启余额差异检查时不查询对账结果
+			name:        "OK",
+			failOnDrift: false,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().LatestReconciliation(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CheckHealthResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, healthStatusOK, rsp.GetStatus())
+			},
+		},
+		{
+			// 还没有进行过对账时不认为存在差异
+			name:        "NoReconciliation",
+			failOnDrift: true,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().LatestReconciliation(gomock.Any()).Times(1).Return(db.ReconciliationResult{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CheckHealthResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, healthStatusOK, rsp.GetStatus())
+			},
+		},
+		{
+			name:        "BalanceDrift",
+			failOnDrift: true,
+			buildStubs: func(store *mockdb.MockStore) {
+				result := db.ReconciliationResult{Run: db.ReconciliationRun{ID: 5, DriftCount: 2}}
+				store.EXPECT().LatestReconciliation(gomock.Any()).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CheckHealthResponse, err error) {
+				st := status.Convert(err)
+				require.Equal(t, codes.Unavailable, st.Code())
+				require.Len(t, st.Details(), 1)
+
+				info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+				require.True(t, ok)
+				require.Equal(t, healthReasonBalanceDrift, info.GetReason())
+				require.Equal(t, "5", info.GetMetadata()["reconciliation_id"])
+				require.Equal(t, "2", info.GetMetadata()["drift_count"])
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.config.HealthCheckFailOnDrift = tc.failOnDrift
+
+			// 健康检查不需要认证
+			rsp, err := server.CheckHealth(context.Background(), &pb.CheckHealthRequest{})
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+func TestGatewayCheckHealthDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	result := db.ReconciliationResult{Run: db.ReconciliationRun{ID: 5, DriftCount: 2}}
+	store.EXPECT().LatestReconciliation(gomock.Any()).Times(1).Return(result, nil)
+
+	server := newTestServer(t, store)
+	server.config.HealthCheckFailOnDrift = true
+	handler, err := NewGatewayHandler(context.Background(), server)
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodGet, "/v1/health", nil)
+	require.NoError(t, err)
+
+	// gRPC 的 Unavailable 状态码被转换为 HTTP 的 503 状态码，负载均衡器可以据此摘除实例
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	require.Contains(t, recorder.Body.String(), healthReasonBalanceDrift)
+}
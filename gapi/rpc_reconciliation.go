@@ -0,0 +1,48 @@
+package gapi
+
+import (
+	"SimpleBank/pb"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetReconciliation 查询最近一次对账的结果，只允许银行职员访问
+func (server *Server) GetReconciliation(ctx context.Context, req *pb.GetReconciliationRequest) (*pb.GetReconciliationResponse, error) {
+	if _, err := server.authorizeUser(ctx, bankerOnly); err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.LatestReconciliation(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.NotFound, "no reconciliation has been run yet")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get reconciliation: %s", err)
+	}
+
+	rsp := &pb.GetReconciliationResponse{
+		Reconciliation: convertReconciliation(result),
+	}
+	return rsp, nil
+}
+
+// RunReconciliation 立即进行一次对账，只允许银行职员访问
+func (server *Server) RunReconciliation(ctx context.Context, req *pb.RunReconciliationRequest) (*pb.RunReconciliationResponse, error) {
+	if _, err := server.authorizeUser(ctx, bankerOnly); err != nil {
+		return nil, err
+	}
+
+	result, err := server.store.ReconcileBalancesTx(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reconcile balances: %s", err)
+	}
+
+	rsp := &pb.RunReconciliationResponse{
+		Reconciliation: convertReconciliation(result),
+	}
+	return rsp, nil
+}
@@ -0,0 +1,103 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetReconciliationRPC(t *testing.T) {
+	result := db.ReconciliationResult{
+		Run: db.ReconciliationRun{ID: 1, AccountsChecked: 10, DriftCount: 1},
+		Drifts: []db.ListBalanceDriftsRow{
+			{RunID: 1, AccountID: 3, Owner: "alice", Currency: util.USD, Balance: 1000, EntriesBalance: 900},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, rsp *pb.GetReconciliationResponse, err error)
+	}{
+		{
+			name: "OK",
+			role: util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().LatestReconciliation(gomock.Any()).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.GetReconciliationResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, int64(1), rsp.GetReconciliation().GetDriftCount())
+				require.Len(t, rsp.GetReconciliation().GetDrifts(), 1)
+				require.Equal(t, "1.00", rsp.GetReconciliation().GetDrifts()[0].GetDifference().GetAmount())
+			},
+		},
+		{
+			// 还没有进行过对账
+			name: "NotFound",
+			role: util.BankerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().LatestReconciliation(gomock.Any()).Times(1).Return(db.ReconciliationResult{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.GetReconciliationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			// 只有银行职员可以查询对账结果
+			name: "Depositor",
+			role: util.DepositorRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().LatestReconciliation(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.GetReconciliationResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, "banker", tc.role, time.Minute)
+			rsp, err := server.GetReconciliation(ctx, &pb.GetReconciliationRequest{})
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+func TestRunReconciliationRPC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	result := db.ReconciliationResult{Run: db.ReconciliationRun{ID: 2, AccountsChecked: 10}}
+	store.EXPECT().ReconcileBalancesTx(gomock.Any()).Times(1).Return(result, nil)
+
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, "banker", util.BankerRole, time.Minute)
+	rsp, err := server.RunReconciliation(ctx, &pb.RunReconciliationRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), rsp.GetReconciliation().GetId())
+	require.Empty(t, rsp.GetReconciliation().GetDrifts())
+}
@@ -0,0 +1,377 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateScheduledTransfer 创建一个计划交易，只有转出账户的所有者才能创建
+func (server *Server) CreateScheduledTransfer(ctx context.Context, req *pb.CreateScheduledTransferRequest) (*pb.CreateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, depositorOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, nextRunAt, violations := validateCreateScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	fromAccount, err := server.authorizedAccount(ctx, authPayload, req.GetFromAccountId())
+	if err != nil {
+		return nil, err
+	}
+	// 计划交易在执行时无法获取汇率报价，因此两个账户的货币类型都必须与金额的货币相同
+	if err := requireAccountCurrency(fromAccount, amount.Currency); err != nil {
+		return nil, err
+	}
+
+	// 邮箱未验证的用户不能创建每次转出超过限额的计划交易
+	if err := server.requireVerifiedEmail(ctx, authPayload.Username, amount.Amount); err != nil {
+		return nil, err
+	}
+
+	toAccount, err := server.findAccount(ctx, req.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireAccountCurrency(toAccount, amount.Currency); err != nil {
+		return nil, err
+	}
+
+	// 系统账户不能作为计划交易的转出或者转入账户
+	for _, account := range []db.Account{fromAccount, toAccount} {
+		if err := requireCustomerAccount(account); err != nil {
+			return nil, err
+		}
+	}
+
+	scheduledTransfer, err := server.store.CreateScheduledTransfer(ctx, db.CreateScheduledTransferParams{
+		Owner:         authPayload.Username,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount.Amount,
+		Currency:      amount.Currency,
+		Schedule:      req.GetSchedule(),
+		NextRunAt:     nextRunAt,
+		EndAt:         optionalTime(req.GetEndAt()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create scheduled transfer: %s", err)
+	}
+
+	rsp := &pb.CreateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+	return rsp, nil
+}
+
+// validateCreateScheduledTransferRequest 检验创建计划交易请求中的所有字段，
+// 返回以最小货币单位表示的金额、第一次执行的时间和所有不合法的字段
+func validateCreateScheduledTransferRequest(req *pb.CreateScheduledTransferRequest) (amount util.Money, nextRunAt time.Time, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	if err := validateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	} else if req.GetToAccountId() == req.GetFromAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", errors.New("must be different from from_account_id")))
+	}
+	amount, err := validateMoney(req.GetAmount())
+	if err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	if err := validateOptionalTimestamp(req.GetStartAt()); err != nil {
+		violations = append(violations, fieldViolation("start_at", err))
+	}
+	if err := validateOptionalTimestamp(req.GetEndAt()); err != nil {
+		violations = append(violations, fieldViolation("end_at", err))
+	}
+
+	schedule, err := util.ParseSchedule(req.GetSchedule())
+	if err != nil {
+		violations = append(violations, fieldViolation("schedule", err))
+		return
+	}
+
+	// 计算第一次执行的时间，结束时间不能早于第一次执行的时间
+	nextRunAt = schedule.Next(time.Now())
+	if req.GetStartAt() != nil {
+		nextRunAt = req.GetStartAt().AsTime()
+	}
+	if req.GetEndAt() != nil && req.GetEndAt().AsTime().Before(nextRunAt) {
+		violations = append(violations, fieldViolation("end_at", errors.New("must not be before the first run")))
+	}
+	return
+}
+
+// requireAccountCurrency 检验账户的货币类型是否为 currency
+func requireAccountCurrency(account db.Account, currency string) error {
+	if account.Currency != currency {
+		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", account.ID, account.Currency, currency)
+		return invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount.currency", err)})
+	}
+	return nil
+}
+
+// GetScheduledTransfer 查询当前登录的用户的一个计划交易
+func (server *Server) GetScheduledTransfer(ctx context.Context, req *pb.GetScheduledTransferRequest) (*pb.GetScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, depositorOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateID(req.GetId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	scheduledTransfer, err := server.authorizedScheduledTransfer(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &pb.GetScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+	return rsp, nil
+}
+
+// ListScheduledTransfers 分页查询当前登录的用户的计划交易
+func (server *Server) ListScheduledTransfers(ctx context.Context, req *pb.ListScheduledTransfersRequest) (*pb.ListScheduledTransfersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, depositorOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	if violations := validatePageRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	afterID, offset, err := pagePosition(req)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledTransfers, err := server.store.ListScheduledTransfers(ctx, db.ListScheduledTransfersParams{
+		Owner:   authPayload.Username,
+		AfterID: afterID,
+		Limit:   req.GetPageSize(),
+		Offset:  offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list scheduled transfers: %s", err)
+	}
+
+	rsp := &pb.ListScheduledTransfersResponse{}
+	for _, scheduledTransfer := range scheduledTransfers {
+		rsp.ScheduledTransfers = append(rsp.ScheduledTransfers, convertScheduledTransfer(scheduledTransfer))
+	}
+	// 游标分页时，当前页已满说明可能还有更多的计划交易，返回下一页的游标
+	if len(scheduledTransfers) > 0 {
+		last := scheduledTransfers[len(scheduledTransfers)-1]
+		rsp.NextCursor = nextPageCursor(req, len(scheduledTransfers), last.ID, last.CreatedAt)
+	}
+	return rsp, nil
+}
+
+// UpdateScheduledTransfer 修改计划交易的金额、执行计划、结束时间，或者暂停和恢复计划交易
+func (server *Server) UpdateScheduledTransfer(ctx context.Context, req *pb.UpdateScheduledTransferRequest) (*pb.UpdateScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, depositorOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, violations := validateUpdateScheduledTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	scheduledTransfer, err := server.authorizedScheduledTransfer(ctx, authPayload, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.UpdateScheduledTransferParams{
+		ID:    scheduledTransfer.ID,
+		EndAt: optionalTime(req.GetEndAt()),
+	}
+	if req.GetAmount() != nil {
+		if amount.Currency != scheduledTransfer.Currency {
+			err := fmt.Errorf("scheduled transfer [%d] currency mismatch: %s vs %s", scheduledTransfer.ID, scheduledTransfer.Currency, amount.Currency)
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount.currency", err)})
+		}
+		if err := server.requireVerifiedEmail(ctx, scheduledTransfer.Owner, amount.Amount); err != nil {
+			return nil, err
+		}
+		arg.Amount = &amount.Amount
+	}
+	if req.Status != nil {
+		arg.Status = sql.NullString{String: req.GetStatus(), Valid: true}
+	}
+
+	// 修改执行计划或者恢复暂停的计划交易时，从当前时间开始重新计算下一次执行的时间，暂停期间错过的执行不会补执行
+	spec := scheduledTransfer.Schedule
+	if req.Schedule != nil {
+		spec = req.GetSchedule()
+		arg.Schedule = sql.NullString{String: spec, Valid: true}
+	}
+	resumed := req.GetStatus() == db.ScheduledTransferStatusActive &&
+		scheduledTransfer.Status == db.ScheduledTransferStatusPaused
+	if req.Schedule != nil || resumed {
+		// 新的执行计划已经在请求检验时解析过，这里只会解析已经保存的执行计划
+		schedule, err := util.ParseSchedule(spec)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to parse schedule: %s", err)
+		}
+		nextRunAt := schedule.Next(time.Now())
+		arg.NextRunAt = &nextRunAt
+	}
+
+	scheduledTransfer, err = server.store.UpdateScheduledTransfer(ctx, arg)
+	if err != nil {
+		return nil, scheduledTransferUpdateError(req.GetId(), err)
+	}
+
+	rsp := &pb.UpdateScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+	return rsp, nil
+}
+
+// validateUpdateScheduledTransferRequest 检验修改计划交易请求中传入了值的字段，返回以最小货币单位表示的金额和所有不合法的字段
+func validateUpdateScheduledTransferRequest(req *pb.UpdateScheduledTransferRequest) (amount util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if req.GetAmount() != nil {
+		var err error
+		if amount, err = validateMoney(req.GetAmount()); err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+	}
+	if req.Schedule != nil {
+		if _, err := util.ParseSchedule(req.GetSchedule()); err != nil {
+			violations = append(violations, fieldViolation("schedule", err))
+		}
+	}
+	if err := validateOptionalTimestamp(req.GetEndAt()); err != nil {
+		violations = append(violations, fieldViolation("end_at", err))
+	}
+	// 取消计划交易需要使用 CancelScheduledTransfer
+	if req.Status != nil && req.GetStatus() != db.ScheduledTransferStatusActive && req.GetStatus() != db.ScheduledTransferStatusPaused {
+		err := fmt.Errorf("must be %s or %s", db.ScheduledTransferStatusActive, db.ScheduledTransferStatusPaused)
+		violations = append(violations, fieldViolation("status", err))
+	}
+	return amount, violations
+}
+
+// CancelScheduledTransfer 取消计划交易，取消之后计划交易不会再执行，但是仍然保留执行记录
+func (server *Server) CancelScheduledTransfer(ctx context.Context, req *pb.CancelScheduledTransferRequest) (*pb.CancelScheduledTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, depositorOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateID(req.GetId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	if _, err := server.authorizedScheduledTransfer(ctx, authPayload, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	scheduledTransfer, err := server.store.UpdateScheduledTransfer(ctx, db.UpdateScheduledTransferParams{
+		ID:     req.GetId(),
+		Status: sql.NullString{String: db.ScheduledTransferStatusCancelled, Valid: true},
+	})
+	if err != nil {
+		return nil, scheduledTransferUpdateError(req.GetId(), err)
+	}
+
+	rsp := &pb.CancelScheduledTransferResponse{
+		ScheduledTransfer: convertScheduledTransfer(scheduledTransfer),
+	}
+	return rsp, nil
+}
+
+// scheduledTransferUpdateError 将修改计划交易时产生的错误转换为 gRPC 的状态码
+// 计划交易已经存在，修改不到记录说明它已经完成或者被取消
+func scheduledTransferUpdateError(id int64, err error) error {
+	if err == pgx.ErrNoRows {
+		return status.Errorf(codes.FailedPrecondition, "scheduled transfer [%d] is already completed or cancelled", id)
+	}
+	return status.Errorf(codes.Internal, "failed to update scheduled transfer: %s", err)
+}
+
+// ListScheduledTransferRuns 分页查询计划交易每次执行的结果以及失败原因
+func (server *Server) ListScheduledTransferRuns(ctx context.Context, req *pb.ListScheduledTransferRunsRequest) (*pb.ListScheduledTransferRunsResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, depositorOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validatePageRequest(req)
+	if err := validateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	afterID, offset, err := pagePosition(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := server.authorizedScheduledTransfer(ctx, authPayload, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	runs, err := server.store.ListScheduledTransferRuns(ctx, db.ListScheduledTransferRunsParams{
+		ScheduledTransferID: req.GetId(),
+		AfterID:             afterID,
+		Limit:               req.GetPageSize(),
+		Offset:              offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list scheduled transfer runs: %s", err)
+	}
+
+	rsp := &pb.ListScheduledTransferRunsResponse{}
+	for _, run := range runs {
+		rsp.Runs = append(rsp.Runs, convertScheduledTransferRun(run))
+	}
+	// 游标分页时，当前页已满说明可能还有更多的执行记录，返回下一页的游标
+	if len(runs) > 0 {
+		last := runs[len(runs)-1]
+		rsp.NextCursor = nextPageCursor(req, len(runs), last.ID, last.CreatedAt)
+	}
+	return rsp, nil
+}
+
+// authorizedScheduledTransfer 查询指定 ID 的计划交易，并检查计划交易是否属于当前登录的用户
+func (server *Server) authorizedScheduledTransfer(ctx context.Context, authPayload *token.Payload, id int64) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := server.store.GetScheduledTransfer(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return scheduledTransfer, status.Errorf(codes.NotFound, "scheduled transfer [%d] not found", id)
+		}
+		return scheduledTransfer, status.Errorf(codes.Internal, "failed to get scheduled transfer: %s", err)
+	}
+	if scheduledTransfer.Owner != authPayload.Username {
+		return scheduledTransfer, status.Errorf(codes.PermissionDenied, "scheduled transfer [%d] doesn't belong to the authenticated user", id)
+	}
+	return scheduledTransfer, nil
+}
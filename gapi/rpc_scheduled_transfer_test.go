@@ -0,0 +1,347 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// randomScheduledTransfer 生成一个属于 owner 的随机计划交易
+func randomScheduledTransfer(owner string, fromAccountID, toAccountID int64) db.ScheduledTransfer {
+	return db.ScheduledTransfer{
+		ID:            util.RandomInt(1, 1000),
+		Owner:         owner,
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        100,
+		Currency:      util.USD,
+		Schedule:      "@daily",
+		NextRunAt:     time.Now().Add(time.Hour),
+		Status:        db.ScheduledTransferStatusActive,
+	}
+}
+
+func TestCreateScheduledTransferRPC(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+
+	startAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	testCases := []struct {
+		name string
+		req  *pb.CreateScheduledTransferRequest
+		// 构建 stubs 的方式
+		buildStubs func(store *mockdb.MockStore)
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, rsp *pb.CreateScheduledTransferResponse, err error)
+	}{
+		{
+			// 指定了开始时间时第一次执行的时间即为开始时间
+			name: "OK",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "1.00", Currency: util.USD},
+				Schedule:      "@daily",
+				StartAt:       timestamppb.New(startAt),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+
+				arg := db.CreateScheduledTransferParams{
+					Owner:         user1.Username,
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        100,
+					Currency:      util.USD,
+					Schedule:      "@daily",
+					NextRunAt:     startAt.UTC(),
+				}
+				scheduledTransfer := randomScheduledTransfer(user1.Username, account1.ID, account2.ID)
+				scheduledTransfer.NextRunAt = startAt
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(scheduledTransfer, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "1.00", rsp.GetScheduledTransfer().GetAmount().GetAmount())
+				require.True(t, startAt.Equal(rsp.GetScheduledTransfer().GetNextRunAt().AsTime()))
+			},
+		},
+		{
+			// 计划交易不能跨币种执行
+			name: "CurrencyMismatch",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "1.00", Currency: util.EUR},
+				Schedule:      "@daily",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateScheduledTransferResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidSchedule",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "1.00", Currency: util.USD},
+				Schedule:      "every day",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateScheduledTransferResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			// 结束时间不能早于第一次执行的时间
+			name: "EndBeforeStart",
+			req: &pb.CreateScheduledTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "1.00", Currency: util.USD},
+				Schedule:      "@daily",
+				StartAt:       timestamppb.New(startAt),
+				EndAt:         timestamppb.New(startAt.Add(-time.Hour)),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateScheduledTransferResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user1.Username, util.DepositorRole, time.Minute)
+			rsp, err := server.CreateScheduledTransfer(ctx, tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+func TestUpdateScheduledTransferRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	scheduledTransfer := randomScheduledTransfer(user.Username, 1, 2)
+	paused := scheduledTransfer
+	paused.Status = db.ScheduledTransferStatusPaused
+	statusActive := db.ScheduledTransferStatusActive
+	statusCancelled := db.ScheduledTransferStatusCancelled
+
+	testCases := []struct {
+		name     string
+		username string
+		req      *pb.UpdateScheduledTransferRequest
+		// 构建 stubs 的方式
+		buildStubs func(store *mockdb.MockStore)
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, rsp *pb.UpdateScheduledTransferResponse, err error)
+	}{
+		{
+			name:     "UpdateAmount",
+			username: user.Username,
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Amount: &pb.Money{Amount: "2.00", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(scheduledTransfer, nil)
+
+				amount := int64(200)
+				arg := db.UpdateScheduledTransferParams{ID: scheduledTransfer.ID, Amount: &amount}
+				updated := scheduledTransfer
+				updated.Amount = amount
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "2.00", rsp.GetScheduledTransfer().GetAmount().GetAmount())
+			},
+		},
+		{
+			// 恢复暂停的计划交易时从当前时间开始重新计算下一次执行的时间
+			name:     "Resume",
+			username: user.Username,
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Status: &statusActive,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(paused, nil)
+				store.EXPECT().
+					UpdateScheduledTransfer(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+						require.Equal(t, sql.NullString{String: statusActive, Valid: true}, arg.Status)
+						require.NotNil(t, arg.NextRunAt)
+						require.True(t, arg.NextRunAt.After(time.Now()))
+						return scheduledTransfer, nil
+					})
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateScheduledTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, db.ScheduledTransferStatusActive, rsp.GetScheduledTransfer().GetStatus())
+			},
+		},
+		{
+			// 取消计划交易需要使用 CancelScheduledTransfer
+			name:     "InvalidStatus",
+			username: user.Username,
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Status: &statusCancelled,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateScheduledTransferResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name:     "PermissionDenied",
+			username: "unauthorized",
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Status: &statusActive,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(scheduledTransfer, nil)
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateScheduledTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			// 计划交易在查询之后已经完成或者被取消
+			name:     "AlreadyCompleted",
+			username: user.Username,
+			req: &pb.UpdateScheduledTransferRequest{
+				Id:     scheduledTransfer.ID,
+				Amount: &pb.Money{Amount: "2.00", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(scheduledTransfer, nil)
+				store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(1).Return(db.ScheduledTransfer{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateScheduledTransferResponse, err error) {
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, util.DepositorRole, time.Minute)
+			rsp, err := server.UpdateScheduledTransfer(ctx, tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+func TestCancelScheduledTransferRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	scheduledTransfer := randomScheduledTransfer(user.Username, 1, 2)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(scheduledTransfer, nil)
+
+	cancelled := scheduledTransfer
+	cancelled.Status = db.ScheduledTransferStatusCancelled
+	arg := db.UpdateScheduledTransferParams{
+		ID:     scheduledTransfer.ID,
+		Status: sql.NullString{String: db.ScheduledTransferStatusCancelled, Valid: true},
+	}
+	store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Eq(arg)).Times(1).Return(cancelled, nil)
+
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, util.DepositorRole, time.Minute)
+	rsp, err := server.CancelScheduledTransfer(ctx, &pb.CancelScheduledTransferRequest{Id: scheduledTransfer.ID})
+	require.NoError(t, err)
+	require.Equal(t, db.ScheduledTransferStatusCancelled, rsp.GetScheduledTransfer().GetStatus())
+}
+
+func TestListScheduledTransferRunsRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	scheduledTransfer := randomScheduledTransfer(user.Username, 1, 2)
+
+	transferID := int64(9)
+	runs := []db.ScheduledTransferRun{
+		{ID: 1, ScheduledTransferID: scheduledTransfer.ID, TransferID: &transferID, Status: db.ScheduledTransferRunSucceeded},
+		{ID: 2, ScheduledTransferID: scheduledTransfer.ID, Status: db.ScheduledTransferRunFailed, FailureReason: "insufficient funds"},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetScheduledTransfer(gomock.Any(), gomock.Eq(scheduledTransfer.ID)).Times(1).Return(scheduledTransfer, nil)
+
+	arg := db.ListScheduledTransferRunsParams{
+		ScheduledTransferID: scheduledTransfer.ID,
+		Limit:               5,
+		Offset:              5,
+	}
+	store.EXPECT().ListScheduledTransferRuns(gomock.Any(), gomock.Eq(arg)).Times(1).Return(runs, nil)
+
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, util.DepositorRole, time.Minute)
+	rsp, err := server.ListScheduledTransferRuns(ctx, &pb.ListScheduledTransferRunsRequest{
+		Id:       scheduledTransfer.ID,
+		PageId:   2,
+		PageSize: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, rsp.GetRuns(), 2)
+	require.Equal(t, transferID, rsp.GetRuns()[0].GetTransferId())
+	require.Equal(t, "insufficient funds", rsp.GetRuns()[1].GetFailureReason())
+	require.Empty(t, rsp.GetNextCursor())
+}
@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BlockSession 封禁会话，会话被封禁之后刷新令牌立即失效
+// 用户可以封禁自己的会话，银行职员可以封禁任意用户的会话
+func (server *Server) BlockSession(ctx context.Context, req *pb.BlockSessionRequest) (*pb.BlockSessionResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, anyRole)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	session, err := server.store.GetSession(ctx, sessionID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find session: %s", err)
+	}
+
+	if session.Username != authPayload.Username && authPayload.Role != util.BankerRole {
+		return nil, status.Errorf(codes.PermissionDenied, "session doesn't belong to the authenticated user")
+	}
+
+	session, err = server.store.BlockSession(ctx, sessionID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to block session: %s", err)
+	}
+
+	rsp := &pb.BlockSessionResponse{
+		Session: convertSession(session),
+	}
+	return rsp, nil
+}
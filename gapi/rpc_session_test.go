@@ -0,0 +1,125 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBlockSessionRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	session := db.Session{
+		ID:        uuid.New(),
+		Username:  user.Username,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	blocked := session
+	blocked.IsBlocked = true
+
+	testCases := []struct {
+		name     string
+		username string
+		role     string
+		id       string
+		// 构建 stubs 的方式
+		buildStubs func(store *mockdb.MockStore)
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, rsp *pb.BlockSessionResponse, err error)
+	}{
+		{
+			// 用户封禁自己的会话
+			name:     "OK",
+			username: user.Username,
+			role:     util.DepositorRole,
+			id:       session.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(blocked, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.BlockSessionResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, rsp.GetSession().GetIsBlocked())
+				require.Equal(t, session.ID.String(), rsp.GetSession().GetId())
+			},
+		},
+		{
+			// 银行职员可以封禁任意用户的会话
+			name:     "Banker",
+			username: "banker",
+			role:     util.BankerRole,
+			id:       session.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(blocked, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.BlockSessionResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			// 客户不能封禁其他用户的会话
+			name:     "OtherUser",
+			username: "other",
+			role:     util.DepositorRole,
+			id:       session.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().BlockSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.BlockSessionResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "NotFound",
+			username: user.Username,
+			role:     util.DepositorRole,
+			id:       session.ID.String(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(db.Session{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.BlockSessionResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			name:     "InvalidID",
+			username: user.Username,
+			role:     util.DepositorRole,
+			id:       "invalid",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.BlockSessionResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, tc.role, time.Minute)
+			rsp, err := server.BlockSession(ctx, &pb.BlockSessionRequest{Id: tc.id})
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
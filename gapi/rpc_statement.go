@@ -0,0 +1,250 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/statement"
+	"SimpleBank/util"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// 对账单默认的时间范围
+	defaultStatementPeriod = 30 * 24 * time.Hour
+	// 导出对账单时每次从数据库中读取的条目数
+	statementExportBatchSize = 1000
+	// 导出对账单时每个数据块的最大字节数
+	statementChunkSize = 32 * 1024
+	// 携带对账单文件名的元数据键，通过 HTTP 网关调用时转换为同名的响应头
+	contentDispositionHeader = "content-disposition"
+)
+
+// ListAccountEntries 按照时间范围分页查询账户的对账单，以及每条条目入账之后的余额，银行职员可以查询任意账户
+func (server *Server) ListAccountEntries(ctx context.Context, req *pb.ListAccountEntriesRequest) (*pb.ListAccountEntriesResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, anyRole)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := validatePageRequest(req)
+	if err := validateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	startTime, endTime, periodViolations := statementPeriod(req.GetStartTime(), req.GetEndTime())
+	violations = append(violations, periodViolations...)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	afterID, offset, err := pagePosition(req)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.authorizedAccount(ctx, authPayload, req.GetId(), util.BankerRole)
+	if err != nil {
+		return nil, err
+	}
+
+	// 分别计算时间范围开始和结束时的账户余额
+	openingBalance, closingBalance, err := server.statementBalances(ctx, account.ID, startTime, endTime)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get statement balances: %s", err)
+	}
+
+	entries, err := server.store.ListStatementEntries(ctx, db.ListStatementEntriesParams{
+		AccountID:  account.ID,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		AfterID:    afterID,
+		PageSize:   req.GetPageSize(),
+		PageOffset: offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list statement entries: %s", err)
+	}
+
+	rsp := &pb.ListAccountEntriesResponse{
+		AccountId:      account.ID,
+		Currency:       account.Currency,
+		StartTime:      timestamppb.New(startTime),
+		EndTime:        timestamppb.New(endTime),
+		OpeningBalance: convertMoney(openingBalance, account.Currency),
+		ClosingBalance: convertMoney(closingBalance, account.Currency),
+	}
+	for _, entry := range entries {
+		rsp.Entries = append(rsp.Entries, convertStatementEntry(entry, account.Currency))
+	}
+	// 游标分页时，当前页已满说明可能还有更多的条目，返回下一页的游标
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		rsp.NextCursor = nextPageCursor(req, len(entries), last.ID, last.CreatedAt)
+	}
+	return rsp, nil
+}
+
+// ExportStatement 按照指定格式导出账户的对账单文件，银行职员可以导出任意账户
+// 条目分批从数据库中读取，文件内容分块发送给客户端，不会在内存中保存所有的条目
+func (server *Server) ExportStatement(req *pb.ExportStatementRequest, stream pb.SimpleBank_ExportStatementServer) error {
+	ctx := stream.Context()
+	authPayload, err := server.authorizeUser(ctx, anyRole)
+	if err != nil {
+		return err
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := validateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if !statement.IsSupportedFormat(req.GetFormat()) {
+		violations = append(violations, fieldViolation("format", fmt.Errorf("unsupported format %q", req.GetFormat())))
+	}
+	startTime, endTime, periodViolations := statementPeriod(req.GetStartTime(), req.GetEndTime())
+	violations = append(violations, periodViolations...)
+	if violations != nil {
+		return invalidArgumentError(violations)
+	}
+
+	account, err := server.authorizedAccount(ctx, authPayload, req.GetId(), util.BankerRole)
+	if err != nil {
+		return err
+	}
+
+	openingBalance, closingBalance, err := server.statementBalances(ctx, account.ID, startTime, endTime)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get statement balances: %s", err)
+	}
+
+	format := statement.Format(req.GetFormat())
+	filename := fmt.Sprintf("statement-%d-%s-%s.%s",
+		account.ID, startTime.UTC().Format("20060102"), endTime.UTC().Format("20060102"), format)
+	err = stream.SetHeader(metadata.Pairs(contentDispositionHeader, fmt.Sprintf("attachment; filename=%q", filename)))
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to set header: %s", err)
+	}
+
+	// 编码器的输出先写入缓冲区，缓冲区满时作为一个数据块发送
+	w := bufio.NewWriterSize(&httpBodyWriter{stream: stream, contentType: format.ContentType()}, statementChunkSize)
+	enc, err := statement.NewEncoder(format, w)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create encoder: %s", err)
+	}
+
+	stmt := statement.Statement{
+		AccountID:      account.ID,
+		Owner:          account.Owner,
+		Currency:       account.Currency,
+		StartTime:      startTime,
+		EndTime:        endTime,
+		OpeningBalance: openingBalance,
+		ClosingBalance: closingBalance,
+	}
+	err = statement.Export(ctx, enc, stmt, server.statementLineSource(account.ID, startTime, endTime), statementExportBatchSize)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to export statement: %s", err)
+	}
+	return nil
+}
+
+// httpBodyWriter 将写入的数据作为一个 google.api.HttpBody 数据块发送给客户端
+type httpBodyWriter struct {
+	stream      pb.SimpleBank_ExportStatementServer
+	contentType string
+}
+
+func (w *httpBodyWriter) Write(p []byte) (int, error) {
+	// 发送之后 p 可能会被调用方复用，因此需要复制一份
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := w.stream.Send(&httpbody.HttpBody{ContentType: w.contentType, Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// statementPeriod 为没有指定的时间范围填充默认值（最近 30 天），并检验开始时间早于结束时间
+func statementPeriod(start, end *timestamppb.Timestamp) (startTime, endTime time.Time, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateOptionalTimestamp(start); err != nil {
+		violations = append(violations, fieldViolation("start_time", err))
+	}
+	if err := validateOptionalTimestamp(end); err != nil {
+		violations = append(violations, fieldViolation("end_time", err))
+	}
+	if violations != nil {
+		return
+	}
+
+	endTime = time.Now()
+	if end != nil {
+		endTime = end.AsTime()
+	}
+	startTime = endTime.Add(-defaultStatementPeriod)
+	if start != nil {
+		startTime = start.AsTime()
+	}
+	if !startTime.Before(endTime) {
+		violations = append(violations, fieldViolation("start_time", errors.New("must be before end_time")))
+	}
+	return
+}
+
+// statementBalances 计算账户在时间范围开始和结束时的余额
+func (server *Server) statementBalances(ctx context.Context, accountID int64, startTime, endTime time.Time) (opening int64, closing int64, err error) {
+	opening, err = server.store.GetAccountBalanceAt(ctx, db.GetAccountBalanceAtParams{
+		AccountID: accountID,
+		At:        startTime,
+	})
+	if err != nil {
+		return
+	}
+
+	closing, err = server.store.GetAccountBalanceAt(ctx, db.GetAccountBalanceAtParams{
+		AccountID: accountID,
+		At:        endTime,
+	})
+	return
+}
+
+// statementLineSource 返回一个从数据库中分批读取账户在时间范围内条目的 statement.LineSource
+// 条目入账之后的余额由 statement.Export 从期初余额开始累加，这里只读取条目本身
+func (server *Server) statementLineSource(accountID int64, startTime, endTime time.Time) statement.LineSource {
+	return func(ctx context.Context, afterID int64, limit int32) ([]statement.Line, error) {
+		entries, err := server.store.ListStatementExportEntries(ctx, db.ListStatementExportEntriesParams{
+			AccountID: accountID,
+			StartTime: startTime,
+			EndTime:   endTime,
+			AfterID:   afterID,
+			PageSize:  limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		lines := make([]statement.Line, len(entries))
+		for i, entry := range entries {
+			lines[i] = statement.Line{
+				EntryID:               entry.ID,
+				TransferID:            entry.TransferID,
+				ReversalID:            entry.ReversalID,
+				CounterpartyAccountID: entry.CounterpartyAccountID,
+				Amount:                entry.Amount,
+				CreatedAt:             entry.CreatedAt,
+			}
+		}
+		return lines, nil
+	}
+}
@@ -0,0 +1,132 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListAccountEntriesRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = util.USD
+
+	endTime := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	startTime := endTime.Add(-24 * time.Hour)
+	transferID := int64(7)
+	entries := []db.ListStatementEntriesRow{
+		{ID: 1, AccountID: account.ID, Amount: 150, CreatedAt: startTime.Add(time.Hour), RunningBalance: 1150},
+		{ID: 2, AccountID: account.ID, Amount: -50, CreatedAt: startTime.Add(2 * time.Hour), TransferID: &transferID, RunningBalance: 1100},
+	}
+
+	testCases := []struct {
+		name          string
+		username      string
+		req           *pb.ListAccountEntriesRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, rsp *pb.ListAccountEntriesResponse, err error)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			req: &pb.ListAccountEntriesRequest{
+				Id:        account.ID,
+				StartTime: timestamppb.New(startTime),
+				EndTime:   timestamppb.New(endTime),
+				PageId:    1,
+				PageSize:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					GetAccountBalanceAt(gomock.Any(), gomock.Eq(db.GetAccountBalanceAtParams{AccountID: account.ID, At: startTime})).
+					Times(1).
+					Return(int64(1000), nil)
+				store.EXPECT().
+					GetAccountBalanceAt(gomock.Any(), gomock.Eq(db.GetAccountBalanceAtParams{AccountID: account.ID, At: endTime})).
+					Times(1).
+					Return(int64(1100), nil)
+
+				arg := db.ListStatementEntriesParams{
+					AccountID: account.ID,
+					StartTime: startTime,
+					EndTime:   endTime,
+					PageSize:  5,
+				}
+				store.EXPECT().ListStatementEntries(gomock.Any(), gomock.Eq(arg)).Times(1).Return(entries, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ListAccountEntriesResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "10.00", rsp.GetOpeningBalance().GetAmount())
+				require.Equal(t, "11.00", rsp.GetClosingBalance().GetAmount())
+				require.Len(t, rsp.GetEntries(), 2)
+				require.Equal(t, "11.50", rsp.GetEntries()[0].GetRunningBalance().GetAmount())
+				require.Equal(t, transferID, rsp.GetEntries()[1].GetTransferId())
+				// 页码分页时不返回游标
+				require.Empty(t, rsp.GetNextCursor())
+			},
+		},
+		{
+			// 开始时间必须早于结束时间
+			name:     "InvalidPeriod",
+			username: user.Username,
+			req: &pb.ListAccountEntriesRequest{
+				Id:        account.ID,
+				StartTime: timestamppb.New(endTime),
+				EndTime:   timestamppb.New(startTime),
+				PageId:    1,
+				PageSize:  5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ListAccountEntriesResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name:     "PermissionDenied",
+			username: "unauthorized",
+			req: &pb.ListAccountEntriesRequest{
+				Id:       account.ID,
+				PageId:   1,
+				PageSize: 5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListStatementEntries(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ListAccountEntriesResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, util.DepositorRole, time.Minute)
+			rsp, err := server.ListAccountEntries(ctx, tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package gapi
+
+import (
+	"SimpleBank/pb"
+	"SimpleBank/token"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RenewAccessToken 使用登录时签发的刷新令牌获取一个新的访问令牌
+func (server *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
+	if req.GetRefreshToken() == "" {
+		violation := fieldViolation("refresh_token", fmt.Errorf("must not be empty"))
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{violation})
+	}
+
+	refreshPayload, err := server.tokenMaker.VerifyToken(req.GetRefreshToken())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid refresh token: %s", err)
+	}
+	// 只有刷新令牌可以获取新的访问令牌
+	if refreshPayload.TokenType != token.TokenTypeRefresh {
+		return nil, status.Errorf(codes.Unauthenticated, "token is not a refresh token")
+	}
+
+	session, err := server.store.GetSession(ctx, refreshPayload.ID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "session not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get session: %s", err)
+	}
+
+	// 会话被封禁、不属于令牌中的用户、令牌与会话中保存的不一致或者会话已经过期
+	switch {
+	case session.IsBlocked:
+		return nil, status.Errorf(codes.Unauthenticated, "blocked session")
+	case session.Username != refreshPayload.Username:
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect session user")
+	case session.RefreshToken != req.GetRefreshToken():
+		return nil, status.Errorf(codes.Unauthenticated, "mismatched session token")
+	case time.Now().After(session.ExpiresAt):
+		return nil, status.Errorf(codes.Unauthenticated, "expired session")
+	}
+
+	// 使用用户当前的角色签发新的访问令牌，用户的角色被修改之后，下一次更新访问令牌时即可生效
+	user, err := server.store.GetUser(ctx, session.Username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find user: %s", err)
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeAccess, server.config.AccessTokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access token: %s", err)
+	}
+
+	rsp := &pb.RenewAccessTokenResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: timestamppb.New(accessPayload.ExpiredAt),
+	}
+	return rsp, nil
+}
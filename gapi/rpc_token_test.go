@@ -0,0 +1,146 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRenewAccessTokenRPC(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name string
+		// 为用户创建刷新令牌，并返回请求中使用的刷新令牌和数据库中保存的会话
+		setupSession func(t *testing.T, tokenMaker token.Maker) (string, db.Session)
+		// 构建 stubs 的方式
+		buildStubs func(store *mockdb.MockStore, session db.Session)
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, rsp *pb.RenewAccessTokenResponse, err error)
+	}{
+		{
+			name: "OK",
+			setupSession: func(t *testing.T, tokenMaker token.Maker) (string, db.Session) {
+				return newTestSession(t, tokenMaker, user.Username)
+			},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.RenewAccessTokenResponse, err error) {
+				require.NoError(t, err)
+				require.NotEmpty(t, rsp.GetAccessToken())
+			},
+		},
+		{
+			// 访问令牌不能用于获取新的访问令牌
+			name: "AccessToken",
+			setupSession: func(t *testing.T, tokenMaker token.Maker) (string, db.Session) {
+				accessToken, payload, err := tokenMaker.CreateToken(user.Username, util.DepositorRole, token.TokenTypeAccess, time.Hour)
+				require.NoError(t, err)
+				return accessToken, db.Session{ID: payload.ID, Username: user.Username, RefreshToken: accessToken, ExpiresAt: payload.ExpiredAt}
+			},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.RenewAccessTokenResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "GetUserError",
+			setupSession: func(t *testing.T, tokenMaker token.Maker) (string, db.Session) {
+				return newTestSession(t, tokenMaker, user.Username)
+			},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.RenewAccessTokenResponse, err error) {
+				require.Equal(t, codes.Internal, status.Code(err))
+			},
+		},
+		{
+			// 会话被封禁之后刷新令牌立即失效
+			name: "BlockedSession",
+			setupSession: func(t *testing.T, tokenMaker token.Maker) (string, db.Session) {
+				refreshToken, session := newTestSession(t, tokenMaker, user.Username)
+				session.IsBlocked = true
+				return refreshToken, session
+			},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Eq(session.ID)).Times(1).Return(session, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.RenewAccessTokenResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidToken",
+			setupSession: func(t *testing.T, tokenMaker token.Maker) (string, db.Session) {
+				return "invalid", db.Session{}
+			},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.RenewAccessTokenResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidArgument",
+			setupSession: func(t *testing.T, tokenMaker token.Maker) (string, db.Session) {
+				return "", db.Session{}
+			},
+			buildStubs: func(store *mockdb.MockStore, session db.Session) {
+				store.EXPECT().GetSession(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.RenewAccessTokenResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			refreshToken, session := tc.setupSession(t, server.tokenMaker)
+			tc.buildStubs(store, session)
+
+			rsp, err := server.RenewAccessToken(context.Background(), &pb.RenewAccessTokenRequest{RefreshToken: refreshToken})
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+// newTestSession 为用户创建一个刷新令牌，并返回与之对应的会话
+func newTestSession(t *testing.T, tokenMaker token.Maker, username string) (string, db.Session) {
+	refreshToken, payload, err := tokenMaker.CreateToken(username, util.DepositorRole, token.TokenTypeRefresh, time.Hour)
+	require.NoError(t, err)
+
+	session := db.Session{
+		ID:           payload.ID,
+		Username:     username,
+		RefreshToken: refreshToken,
+		ExpiresAt:    payload.ExpiredAt,
+	}
+	return refreshToken, session
+}
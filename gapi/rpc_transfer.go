@@ -0,0 +1,388 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/fx"
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 分页查询账户交易时交易相对于该账户的方向
+const (
+	transferDirectionIncoming = "incoming"
+	transferDirectionOutgoing = "outgoing"
+)
+
+// CreateTransfer 从当前登录的用户的账户向另一个账户转账
+func (server *Server) CreateTransfer(ctx context.Context, req *pb.CreateTransferRequest) (*pb.CreateTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, depositorOnly)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withAuditMetadata(ctx, authPayload.Username)
+
+	amount, violations := validateCreateTransferRequest(req)
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	idempotencyKey, err := getIdempotencyKey(ctx)
+	if err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation(idempotencyKeyHeader, err)})
+	}
+
+	// 只有转出账户的所有者才能发起交易，并且转出账户的货币类型必须与请求一致
+	fromAccount, err := server.authorizedAccount(ctx, authPayload, req.GetFromAccountId())
+	if err != nil {
+		return nil, err
+	}
+	if fromAccount.Currency != amount.Currency {
+		err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", fromAccount.ID, fromAccount.Currency, amount.Currency)
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount.currency", err)})
+	}
+
+	// 邮箱未验证的用户不能转出超过限额的金额
+	if err := server.requireVerifiedEmail(ctx, authPayload.Username, amount.Amount); err != nil {
+		return nil, err
+	}
+
+	// 转入账户可以是其他货币类型的账户，只检验其是否存在
+	toAccount, err := server.findAccount(ctx, req.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	// 系统账户只能通过日记账分录记账，不能作为交易的转出或者转入账户
+	for _, account := range []db.Account{fromAccount, toAccount} {
+		if err := requireCustomerAccount(account); err != nil {
+			return nil, err
+		}
+	}
+
+	arg := db.TransferTxParams{
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        amount.Amount,
+	}
+
+	// 两个账户的货币类型不同时，获取汇率报价并换算出转入账户货币的金额
+	if toAccount.Currency != fromAccount.Currency {
+		if err := server.applyExchangeQuote(ctx, &arg, fromAccount.Currency, toAccount.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	rsp := &pb.CreateTransferResponse{}
+	var result db.TransferTxResult
+	if idempotencyKey != "" {
+		var idempotentResult db.IdempotentTransferTxResult
+		idempotentResult, err = server.idempotentTransfer(ctx, authPayload.Username, idempotencyKey, amount.Currency, arg)
+		result = idempotentResult.TransferTxResult
+		rsp.Replayed = idempotentResult.Replayed
+	} else {
+		result, err = server.store.TransferTx(ctx, arg)
+	}
+	if err != nil {
+		var limitErr *db.TransferLimitError
+		switch {
+		case errors.As(err, &limitErr):
+			return nil, transferLimitError(limitErr)
+		case errors.Is(err, db.ErrInsufficientFunds), errors.Is(err, db.ErrAccountNotActive):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		case errors.Is(err, db.ErrIdempotencyKeyReused):
+			return nil, status.Errorf(codes.AlreadyExists, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create transfer: %s", err)
+	}
+
+	rsp.Result = convertTransferResult(result)
+	return rsp, nil
+}
+
+// validateCreateTransferRequest 检验交易请求中的所有字段，返回以最小货币单位表示的转出金额和所有不合法的字段
+func validateCreateTransferRequest(req *pb.CreateTransferRequest) (amount util.Money, violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateID(req.GetFromAccountId()); err != nil {
+		violations = append(violations, fieldViolation("from_account_id", err))
+	}
+	if err := validateID(req.GetToAccountId()); err != nil {
+		violations = append(violations, fieldViolation("to_account_id", err))
+	} else if req.GetToAccountId() == req.GetFromAccountId() {
+		violations = append(violations, fieldViolation("to_account_id", errors.New("must be different from from_account_id")))
+	}
+	amount, err := validateMoney(req.GetAmount())
+	if err != nil {
+		violations = append(violations, fieldViolation("amount", err))
+	}
+	return amount, violations
+}
+
+// applyExchangeQuote 获取从 from 货币到 to 货币的汇率报价，并将换算结果和报价信息写入交易参数
+func (server *Server) applyExchangeQuote(ctx context.Context, arg *db.TransferTxParams, from string, to string) error {
+	quote, err := server.exchangeRateProvider.GetQuote(ctx, from, to)
+	if err != nil {
+		if errors.Is(err, fx.ErrUnsupportedCurrencyPair) {
+			return status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return status.Errorf(codes.Unavailable, "failed to get exchange quote: %s", err)
+	}
+	if quote.Expired() {
+		return status.Errorf(codes.Unavailable, "exchange quote %s has expired", quote.ID)
+	}
+
+	toAmount, err := quote.Convert(arg.Amount)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to convert amount: %s", err)
+	}
+	if toAmount <= 0 {
+		err := fmt.Errorf("amount %d %s is too small to convert to %s", arg.Amount, from, to)
+		return invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount", err)})
+	}
+
+	arg.ToAmount = toAmount
+	arg.ExchangeRate = quote.Rate
+	arg.QuoteID = quote.ID
+	return nil
+}
+
+// idempotentTransfer 使用幂等键执行交易
+func (server *Server) idempotentTransfer(
+	ctx context.Context,
+	username string,
+	key string,
+	currency string,
+	arg db.TransferTxParams,
+) (db.IdempotentTransferTxResult, error) {
+	// 使用规范格式计算请求的散列值，同一个幂等键的重试请求必须与第一次请求完全相同
+	requestHash := db.TransferRequestHash(arg.FromAccountID, arg.ToAccountID, arg.Amount, currency)
+
+	return server.store.IdempotentTransferTx(ctx, db.IdempotentTransferTxParams{
+		TransferTxParams: arg,
+		Username:         username,
+		Key:              key,
+		RequestHash:      requestHash,
+		ExpiresAt:        time.Now().Add(server.config.IdempotencyKeyTTL),
+	})
+}
+
+// requireCustomerAccount 检验账户不是系统账户，系统账户只能通过日记账分录记账，
+// 不能作为交易、计划交易、存款或者取款的账户
+func requireCustomerAccount(account db.Account) error {
+	if account.Owner == db.SystemUsername {
+		return status.Errorf(codes.PermissionDenied, "account [%d] is a system account", account.ID)
+	}
+	return nil
+}
+
+// GetTransfer 查询一笔交易，只有交易任意一方账户的所有者才能查询，银行职员可以查询任意交易
+func (server *Server) GetTransfer(ctx context.Context, req *pb.GetTransferRequest) (*pb.GetTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, anyRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateID(req.GetId()); err != nil {
+		return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("id", err)})
+	}
+
+	transfer, err := server.findTransfer(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	// 查询交易双方的账户，交易的金额需要使用两个账户各自的货币表示
+	fromAccount, err := server.findAccount(ctx, transfer.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+	toAccount, err := server.findAccount(ctx, transfer.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromAccount.Owner != authPayload.Username && toAccount.Owner != authPayload.Username && authPayload.Role != util.BankerRole {
+		return nil, status.Errorf(codes.PermissionDenied, "transfer doesn't belong to the authenticated user")
+	}
+
+	rsp := &pb.GetTransferResponse{
+		Transfer: convertTransfer(transfer, fromAccount.Currency, toAccount.Currency),
+	}
+	return rsp, nil
+}
+
+// findTransfer 查询指定 ID 的交易，并将错误转换为 gRPC 的状态码
+func (server *Server) findTransfer(ctx context.Context, transferID int64) (db.Transfer, error) {
+	transfer, err := server.store.GetTransfer(ctx, transferID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return transfer, status.Errorf(codes.NotFound, "transfer [%d] not found", transferID)
+		}
+		return transfer, status.Errorf(codes.Internal, "failed to get transfer: %s", err)
+	}
+	return transfer, nil
+}
+
+// ListAccountTransfers 分页查询账户转出和转入的交易，银行职员可以查询任意账户
+func (server *Server) ListAccountTransfers(ctx context.Context, req *pb.ListAccountTransfersRequest) (*pb.ListAccountTransfersResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, anyRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if violations := validateListAccountTransfersRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	afterID, offset, err := pagePosition(req)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := server.authorizedAccount(ctx, authPayload, req.GetId(), util.BankerRole)
+	if err != nil {
+		return nil, err
+	}
+
+	// 金额范围按照该账户的货币解析，并检验范围是否有效
+	var violations []*errdetails.BadRequest_FieldViolation
+	minAmount, err := parseAmountFilter(req.MinAmount, account.Currency)
+	if err != nil {
+		violations = append(violations, fieldViolation("min_amount", err))
+	}
+	maxAmount, err := parseAmountFilter(req.MaxAmount, account.Currency)
+	if err != nil {
+		violations = append(violations, fieldViolation("max_amount", err))
+	}
+	if minAmount != nil && maxAmount != nil && *minAmount > *maxAmount {
+		violations = append(violations, fieldViolation("max_amount", errors.New("must not be less than min_amount")))
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// 没有指定的过滤条件以 NULL 传入，数据库查询时不进行过滤
+	arg := db.ListTransfersParams{
+		AccountID:             account.ID,
+		Direction:             sql.NullString{String: req.GetDirection(), Valid: req.GetDirection() != ""},
+		CounterpartyAccountID: req.CounterpartyAccountId,
+		MinAmount:             minAmount,
+		MaxAmount:             maxAmount,
+		StartTime:             optionalTime(req.GetStartTime()),
+		EndTime:               optionalTime(req.GetEndTime()),
+		AfterID:               afterID,
+		Limit:                 req.GetPageSize(),
+		Offset:                offset,
+	}
+
+	transfers, err := server.store.ListTransfers(ctx, arg)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transfers: %s", err)
+	}
+
+	rsp := &pb.ListAccountTransfersResponse{}
+	rsp.Transfers, err = server.convertTransfers(ctx, transfers)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list account currencies: %s", err)
+	}
+	// 游标分页时，当前页已满说明可能还有更多的交易，返回下一页的游标
+	if len(transfers) > 0 {
+		last := transfers[len(transfers)-1]
+		rsp.NextCursor = nextPageCursor(req, len(transfers), last.ID, last.CreatedAt)
+	}
+	return rsp, nil
+}
+
+// validateListAccountTransfersRequest 检验分页查询账户交易请求中除金额范围以外的所有字段，返回所有不合法的字段
+// 金额范围需要按照账户的货币解析，在查询到账户之后检验
+func validateListAccountTransfersRequest(req *pb.ListAccountTransfersRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	violations = validatePageRequest(req)
+	if err := validateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if direction := req.GetDirection(); direction != "" && direction != transferDirectionIncoming && direction != transferDirectionOutgoing {
+		violations = append(violations, fieldViolation("direction", fmt.Errorf("must be %s or %s", transferDirectionIncoming, transferDirectionOutgoing)))
+	}
+	if req.CounterpartyAccountId != nil {
+		if err := validateID(req.GetCounterpartyAccountId()); err != nil {
+			violations = append(violations, fieldViolation("counterparty_account_id", err))
+		}
+	}
+	if err := validateOptionalTimestamp(req.GetStartTime()); err != nil {
+		violations = append(violations, fieldViolation("start_time", err))
+	}
+	if err := validateOptionalTimestamp(req.GetEndTime()); err != nil {
+		violations = append(violations, fieldViolation("end_time", err))
+	}
+	if req.GetStartTime() != nil && req.GetEndTime() != nil && !req.GetStartTime().AsTime().Before(req.GetEndTime().AsTime()) {
+		violations = append(violations, fieldViolation("start_time", errors.New("must be before end_time")))
+	}
+	return violations
+}
+
+// parseAmountFilter 将过滤条件中的十进制金额解析为以最小货币单位表示的金额，没有指定时返回 nil
+func parseAmountFilter(value *string, currency string) (*int64, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	money, err := util.ParseMoney(*value, currency)
+	if err != nil {
+		return nil, err
+	}
+	if !money.IsPositive() {
+		return nil, errors.New("must be greater than 0")
+	}
+	return &money.Amount, nil
+}
+
+// optionalTime 将没有指定的时间转换为 nil ，数据库查询时以 NULL 传入
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// convertTransfers 查询交易双方账户的货币，并将多个交易转换为 pb.Transfer
+func (server *Server) convertTransfers(ctx context.Context, transfers []db.Transfer) ([]*pb.Transfer, error) {
+	if len(transfers) == 0 {
+		return nil, nil
+	}
+
+	// 同一页的交易只需要查询一次所有相关账户的货币
+	seen := make(map[int64]bool)
+	var ids []int64
+	for _, transfer := range transfers {
+		for _, id := range []int64{transfer.FromAccountID, transfer.ToAccountID} {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	rows, err := server.store.ListAccountCurrencies(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	currencies := make(map[int64]string, len(rows))
+	for _, row := range rows {
+		currencies[row.ID] = row.Currency
+	}
+
+	result := make([]*pb.Transfer, len(transfers))
+	for i, transfer := range transfers {
+		result[i] = convertTransfer(transfer, currencies[transfer.FromAccountID], currencies[transfer.ToAccountID])
+	}
+	return result, nil
+}
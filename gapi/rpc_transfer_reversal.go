@@ -0,0 +1,93 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxReversalReasonLength 是冲正原因的最大长度
+const maxReversalReasonLength = 255
+
+// ReverseTransfer 冲正交易，只允许银行职员访问
+// 从原交易的转入账户扣回金额并退还给转出账户，支持多次部分退款，退款的总金额不超过原交易的金额
+func (server *Server) ReverseTransfer(ctx context.Context, req *pb.ReverseTransferRequest) (*pb.ReverseTransferResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, bankerOnly)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withAuditMetadata(ctx, authPayload.Username)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if err := validateID(req.GetId()); err != nil {
+		violations = append(violations, fieldViolation("id", err))
+	}
+	if len(req.GetReason()) > maxReversalReasonLength {
+		violations = append(violations, fieldViolation("reason", fmt.Errorf("must be at most %d characters", maxReversalReasonLength)))
+	}
+	arg := db.ReverseTransferTxParams{
+		TransferID: req.GetId(),
+		Reason:     req.GetReason(),
+		ReversedBy: authPayload.Username,
+	}
+	// 没有指定退款金额时退还剩余的全部金额
+	if req.GetAmount() != nil {
+		amount, err := validateMoney(req.GetAmount())
+		if err != nil {
+			violations = append(violations, fieldViolation("amount", err))
+		}
+		arg.Amount = amount.Amount
+	}
+	if violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	// 指定了退款金额时，金额的货币必须与原交易转出账户的货币一致
+	if req.GetAmount() != nil {
+		transfer, err := server.findTransfer(ctx, req.GetId())
+		if err != nil {
+			return nil, err
+		}
+		fromAccount, err := server.findAccount(ctx, transfer.FromAccountID)
+		if err != nil {
+			return nil, err
+		}
+		if fromAccount.Currency != req.GetAmount().GetCurrency() {
+			err := fmt.Errorf("account [%d] currency mismatch: %s vs %s", fromAccount.ID, fromAccount.Currency, req.GetAmount().GetCurrency())
+			return nil, invalidArgumentError([]*errdetails.BadRequest_FieldViolation{fieldViolation("amount.currency", err)})
+		}
+	}
+
+	result, err := server.store.ReverseTransferTx(ctx, arg)
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, status.Errorf(codes.NotFound, "transfer [%d] not found", req.GetId())
+		// 交易已经全部退款，退款金额超过剩余的金额，转入账户余额不足或者任意一方账户已被关闭
+		case errors.Is(err, db.ErrTransferAlreadyReversed),
+			errors.Is(err, db.ErrReversalExceedsTransfer),
+			errors.Is(err, db.ErrInsufficientFunds),
+			errors.Is(err, db.ErrAccountNotActive):
+			return nil, status.Errorf(codes.FailedPrecondition, "%s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reverse transfer: %s", err)
+	}
+
+	fromCurrency, toCurrency := result.FromAccount.Currency, result.ToAccount.Currency
+	rsp := &pb.ReverseTransferResponse{
+		Transfer:    convertTransfer(result.Transfer, fromCurrency, toCurrency),
+		Reversal:    convertTransferReversal(result.Reversal, fromCurrency, toCurrency),
+		FromAccount: convertAccount(result.FromAccount),
+		ToAccount:   convertAccount(result.ToAccount),
+		FromEntry:   convertEntry(result.FromEntry, fromCurrency),
+		ToEntry:     convertEntry(result.ToEntry, toCurrency),
+	}
+	return rsp, nil
+}
@@ -0,0 +1,137 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReverseTransferRPC(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+
+	transfer := db.Transfer{ID: 1, FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 100, ToAmount: 100}
+
+	testCases := []struct {
+		name          string
+		role          string
+		req           *pb.ReverseTransferRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, rsp *pb.ReverseTransferResponse, err error)
+	}{
+		{
+			// 没有指定金额时退还剩余的全部金额
+			name: "OK",
+			role: util.BankerRole,
+			req:  &pb.ReverseTransferRequest{Id: transfer.ID, Reason: "duplicate"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReverseTransferTxParams{
+					TransferID: transfer.ID,
+					Reason:     "duplicate",
+					ReversedBy: "banker",
+				}
+				result := db.ReverseTransferTxResult{
+					Transfer:    transfer,
+					Reversal:    db.TransferReversal{ID: 1, TransferID: transfer.ID, Amount: 100, ToAmount: 100, Reason: "duplicate", ReversedBy: "banker"},
+					FromAccount: account1,
+					ToAccount:   account2,
+					FromEntry:   db.Entry{ID: 1, AccountID: account1.ID, Amount: 100},
+					ToEntry:     db.Entry{ID: 2, AccountID: account2.ID, Amount: -100},
+				}
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ReverseTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "1.00", rsp.GetReversal().GetAmount().GetAmount())
+				require.Equal(t, "-1.00", rsp.GetToEntry().GetAmount().GetAmount())
+			},
+		},
+		{
+			// 部分退款的金额必须使用原交易转出账户的货币
+			name: "CurrencyMismatch",
+			role: util.BankerRole,
+			req: &pb.ReverseTransferRequest{
+				Id:     transfer.ID,
+				Amount: &pb.Money{Amount: "0.50", Currency: util.EUR},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ReverseTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			// 只有银行职员可以冲正交易
+			name: "Depositor",
+			role: util.DepositorRole,
+			req:  &pb.ReverseTransferRequest{Id: transfer.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ReverseTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name: "NotFound",
+			role: util.BankerRole,
+			req:  &pb.ReverseTransferRequest{Id: transfer.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ReverseTransferTxResult{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ReverseTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			// 交易已经全部退款
+			name: "AlreadyReversed",
+			role: util.BankerRole,
+			req:  &pb.ReverseTransferRequest{Id: transfer.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.ReverseTransferTxResult{}, db.ErrTransferAlreadyReversed)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ReverseTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, "banker", tc.role, time.Minute)
+			rsp, err := server.ReverseTransfer(ctx, tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
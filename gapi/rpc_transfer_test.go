@@ -0,0 +1,600 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateTransferRPC(t *testing.T) {
+	amount := int64(10)
+
+	// 创建两个随机用户和他们的货币类型相同的账户
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+
+	account1.Currency = util.USD
+	account2.Currency = util.USD
+
+	// 系统用户持有的银行自身账户
+	systemAccount := randomAccount(db.SystemUsername)
+	systemAccount.ID = account1.ID + 2
+	systemAccount.Currency = util.USD
+
+	testCases := []struct {
+		name string
+		req  *pb.CreateTransferRequest
+		// 构建 stubs 的方式
+		buildStubs func(store *mockdb.MockStore)
+		// 构建携带认证信息的上下文的方式
+		buildContext func(t *testing.T, tokenMaker token.Maker) context.Context
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, rsp *pb.CreateTransferResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+
+				arg := db.TransferTxParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+				}
+				result := db.TransferTxResult{
+					Transfer:    db.Transfer{ID: 1, FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: amount},
+					FromAccount: account1,
+					ToAccount:   account2,
+				}
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, rsp.GetReplayed())
+				require.Equal(t, account1.ID, rsp.GetResult().GetTransfer().GetFromAccountId())
+				require.Equal(t, account2.ID, rsp.GetResult().GetTransfer().GetToAccountId())
+				require.Equal(t, "0.10", rsp.GetResult().GetTransfer().GetAmount().GetAmount())
+				require.Equal(t, util.USD, rsp.GetResult().GetTransfer().GetAmount().GetCurrency())
+			},
+		},
+		{
+			// 转出账户不属于当前登录用户的情况的测试用例
+			name: "PermissionDenied",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user2.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			// 转入账户是系统账户的情况的测试用例
+			name: "ToSystemAccount",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   systemAccount.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(systemAccount.ID)).Times(1).Return(systemAccount, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			// 邮箱未验证的用户不能转出超过限额的金额
+			name: "EmailNotVerified",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "10.01", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user1.Username)).Times(1).Return(user1, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			// 余额不足的情况的测试用例
+			name: "InsufficientFunds",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, db.ErrInsufficientFunds)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			},
+		},
+		{
+			// 违反限额规则的情况的测试用例，错误详情中包括违反的规则
+			name: "TransferLimitExceeded",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1).Return(db.TransferTxResult{}, &db.TransferLimitError{
+					Violations: []db.TransferLimitViolation{
+						{RuleID: "usd-account-single", Scope: db.TransferLimitScopeAccount, Period: db.TransferLimitPeriodTransfer, Metric: db.TransferLimitMetricAmount, Currency: util.USD, Limit: 1, Requested: amount},
+					},
+				})
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				st := status.Convert(err)
+				require.Equal(t, codes.FailedPrecondition, st.Code())
+				require.Len(t, st.Details(), 1)
+				failure, ok := st.Details()[0].(*errdetails.PreconditionFailure)
+				require.True(t, ok)
+				require.Equal(t, "usd-account-single", failure.GetViolations()[0].GetSubject())
+			},
+		},
+		{
+			// 请求参数不合法的情况的测试用例
+			name: "InvalidArgument",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "-0.10", Currency: "XYZ"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			// 金额的小数位数超过货币的小数位数的情况的测试用例
+			name: "InvalidAmountPrecision",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "0.101", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			// 向转出账户自身转账的情况的测试用例
+			name: "SameAccount",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account1.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			// 没有提供访问令牌的情况的测试用例
+			name: "NoAuthorization",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return metadata.NewIncomingContext(context.Background(), metadata.MD{})
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			// 使用刷新令牌代替访问令牌的情况的测试用例
+			name: "RefreshToken",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				refreshToken, _, err := tokenMaker.CreateToken(user1.Username, util.DepositorRole, token.TokenTypeRefresh, time.Minute)
+				require.NoError(t, err)
+				md := metadata.MD{
+					authorizationHeader: []string{fmt.Sprintf("%s %s", authorizationBearer, refreshToken)},
+				}
+				return metadata.NewIncomingContext(context.Background(), md)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			// 访问令牌已经过期的情况的测试用例
+			name: "ExpiredToken",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, util.DepositorRole, -time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			// 银行职员不能从客户的账户转账
+			name: "BankerForbidden",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, "banker", util.BankerRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			// 用户在令牌签发之后修改了密码
+			name: "PasswordChanged",
+			req: &pb.CreateTransferRequest{
+				FromAccountId: account1.ID,
+				ToAccountId:   account2.ID,
+				Amount:        &pb.Money{Amount: "0.10", Currency: util.USD},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserPasswordChangedAt(gomock.Any(), gomock.Eq(user1.Username)).
+					Times(1).
+					Return(time.Now().Add(time.Minute), nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			buildContext: func(t *testing.T, tokenMaker token.Maker) context.Context {
+				return newContextWithBearerToken(t, tokenMaker, user1.Username, util.DepositorRole, time.Minute)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.CreateTransferResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := tc.buildContext(t, server.tokenMaker)
+			rsp, err := server.CreateTransfer(ctx, tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+func TestGetTransferRPC(t *testing.T) {
+	user1, _ := randomUser(t)
+	user2, _ := randomUser(t)
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account2.ID = account1.ID + 1
+	account1.Currency = util.USD
+	account2.Currency = util.EUR
+
+	transfer := db.Transfer{ID: 1, FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 100, ToAmount: 92}
+
+	testCases := []struct {
+		name     string
+		username string
+		// 构建 stubs 的方式
+		buildStubs func(store *mockdb.MockStore)
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, rsp *pb.GetTransferResponse, err error)
+	}{
+		{
+			// 转入账户的所有者也可以查询交易，两个金额分别使用双方账户的货币
+			name:     "OK",
+			username: user2.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.GetTransferResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "1.00", rsp.GetTransfer().GetAmount().GetAmount())
+				require.Equal(t, util.USD, rsp.GetTransfer().GetAmount().GetCurrency())
+				require.Equal(t, "0.92", rsp.GetTransfer().GetToAmount().GetAmount())
+				require.Equal(t, util.EUR, rsp.GetTransfer().GetToAmount().GetCurrency())
+			},
+		},
+		{
+			// 与交易双方都无关的客户不能查询交易
+			name:     "PermissionDenied",
+			username: "unauthorized",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(transfer, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.GetTransferResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			name:     "NotFound",
+			username: user1.Username,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), gomock.Eq(transfer.ID)).Times(1).Return(db.Transfer{}, pgx.ErrNoRows)
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.GetTransferResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, util.DepositorRole, time.Minute)
+			rsp, err := server.GetTransfer(ctx, &pb.GetTransferRequest{Id: transfer.ID})
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
+
+func TestListAccountTransfersRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	account := randomAccount(user.Username)
+	account.Currency = util.USD
+	counterpartyID := account.ID + 1
+
+	n := 5
+	transfers := make([]db.Transfer, n)
+	for i := range transfers {
+		transfers[i] = db.Transfer{
+			ID:            int64(i + 1),
+			FromAccountID: account.ID,
+			ToAccountID:   counterpartyID,
+			Amount:        200,
+			ToAmount:      200,
+			CreatedAt:     time.Now(),
+		}
+	}
+	currencies := []db.ListAccountCurrenciesRow{
+		{ID: account.ID, Currency: util.USD},
+		{ID: counterpartyID, Currency: util.USD},
+	}
+	minAmount := "1.00"
+	maxAmount := "5.00"
+
+	testCases := []struct {
+		name string
+		req  *pb.ListAccountTransfersRequest
+		// 构建 stubs 的方式
+		buildStubs func(store *mockdb.MockStore)
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, rsp *pb.ListAccountTransfersResponse, err error)
+	}{
+		{
+			// 过滤条件中的金额按照账户的货币解析，游标分页时当前页已满则返回下一页的游标
+			name: "OK",
+			req: &pb.ListAccountTransfersRequest{
+				Id:                    account.ID,
+				Direction:             transferDirectionOutgoing,
+				CounterpartyAccountId: &counterpartyID,
+				MinAmount:             &minAmount,
+				MaxAmount:             &maxAmount,
+				PageSize:              int32(n),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+
+				min, max := int64(100), int64(500)
+				arg := db.ListTransfersParams{
+					AccountID:             account.ID,
+					Direction:             sql.NullString{String: transferDirectionOutgoing, Valid: true},
+					CounterpartyAccountID: &counterpartyID,
+					MinAmount:             &min,
+					MaxAmount:             &max,
+					Limit:                 int32(n),
+				}
+				store.EXPECT().ListTransfers(gomock.Any(), gomock.Eq(arg)).Times(1).Return(transfers, nil)
+				store.EXPECT().
+					ListAccountCurrencies(gomock.Any(), gomock.Eq([]int64{account.ID, counterpartyID})).
+					Times(1).
+					Return(currencies, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ListAccountTransfersResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, rsp.GetTransfers(), n)
+				require.Equal(t, "2.00", rsp.GetTransfers()[0].GetAmount().GetAmount())
+
+				cursor, err := util.DecodeCursor(rsp.GetNextCursor())
+				require.NoError(t, err)
+				require.Equal(t, transfers[n-1].ID, cursor.ID)
+			},
+		},
+		{
+			name: "InvalidAmountRange",
+			req: &pb.ListAccountTransfersRequest{
+				Id:        account.ID,
+				MinAmount: &maxAmount,
+				MaxAmount: &minAmount,
+				PageSize:  int32(n),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ListTransfers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ListAccountTransfersResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidDirection",
+			req: &pb.ListAccountTransfersRequest{
+				Id:        account.ID,
+				Direction: "sideways",
+				PageSize:  int32(n),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ListAccountTransfersResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidCursor",
+			req: &pb.ListAccountTransfersRequest{
+				Id:       account.ID,
+				Cursor:   "invalid",
+				PageSize: int32(n),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccountForUpdate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.ListAccountTransfersResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, user.Username, util.DepositorRole, time.Minute)
+			rsp, err := server.ListAccountTransfers(ctx, tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"SimpleBank/worker"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateUser 创建一个新用户
+func (server *Server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	if violations := validateCreateUserRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	ctx = withAuditMetadata(ctx, db.AuditActorAnonymous)
+
+	hashedPassword, err := util.HashPassword(req.GetPassword())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
+	}
+
+	// 在创建用户的同一个事务中将发送验证邮件的任务放入队列
+	arg := db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       req.GetUsername(),
+			HashedPassword: hashedPassword,
+			FullName:       req.GetFullName(),
+			Email:          req.GetEmail(),
+		},
+		AfterCreate: func(q db.Querier, user db.User) error {
+			payload := &worker.PayloadSendVerifyEmail{Username: user.Username}
+			return server.taskDistributor.DistributeTaskSendVerifyEmail(ctx, q, payload)
+		},
+	}
+
+	txResult, err := server.store.CreateUserTx(ctx, arg)
+	if err != nil {
+		// 用户名或者邮箱已经存在
+		if pqErr, ok := err.(*pgconn.PgError); ok && pqErr.Code == "23505" {
+			return nil, status.Errorf(codes.AlreadyExists, "user already exists: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create user: %s", err)
+	}
+
+	rsp := &pb.CreateUserResponse{
+		User: convertUser(txResult.User),
+	}
+	return rsp, nil
+}
+
+// validateCreateUserRequest 检验创建用户请求中的所有字段，返回所有不合法的字段
+func validateCreateUserRequest(req *pb.CreateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+	if err := validatePassword(req.GetPassword()); err != nil {
+		violations = append(violations, fieldViolation("password", err))
+	}
+	if err := validateFullName(req.GetFullName()); err != nil {
+		violations = append(violations, fieldViolation("full_name", err))
+	}
+	if err := validateEmail(req.GetEmail()); err != nil {
+		violations = append(violations, fieldViolation("email", err))
+	}
+	return violations
+}
+
+// LoginUser 验证用户名和密码，成功后签发访问令牌
+func (server *Server) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*pb.LoginUserResponse, error) {
+	if violations := validateLoginUserRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+
+	user, err := server.store.GetUser(ctx, req.GetUsername())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find user: %s", err)
+	}
+
+	err = util.CheckPassword(req.GetPassword(), user.HashedPassword)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "incorrect password")
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeAccess, server.config.AccessTokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access token: %s", err)
+	}
+
+	// 刷新令牌的 ID 即为会话的 ID ，会话被封禁之后刷新令牌立即失效
+	refreshToken, refreshPayload, err := server.tokenMaker.CreateToken(user.Username, user.Role, token.TokenTypeRefresh, server.config.RefreshTokenDuration)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create refresh token: %s", err)
+	}
+
+	mtdt := server.extractMetadata(ctx)
+	session, err := server.store.CreateSession(ctx, db.CreateSessionParams{
+		ID:           refreshPayload.ID,
+		Username:     user.Username,
+		RefreshToken: refreshToken,
+		UserAgent:    mtdt.UserAgent,
+		ClientIp:     mtdt.ClientIP,
+		IsBlocked:    false,
+		ExpiresAt:    refreshPayload.ExpiredAt,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create session: %s", err)
+	}
+
+	rsp := &pb.LoginUserResponse{
+		User:                  convertUser(user),
+		SessionId:             session.ID.String(),
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessPayload.ExpiredAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(refreshPayload.ExpiredAt),
+	}
+	return rsp, nil
+}
+
+// validateLoginUserRequest 检验登录请求中的所有字段，返回所有不合法的字段
+func validateLoginUserRequest(req *pb.LoginUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+	if err := validatePassword(req.GetPassword()); err != nil {
+		violations = append(violations, fieldViolation("password", err))
+	}
+	return violations
+}
+
+// UpdateUser 修改当前登录的用户的姓名、邮箱和密码
+// 修改密码之后，之前签发的访问令牌和刷新令牌全部失效；修改邮箱之后需要重新验证新的邮箱
+func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
+	authPayload, err := server.authorizeUser(ctx, anyRole)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withAuditMetadata(ctx, authPayload.Username)
+
+	if violations := validateUpdateUserRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	if req.FullName == nil && req.Email == nil && req.Password == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one of full_name, email and password must be provided")
+	}
+
+	// 只能修改当前登录的用户
+	if req.GetUsername() != authPayload.Username {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot update other user's info")
+	}
+
+	user, err := server.store.GetUser(ctx, req.GetUsername())
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.NotFound, "user not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find user: %s", err)
+	}
+
+	arg := db.UpdateUserTxParams{
+		UpdateUserParams: db.UpdateUserParams{
+			Username: user.Username,
+		},
+	}
+	if req.FullName != nil {
+		arg.FullName = sql.NullString{String: req.GetFullName(), Valid: true}
+	}
+
+	// 修改密码时检查当前的密码，并重新加密新的密码
+	if req.Password != nil {
+		if err := util.CheckPassword(req.GetCurrentPassword(), user.HashedPassword); err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "current password is incorrect")
+		}
+
+		hashedPassword, err := util.HashPassword(req.GetPassword())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash password: %s", err)
+		}
+		passwordChangedAt := time.Now()
+		arg.HashedPassword = sql.NullString{String: hashedPassword, Valid: true}
+		arg.PasswordChangedAt = &passwordChangedAt
+	}
+
+	// 修改邮箱之后新的邮箱未验证，在同一个事务中将发送验证邮件的任务放入队列
+	if req.Email != nil && req.GetEmail() != user.Email {
+		arg.Email = sql.NullString{String: req.GetEmail(), Valid: true}
+		arg.IsEmailVerified = sql.NullBool{Bool: false, Valid: true}
+		arg.AfterUpdate = func(q db.Querier, user db.User) error {
+			payload := &worker.PayloadSendVerifyEmail{Username: user.Username}
+			return server.taskDistributor.DistributeTaskSendVerifyEmail(ctx, q, payload)
+		}
+	}
+
+	txResult, err := server.store.UpdateUserTx(ctx, arg)
+	if err != nil {
+		// 新的邮箱已经被其他用户使用
+		if pqErr, ok := err.(*pgconn.PgError); ok && pqErr.Code == "23505" {
+			return nil, status.Errorf(codes.AlreadyExists, "email already in use: %s", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update user: %s", err)
+	}
+
+	rsp := &pb.UpdateUserResponse{
+		User: convertUser(txResult.User),
+	}
+	return rsp, nil
+}
+
+// validateUpdateUserRequest 检验修改用户请求中传入了值的字段，返回所有不合法的字段
+func validateUpdateUserRequest(req *pb.UpdateUserRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateUsername(req.GetUsername()); err != nil {
+		violations = append(violations, fieldViolation("username", err))
+	}
+	if req.FullName != nil {
+		if err := validateFullName(req.GetFullName()); err != nil {
+			violations = append(violations, fieldViolation("full_name", err))
+		}
+	}
+	if req.Email != nil {
+		if err := validateEmail(req.GetEmail()); err != nil {
+			violations = append(violations, fieldViolation("email", err))
+		}
+	}
+	if req.Password != nil {
+		if err := validatePassword(req.GetPassword()); err != nil {
+			violations = append(violations, fieldViolation("password", err))
+		}
+	}
+	return violations
+}
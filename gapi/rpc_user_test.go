@@ -0,0 +1,274 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"SimpleBank/worker"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginUserRPC(t *testing.T) {
+	user, password := randomUser(t)
+
+	testCases := []struct {
+		name string
+		req  *pb.LoginUserRequest
+		// 构建 stubs 的方式
+		buildStubs func(store *mockdb.MockStore)
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, server *Server, rsp *pb.LoginUserResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.LoginUserRequest{Username: user.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateSessionParams) (db.Session, error) {
+						require.Equal(t, user.Username, arg.Username)
+						return db.Session{ID: arg.ID, Username: arg.Username, RefreshToken: arg.RefreshToken, ExpiresAt: arg.ExpiresAt}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, server *Server, rsp *pb.LoginUserResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, user.Username, rsp.GetUser().GetUsername())
+
+				// 签发的访问令牌可以被验证，并且过期时间与响应一致
+				payload, err := server.tokenMaker.VerifyToken(rsp.GetAccessToken())
+				require.NoError(t, err)
+				require.Equal(t, user.Username, payload.Username)
+				require.True(t, payload.ExpiredAt.Equal(rsp.GetAccessTokenExpiresAt().AsTime()))
+
+				// 刷新令牌的 ID 即为会话的 ID
+				refreshPayload, err := server.tokenMaker.VerifyToken(rsp.GetRefreshToken())
+				require.NoError(t, err)
+				require.Equal(t, refreshPayload.ID.String(), rsp.GetSessionId())
+				require.True(t, refreshPayload.ExpiredAt.Equal(rsp.GetRefreshTokenExpiresAt().AsTime()))
+			},
+		},
+		{
+			// 用户不存在的情况的测试用例
+			name: "UserNotFound",
+			req:  &pb.LoginUserRequest{Username: user.Username, Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, server *Server, rsp *pb.LoginUserResponse, err error) {
+				require.Equal(t, codes.NotFound, status.Code(err))
+			},
+		},
+		{
+			// 密码错误的情况的测试用例
+			name: "IncorrectPassword",
+			req:  &pb.LoginUserRequest{Username: user.Username, Password: "incorrect"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, server *Server, rsp *pb.LoginUserResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			// 用户名不合法的情况的测试用例
+			name: "InvalidUsername",
+			req:  &pb.LoginUserRequest{Username: "invalid-user#1", Password: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, rsp *pb.LoginUserResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			rsp, err := server.LoginUser(context.Background(), tc.req)
+			tc.checkResponse(t, server, rsp, err)
+		})
+	}
+}
+
+func TestUpdateUserRPC(t *testing.T) {
+	user, password := randomUser(t)
+	newName := util.RandomOwner()
+	newEmail := util.RandomEmail()
+	newPassword := util.RandomString(8)
+
+	testCases := []struct {
+		name     string
+		username string
+		req      *pb.UpdateUserRequest
+		// 构建 stubs 的方式
+		buildStubs func(store *mockdb.MockStore)
+		// 检查 RPC 的输出
+		checkResponse func(t *testing.T, rsp *pb.UpdateUserResponse, err error)
+	}{
+		{
+			name:     "OK",
+			username: user.Username,
+			req:      &pb.UpdateUserRequest{Username: user.Username, FullName: &newName},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					UpdateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateUserTxParams) (db.UpdateUserTxResult, error) {
+						require.Equal(t, newName, arg.FullName.String)
+						require.False(t, arg.HashedPassword.Valid)
+						require.Nil(t, arg.AfterUpdate)
+
+						updated := user
+						updated.FullName = newName
+						return db.UpdateUserTxResult{User: updated}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, newName, rsp.GetUser().GetFullName())
+			},
+		},
+		{
+			// 修改邮箱之后新的邮箱未验证，并在同一个事务中放入发送验证邮件的任务
+			name:     "ChangeEmail",
+			username: user.Username,
+			req:      &pb.UpdateUserRequest{Username: user.Username, Email: &newEmail},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					CreateTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateTaskParams) (db.Task, error) {
+						require.Equal(t, worker.TaskSendVerifyEmail, arg.Type)
+						return db.Task{}, nil
+					})
+				store.EXPECT().
+					UpdateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateUserTxParams) (db.UpdateUserTxResult, error) {
+						require.Equal(t, newEmail, arg.Email.String)
+						require.True(t, arg.IsEmailVerified.Valid)
+						require.False(t, arg.IsEmailVerified.Bool)
+
+						updated := user
+						updated.Email = newEmail
+						require.NoError(t, arg.AfterUpdate(store, updated))
+						return db.UpdateUserTxResult{User: updated}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, newEmail, rsp.GetUser().GetEmail())
+				require.False(t, rsp.GetUser().GetIsEmailVerified())
+			},
+		},
+		{
+			// 修改密码时当前的密码错误
+			name:     "IncorrectCurrentPassword",
+			username: user.Username,
+			req:      &pb.UpdateUserRequest{Username: user.Username, Password: &newPassword, CurrentPassword: "incorrect"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateUserResponse, err error) {
+				require.Equal(t, codes.Unauthenticated, status.Code(err))
+			},
+		},
+		{
+			name:     "ChangePassword",
+			username: user.Username,
+			req:      &pb.UpdateUserRequest{Username: user.Username, Password: &newPassword, CurrentPassword: password},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					UpdateUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateUserTxParams) (db.UpdateUserTxResult, error) {
+						require.NoError(t, util.CheckPassword(newPassword, arg.HashedPassword.String))
+						require.NotNil(t, arg.PasswordChangedAt)
+						return db.UpdateUserTxResult{User: user, BlockedSessions: 2}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateUserResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			// 新的邮箱已经被其他用户使用
+			name:     "DuplicateEmail",
+			username: user.Username,
+			req:      &pb.UpdateUserRequest{Username: user.Username, Email: &newEmail},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().UpdateUserTx(gomock.Any(), gomock.Any()).Times(1).Return(db.UpdateUserTxResult{}, &pgconn.PgError{Code: "23505"})
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateUserResponse, err error) {
+				require.Equal(t, codes.AlreadyExists, status.Code(err))
+			},
+		},
+		{
+			// 不能修改其他用户的信息
+			name:     "OtherUser",
+			username: "other",
+			req:      &pb.UpdateUserRequest{Username: user.Username, FullName: &newName},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateUserResponse, err error) {
+				require.Equal(t, codes.PermissionDenied, status.Code(err))
+			},
+		},
+		{
+			// 没有指定任何需要修改的字段
+			name:     "NothingToUpdate",
+			username: user.Username,
+			req:      &pb.UpdateUserRequest{Username: user.Username},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.UpdateUserResponse, err error) {
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.username, util.DepositorRole, time.Minute)
+			rsp, err := server.UpdateUser(ctx, tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
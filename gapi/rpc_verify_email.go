@@ -0,0 +1,67 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyEmail 使用验证邮件中的验证码验证用户的邮箱
+func (server *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	if violations := validateVerifyEmailRequest(req); violations != nil {
+		return nil, invalidArgumentError(violations)
+	}
+	ctx = withAuditMetadata(ctx, db.AuditActorAnonymous)
+
+	txResult, err := server.store.VerifyEmailTx(ctx, db.VerifyEmailTxParams{
+		EmailID:    req.GetEmailId(),
+		SecretCode: req.GetSecretCode(),
+	})
+	if err != nil {
+		// 验证码不正确、已经使用或者已经过期
+		if err == pgx.ErrNoRows {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid or expired verification code")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to verify email: %s", err)
+	}
+
+	rsp := &pb.VerifyEmailResponse{
+		IsVerified: txResult.User.IsEmailVerified,
+	}
+	return rsp, nil
+}
+
+// validateVerifyEmailRequest 检验验证邮箱请求中的所有字段，返回所有不合法的字段
+func validateVerifyEmailRequest(req *pb.VerifyEmailRequest) (violations []*errdetails.BadRequest_FieldViolation) {
+	if err := validateID(req.GetEmailId()); err != nil {
+		violations = append(violations, fieldViolation("email_id", err))
+	}
+	if req.GetSecretCode() == "" {
+		violations = append(violations, fieldViolation("secret_code", fmt.Errorf("must not be empty")))
+	}
+	return violations
+}
+
+// requireVerifiedEmail 检查用户是否可以转出 amount ，金额超过配置的限额时用户的邮箱必须已经验证
+// 金额为转出账户货币的金额，限额不大于 0 时不进行限制
+func (server *Server) requireVerifiedEmail(ctx context.Context, username string, amount int64) error {
+	limit := server.config.UnverifiedTransferLimit
+	if limit <= 0 || amount <= limit {
+		return nil
+	}
+
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get user: %s", err)
+	}
+	if !user.IsEmailVerified {
+		return status.Errorf(codes.PermissionDenied, "email must be verified to transfer more than %d", limit)
+	}
+	return nil
+}
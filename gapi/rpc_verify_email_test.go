@@ -0,0 +1,85 @@
+package gapi
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/pb"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyEmailRPC(t *testing.T) {
+	user, _ := randomUser(t)
+	user.IsEmailVerified = true
+
+	testCases := []struct {
+		name          string
+		req           *pb.VerifyEmailRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, rsp *pb.VerifyEmailResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.VerifyEmailRequest{EmailId: 1, SecretCode: "secret"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.VerifyEmailTxParams{EmailID: 1, SecretCode: "secret"}
+				store.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.VerifyEmailTxResult{User: user}, nil)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.VerifyEmailResponse, err error) {
+				require.NoError(t, err)
+				require.True(t, rsp.GetIsVerified())
+			},
+		},
+		{
+			// 验证码不正确、已经使用或者已经过期的情况的测试用例
+			name: "InvalidCode",
+			req:  &pb.VerifyEmailRequest{EmailId: 1, SecretCode: "wrong"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.VerifyEmailTxResult{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.VerifyEmailResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+		{
+			name: "InvalidArgument",
+			req:  &pb.VerifyEmailRequest{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyEmailTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, rsp *pb.VerifyEmailResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, codes.InvalidArgument, status.Code(err))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			rsp, err := server.VerifyEmail(context.Background(), tc.req)
+			tc.checkResponse(t, rsp, err)
+		})
+	}
+}
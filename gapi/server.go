@@ -0,0 +1,49 @@
+package gapi
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/fx"
+	"SimpleBank/pb"
+	"SimpleBank/token"
+	"SimpleBank/util"
+	"SimpleBank/worker"
+	"fmt"
+)
+
+// Server 为银行服务提供所有的 gRPC 请求，HTTP 请求经过网关转换后也由它处理
+type Server struct {
+	pb.UnimplementedSimpleBankServer
+	// 保存服务器运行所需要的配置
+	config util.Config
+	// 允许处理来自客户端的 RPC 请求时，与数据库进行交互
+	store db.Store
+	// 用于创建和验证访问令牌
+	tokenMaker token.Maker
+	// 用于跨币种交易时获取汇率报价
+	exchangeRateProvider fx.ExchangeRateProvider
+	// 用于将发送邮件等异步任务放入队列
+	taskDistributor worker.TaskDistributor
+}
+
+// NewServer 创建一个 gRPC 服务器
+func NewServer(
+	config util.Config,
+	store db.Store,
+	exchangeRateProvider fx.ExchangeRateProvider,
+	taskDistributor worker.TaskDistributor,
+) (*Server, error) {
+	// 与 HTTP 服务器使用相同的对称密钥，两种 API 签发的访问令牌可以互相使用
+	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create token maker: %w", err)
+	}
+
+	server := &Server{
+		config:               config,
+		store:                store,
+		tokenMaker:           tokenMaker,
+		exchangeRateProvider: exchangeRateProvider,
+		taskDistributor:      taskDistributor,
+	}
+	return server, nil
+}
@@ -0,0 +1,96 @@
+package gapi
+
+import (
+	"SimpleBank/pb"
+	"SimpleBank/util"
+	"fmt"
+	"net/mail"
+	"regexp"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 用户名只能包含字母和数字
+var isValidUsername = regexp.MustCompile(`^[a-zA-Z0-9]+$`).MatchString
+
+// validateUsername 检验用户名只包含字母和数字
+func validateUsername(value string) error {
+	if value == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if !isValidUsername(value) {
+		return fmt.Errorf("must contain only letters and digits")
+	}
+	return nil
+}
+
+// validatePassword 检验密码至少包含 6 个字符
+func validatePassword(value string) error {
+	if len(value) < 6 {
+		return fmt.Errorf("must contain at least 6 characters")
+	}
+	return nil
+}
+
+// validateFullName 检验全名不为空
+func validateFullName(value string) error {
+	if value == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	return nil
+}
+
+// validateEmail 检验邮箱地址的格式
+func validateEmail(value string) error {
+	if _, err := mail.ParseAddress(value); err != nil {
+		return fmt.Errorf("is not a valid email address")
+	}
+	return nil
+}
+
+// validateCurrency 检验是否为支持的货币类型
+func validateCurrency(value string) error {
+	if !util.IsSupportedCurrency(value) {
+		return fmt.Errorf("unsupported currency %q", value)
+	}
+	return nil
+}
+
+// validateID 检验 ID 为正数
+func validateID(value int64) error {
+	if value < 1 {
+		return fmt.Errorf("must be a positive integer")
+	}
+	return nil
+}
+
+// validateMoney 检验金额为支持的货币类型的正数，并转换为以最小货币单位表示的 util.Money
+func validateMoney(value *pb.Money) (util.Money, error) {
+	if err := validateCurrency(value.GetCurrency()); err != nil {
+		return util.Money{}, err
+	}
+	money, err := util.ParseMoney(value.GetAmount(), value.GetCurrency())
+	if err != nil {
+		return util.Money{}, err
+	}
+	if !money.IsPositive() {
+		return util.Money{}, fmt.Errorf("must be greater than 0")
+	}
+	return money, nil
+}
+
+// validatePageSize 检验每页的数量在 5 到 100 之间
+func validatePageSize(value int32) error {
+	if value < 5 || value > 100 {
+		return fmt.Errorf("must be between 5 and 100")
+	}
+	return nil
+}
+
+// validateOptionalTimestamp 检验传入了值的时间是否有效，没有传入值时不进行检验
+func validateOptionalTimestamp(value *timestamppb.Timestamp) error {
+	if value == nil {
+		return nil
+	}
+	return value.CheckValid()
+}
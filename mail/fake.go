@@ -0,0 +1,51 @@
+package mail
+
+import "sync"
+
+// Email 是 FakeSender 记录的一封邮件
+type Email struct {
+	Subject string
+	Content string
+	To      []string
+	Cc      []string
+	Bcc     []string
+}
+
+// FakeSender 不会真正发送邮件，只在内存中记录所有发送的邮件，用于测试和没有配置 SMTP 服务器的本地开发
+type FakeSender struct {
+	mu   sync.Mutex
+	sent []Email
+	// Err 不为空时 SendEmail 返回该错误，用于模拟发送失败
+	Err error
+}
+
+// NewFakeSender 创建一个 FakeSender
+func NewFakeSender() *FakeSender {
+	return &FakeSender{}
+}
+
+// SendEmail 记录一封邮件，Err 不为空时不记录并返回 Err
+func (sender *FakeSender) SendEmail(subject string, content string, to []string, cc []string, bcc []string) error {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	if sender.Err != nil {
+		return sender.Err
+	}
+	sender.sent = append(sender.sent, Email{
+		Subject: subject,
+		Content: content,
+		To:      to,
+		Cc:      cc,
+		Bcc:     bcc,
+	})
+	return nil
+}
+
+// Sent 返回所有已经发送的邮件
+func (sender *FakeSender) Sent() []Email {
+	sender.mu.Lock()
+	defer sender.mu.Unlock()
+
+	return append([]Email(nil), sender.sent...)
+}
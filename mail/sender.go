@@ -0,0 +1,75 @@
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+)
+
+// EmailSender 发送邮件，content 为 HTML 格式的邮件正文
+type EmailSender interface {
+	SendEmail(subject string, content string, to []string, cc []string, bcc []string) error
+}
+
+// SMTPSender 通过 SMTP 服务器发送邮件，使用 PLAIN 认证，服务器需要支持 STARTTLS
+type SMTPSender struct {
+	name              string
+	fromEmailAddress  string
+	fromEmailPassword string
+	// SMTP 服务器的地址，格式为 host:port
+	serverAddress string
+}
+
+// NewSMTPSender 创建一个使用 fromEmailAddress 账号通过 serverAddress 发送邮件的 SMTPSender
+func NewSMTPSender(name, fromEmailAddress, fromEmailPassword, serverAddress string) EmailSender {
+	return &SMTPSender{
+		name:              name,
+		fromEmailAddress:  fromEmailAddress,
+		fromEmailPassword: fromEmailPassword,
+		serverAddress:     serverAddress,
+	}
+}
+
+// SendEmail 通过 SMTP 服务器发送一封邮件，密送的地址只作为收件人，不会出现在邮件头中
+func (sender *SMTPSender) SendEmail(subject string, content string, to []string, cc []string, bcc []string) error {
+	if len(to) == 0 {
+		return errors.New("no recipient")
+	}
+
+	host, _, err := net.SplitHostPort(sender.serverAddress)
+	if err != nil {
+		return fmt.Errorf("invalid smtp server address: %w", err)
+	}
+
+	from := mail.Address{Name: sender.name, Address: sender.fromEmailAddress}
+	msg := buildMessage(from, subject, content, to, cc)
+
+	recipients := make([]string, 0, len(to)+len(cc)+len(bcc))
+	recipients = append(recipients, to...)
+	recipients = append(recipients, cc...)
+	recipients = append(recipients, bcc...)
+
+	auth := smtp.PlainAuth("", sender.fromEmailAddress, sender.fromEmailPassword, host)
+	return smtp.SendMail(sender.serverAddress, auth, sender.fromEmailAddress, recipients, msg)
+}
+
+// buildMessage 生成 HTML 格式的邮件内容，标题使用 RFC 2047 编码以支持非 ASCII 字符
+func buildMessage(from mail.Address, subject string, content string, to []string, cc []string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	if len(cc) > 0 {
+		fmt.Fprintf(&buf, "Cc: %s\r\n", strings.Join(cc, ", "))
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(content)
+	return buf.Bytes()
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMessage(t *testing.T) {
+	from := mail.Address{Name: "Simple Bank", Address: "bank@email.com"}
+	content := `<h1>Hello</h1>`
+
+	msg := string(buildMessage(from, "欢迎使用 Simple Bank", content, []string{"a@email.com", "b@email.com"}, []string{"c@email.com"}))
+
+	header, body, found := strings.Cut(msg, "\r\n\r\n")
+	require.True(t, found)
+	require.Equal(t, content, body)
+
+	require.Contains(t, header, `From: "Simple Bank" <bank@email.com>`)
+	require.Contains(t, header, "To: a@email.com, b@email.com")
+	require.Contains(t, header, "Cc: c@email.com")
+	require.Contains(t, header, "Content-Type: text/html; charset=UTF-8")
+
+	// 非 ASCII 的标题需要编码之后才能放在邮件头中
+	require.Contains(t, header, "Subject: =?utf-8?q?")
+	require.NotContains(t, header, "欢迎")
+}
+
+func TestBuildMessageWithoutCc(t *testing.T) {
+	from := mail.Address{Address: "bank@email.com"}
+	msg := string(buildMessage(from, "Hello", "content", []string{"a@email.com"}, nil))
+	require.NotContains(t, msg, "Cc:")
+	require.Contains(t, msg, "Subject: Hello\r\n")
+}
+
+func TestSMTPSenderNoRecipient(t *testing.T) {
+	sender := NewSMTPSender("Simple Bank", "bank@email.com", "secret", "localhost:587")
+	err := sender.SendEmail("Hello", "content", nil, nil, nil)
+	require.Error(t, err)
+}
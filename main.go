@@ -1,14 +1,27 @@
 package main
 
 import (
+	"SimpleBank/audit"
 	db "SimpleBank/db/sqlc"
+	"SimpleBank/doc"
+	"SimpleBank/fx"
+	"SimpleBank/gapi"
+	"SimpleBank/mail"
+	"SimpleBank/pb"
+	"SimpleBank/reconcile"
+	"SimpleBank/scheduler"
 	"SimpleBank/util"
+	"SimpleBank/worker"
 	"context"
 	"log"
-
-	"SimpleBank/api"
+	"net"
+	"net/http"
+	"os"
+	"time"
 
 	"github.com/jackc/pgx/v4/pgxpool"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
@@ -27,12 +40,149 @@ func main() {
 	// 根据 *pgx.Conn 类型变量生成一个 Store 类型对象
 	store := db.NewStore(conn)
 
-	// 根据生成的 store 创建一个 sever
-	server := api.NewServer(store)
+	// go run main.go reconcile 只进行一次对账，发现余额差异时以非 0 状态码退出
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		runReconcileCommand(store)
+		return
+	}
+
+	// go run main.go verify-audit-log 校验审计日志的哈希链，报告第一处断裂并以非 0 状态码退出
+	if len(os.Args) > 1 && os.Args[1] == "verify-audit-log" {
+		runVerifyAuditLogCommand(store)
+		return
+	}
 
-	// 启动上面创建的 server，并监听指定的地址
-	err = server.Start(config.ServerAddress)
+	// 在后台定期清理过期的幂等键
+	go runIdempotencyKeyCleaner(context.Background(), store, config.IdempotencyKeyCleanupInterval)
+
+	// 从配置的汇率文件中读取汇率表，用于跨币种交易
+	exchangeRateProvider, err := fx.LoadStaticProvider(config.ExchangeRatesFile, config.ExchangeQuoteTTL)
+	if err != nil {
+		log.Fatal("cannot load exchange rates:", err)
+	}
+
+	// 在后台定期执行到期的计划交易
+	go scheduler.New(store, config.ScheduledTransferInterval).Run(context.Background())
+
+	// 在后台定期对账，检查账户余额与条目之和是否一致
+	go reconcile.New(store, config.ReconciliationInterval).Run(context.Background())
+
+	// 在后台定期封存审计日志，计算已经提交的审计日志的哈希链
+	go audit.NewSealer(store, config.AuditLogSealInterval).Run(context.Background())
+
+	// 异步任务保存在数据库中，在后台定期处理到期的任务
+	taskDistributor := worker.NewPostgresTaskDistributor()
+	go worker.NewPostgresTaskProcessor(config, store, newEmailSender(config)).Start(context.Background())
+
+	// gRPC 服务器和 HTTP 网关共用同一个 server ，分别监听不同的地址
+	server, err := gapi.NewServer(config, store, exchangeRateProvider, taskDistributor)
+	if err != nil {
+		log.Fatal("cannot create gRPC server:", err)
+	}
+
+	go runGrpcServer(config, server)
+	runHTTPServer(config, server)
+}
+
+// newEmailSender 根据配置创建发送邮件的 EmailSender ，没有配置发件邮箱时邮件只记录在内存中，不会真正发送
+func newEmailSender(config util.Config) mail.EmailSender {
+	if config.EmailSenderAddress == "" {
+		log.Println("EMAIL_SENDER_ADDRESS is not set, emails will not be delivered")
+		return mail.NewFakeSender()
+	}
+	return mail.NewSMTPSender(config.EmailSenderName, config.EmailSenderAddress, config.EmailSenderPassword, config.SMTPServerAddress)
+}
+
+// runHTTPServer 启动 HTTP 服务器
+// /v1 下的所有路由由 gRPC 网关根据 proto 文件生成，/swagger 提供浏览这些路由的 OpenAPI 文档的页面
+func runHTTPServer(config util.Config, server *gapi.Server) {
+	gatewayHandler, err := gapi.NewGatewayHandler(context.Background(), server)
+	if err != nil {
+		log.Fatal("cannot create gateway handler:", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gatewayHandler)
+	mux.Handle("/swagger", http.RedirectHandler("/swagger/", http.StatusMovedPermanently))
+	mux.Handle("/swagger/", http.StripPrefix("/swagger/", http.FileServer(http.FS(doc.SwaggerFS()))))
+
+	// 启动 HTTP 服务器，并监听指定的地址
+	log.Printf("start HTTP server at %s", config.ServerAddress)
+	err = http.ListenAndServe(config.ServerAddress, mux)
 	if err != nil {
 		log.Fatal("connot start server:", err)
 	}
 }
+
+// runGrpcServer 启动 gRPC 服务器
+func runGrpcServer(config util.Config, server *gapi.Server) {
+	grpcServer := grpc.NewServer()
+	pb.RegisterSimpleBankServer(grpcServer, server)
+	// 允许客户端（例如 grpcurl ）查询服务器提供的服务和方法
+	reflection.Register(grpcServer)
+
+	listener, err := net.Listen("tcp", config.GRPCServerAddress)
+	if err != nil {
+		log.Fatal("cannot create listener:", err)
+	}
+
+	log.Printf("start gRPC server at %s", listener.Addr().String())
+	err = grpcServer.Serve(listener)
+	if err != nil {
+		log.Fatal("cannot start gRPC server:", err)
+	}
+}
+
+// runIdempotencyKeyCleaner 每隔 interval 删除一次过期的幂等键，interval 不大于 0 时不进行清理
+func runIdempotencyKeyCleaner(ctx context.Context, store db.Store, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := store.DeleteExpiredIdempotencyKeys(ctx)
+			if err != nil {
+				log.Println("cannot delete expired idempotency keys:", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("deleted %d expired idempotency keys", deleted)
+			}
+		}
+	}
+}
+
+// runReconcileCommand 进行一次对账，发现余额差异时以状态码 1 退出
+func runReconcileCommand(store db.Store) {
+	result, err := reconcile.New(store, 0).Reconcile(context.Background())
+	if err != nil {
+		log.Fatal("cannot reconcile balances:", err)
+	}
+	if result.Run.DriftCount > 0 {
+		os.Exit(1)
+	}
+}
+
+// runVerifyAuditLogCommand 封存所有已经提交的审计日志之后校验一次，哈希链断裂时报告第一处断裂的行并以状态码 1 退出
+func runVerifyAuditLogCommand(store db.Store) {
+	if _, err := audit.NewSealer(store, 0).Seal(context.Background()); err != nil {
+		log.Fatal("cannot seal audit log:", err)
+	}
+
+	result, err := audit.New(store).Verify(context.Background())
+	if err != nil {
+		log.Fatal("cannot verify audit log:", err)
+	}
+	if result.Broken != nil {
+		log.Printf("audit log is broken at entry [%d] (%s on %s): %s", result.Broken.ID, result.Broken.Action, result.Broken.Target, result.Reason)
+		os.Exit(1)
+	}
+	log.Printf("audit log verified, %d entries are intact", result.Checked)
+}
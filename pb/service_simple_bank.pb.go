@@ -0,0 +1,665 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.1
+// 	protoc        (unknown)
+// source: service_simple_bank.proto
+
+package pb
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_service_simple_bank_proto protoreflect.FileDescriptor
+
+var file_service_simple_bank_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a,
+	0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x68, 0x74, 0x74, 0x70, 0x62, 0x6f,
+	0x64, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32, 0x2f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x72, 0x70, 0x63,
+	0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x72, 0x70, 0x63, 0x5f, 0x64, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x72, 0x70, 0x63, 0x5f, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x72, 0x70, 0x63, 0x5f,
+	0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x11, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x72, 0x70, 0x63, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x72, 0x70, 0x63,
+	0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x0e, 0x72, 0x70, 0x63, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x16, 0x72, 0x70, 0x63, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0xc3, 0x32, 0x0a, 0x0a, 0x53, 0x69, 0x6d, 0x70,
+	0x6c, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x12, 0xd4, 0x01, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x93, 0x01, 0x92, 0x41, 0x7e, 0x12, 0x7a, 0xe5,
+	0x81, 0xa5, 0xe5, 0xba, 0xb7, 0xe6, 0xa3, 0x80, 0xe6, 0x9f, 0xa5, 0xef, 0xbc, 0x8c, 0xe9, 0x85,
+	0x8d, 0xe7, 0xbd, 0xae, 0xe4, 0xba, 0x86, 0x20, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x5f, 0x43,
+	0x48, 0x45, 0x43, 0x4b, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x5f, 0x4f, 0x4e, 0x5f, 0x44, 0x52, 0x49,
+	0x46, 0x54, 0x20, 0xe6, 0x97, 0xb6, 0xef, 0xbc, 0x8c, 0xe6, 0x9c, 0x80, 0xe8, 0xbf, 0x91, 0xe4,
+	0xb8, 0x80, 0xe6, 0xac, 0xa1, 0xe5, 0xaf, 0xb9, 0xe8, 0xb4, 0xa6, 0xe5, 0x8f, 0x91, 0xe7, 0x8e,
+	0xb0, 0xe4, 0xbd, 0x99, 0xe9, 0xa2, 0x9d, 0xe5, 0xb7, 0xae, 0xe5, 0xbc, 0x82, 0xe5, 0x88, 0x99,
+	0xe8, 0xbf, 0x94, 0xe5, 0x9b, 0x9e, 0x20, 0x55, 0x6e, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x20, 0xe9, 0x94, 0x99, 0xe8, 0xaf, 0xaf, 0x62, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x0c, 0x12, 0x0a, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x6d, 0x0a,
+	0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x15, 0x2e, 0x70, 0x62,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x30, 0x92, 0x41, 0x19, 0x12,
+	0x15, 0xe5, 0x88, 0x9b, 0xe5, 0xbb, 0xba, 0xe4, 0xb8, 0x80, 0xe4, 0xb8, 0xaa, 0xe6, 0x96, 0xb0,
+	0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0x62, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x3a, 0x01,
+	0x2a, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0xa0, 0x01, 0x0a,
+	0x09, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x12, 0x14, 0x2e, 0x70, 0x62, 0x2e,
+	0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x66, 0x92, 0x41, 0x49, 0x12, 0x45, 0xe9, 0xaa,
+	0x8c, 0xe8, 0xaf, 0x81, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe5, 0x90, 0x8d, 0xe5, 0x92, 0x8c,
+	0xe5, 0xaf, 0x86, 0xe7, 0xa0, 0x81, 0xef, 0xbc, 0x8c, 0xe6, 0x88, 0x90, 0xe5, 0x8a, 0x9f, 0xe5,
+	0x90, 0x8e, 0xe7, 0xad, 0xbe, 0xe5, 0x8f, 0x91, 0xe8, 0xae, 0xbf, 0xe9, 0x97, 0xae, 0xe4, 0xbb,
+	0xa4, 0xe7, 0x89, 0x8c, 0xe5, 0x92, 0x8c, 0xe5, 0x88, 0xb7, 0xe6, 0x96, 0xb0, 0xe4, 0xbb, 0xa4,
+	0xe7, 0x89, 0x8c, 0x62, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x3a, 0x01, 0x2a, 0x22, 0x0f,
+	0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x73, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x12,
+	0xba, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x41,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x41, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x6b, 0x92, 0x41, 0x46, 0x12, 0x42, 0xe4, 0xbd, 0xbf, 0xe7, 0x94, 0xa8, 0xe7, 0x99, 0xbb, 0xe5,
+	0xbd, 0x95, 0xe6, 0x97, 0xb6, 0xe7, 0xad, 0xbe, 0xe5, 0x8f, 0x91, 0xe7, 0x9a, 0x84, 0xe5, 0x88,
+	0xb7, 0xe6, 0x96, 0xb0, 0xe4, 0xbb, 0xa4, 0xe7, 0x89, 0x8c, 0xe8, 0x8e, 0xb7, 0xe5, 0x8f, 0x96,
+	0xe4, 0xb8, 0x80, 0xe4, 0xb8, 0xaa, 0xe6, 0x96, 0xb0, 0xe7, 0x9a, 0x84, 0xe8, 0xae, 0xbf, 0xe9,
+	0x97, 0xae, 0xe4, 0xbb, 0xa4, 0xe7, 0x89, 0x8c, 0x62, 0x00, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c,
+	0x3a, 0x01, 0x2a, 0x22, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x2f,
+	0x72, 0x65, 0x6e, 0x65, 0x77, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x95, 0x01, 0x0a,
+	0x0b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x16, 0x2e, 0x70,
+	0x62, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x55, 0x92,
+	0x41, 0x3a, 0x12, 0x36, 0xe4, 0xbd, 0xbf, 0xe7, 0x94, 0xa8, 0xe9, 0xaa, 0x8c, 0xe8, 0xaf, 0x81,
+	0xe9, 0x82, 0xae, 0xe4, 0xbb, 0xb6, 0xe4, 0xb8, 0xad, 0xe7, 0x9a, 0x84, 0xe9, 0xaa, 0x8c, 0xe8,
+	0xaf, 0x81, 0xe7, 0xa0, 0x81, 0xe9, 0xaa, 0x8c, 0xe8, 0xaf, 0x81, 0xe7, 0x94, 0xa8, 0xe6, 0x88,
+	0xb7, 0xe7, 0x9a, 0x84, 0xe9, 0x82, 0xae, 0xe7, 0xae, 0xb1, 0x62, 0x00, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x12, 0x12, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x12, 0xf7, 0x01, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55,
+	0x73, 0x65, 0x72, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55,
+	0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0xb9, 0x01, 0x92, 0x41, 0x96, 0x01, 0x12, 0x93, 0x01, 0xe4, 0xbf, 0xae, 0xe6,
+	0x94, 0xb9, 0xe5, 0xbd, 0x93, 0xe5, 0x89, 0x8d, 0xe7, 0x99, 0xbb, 0xe5, 0xbd, 0x95, 0xe7, 0x9a,
+	0x84, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe5, 0xa7, 0x93, 0xe5, 0x90, 0x8d,
+	0xe3, 0x80, 0x81, 0xe9, 0x82, 0xae, 0xe7, 0xae, 0xb1, 0xe5, 0x92, 0x8c, 0xe5, 0xaf, 0x86, 0xe7,
+	0xa0, 0x81, 0xef, 0xbc, 0x8c, 0xe4, 0xbf, 0xae, 0xe6, 0x94, 0xb9, 0xe5, 0xaf, 0x86, 0xe7, 0xa0,
+	0x81, 0xe4, 0xb9, 0x8b, 0xe5, 0x90, 0x8e, 0xe4, 0xb9, 0x8b, 0xe5, 0x89, 0x8d, 0xe7, 0xad, 0xbe,
+	0xe5, 0x8f, 0x91, 0xe7, 0x9a, 0x84, 0xe4, 0xbb, 0xa4, 0xe7, 0x89, 0x8c, 0xe5, 0x85, 0xa8, 0xe9,
+	0x83, 0xa8, 0xe5, 0xa4, 0xb1, 0xe6, 0x95, 0x88, 0xef, 0xbc, 0x8c, 0xe4, 0xbf, 0xae, 0xe6, 0x94,
+	0xb9, 0xe9, 0x82, 0xae, 0xe7, 0xae, 0xb1, 0xe4, 0xb9, 0x8b, 0xe5, 0x90, 0x8e, 0xe9, 0x9c, 0x80,
+	0xe8, 0xa6, 0x81, 0xe9, 0x87, 0x8d, 0xe6, 0x96, 0xb0, 0xe9, 0xaa, 0x8c, 0xe8, 0xaf, 0x81, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x32, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0xb4,
+	0x01, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x69, 0x92, 0x41, 0x41, 0x12,
+	0x3f, 0xe5, 0x88, 0x86, 0xe9, 0xa1, 0xb5, 0xe6, 0x9f, 0xa5, 0xe8, 0xaf, 0xa2, 0xe6, 0x8c, 0x87,
+	0xe5, 0xae, 0x9a, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe8, 0xb4, 0xa6, 0xe6,
+	0x88, 0xb7, 0xef, 0xbc, 0x8c, 0xe5, 0x8f, 0xaa, 0xe5, 0x85, 0x81, 0xe8, 0xae, 0xb8, 0xe9, 0x93,
+	0xb6, 0xe8, 0xa1, 0x8c, 0xe8, 0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe8, 0xae, 0xbf, 0xe9, 0x97, 0xae,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x12, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72,
+	0x73, 0x2f, 0x7b, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x7d, 0x2f, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0xd4, 0x01, 0x0a, 0x0c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x70, 0x62, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x90, 0x01, 0x92, 0x41, 0x6e, 0x12,
+	0x6c, 0xe5, 0xb0, 0x81, 0xe7, 0xa6, 0x81, 0xe4, 0xbc, 0x9a, 0xe8, 0xaf, 0x9d, 0xef, 0xbc, 0x8c,
+	0xe4, 0xbc, 0x9a, 0xe8, 0xaf, 0x9d, 0xe8, 0xa2, 0xab, 0xe5, 0xb0, 0x81, 0xe7, 0xa6, 0x81, 0xe4,
+	0xb9, 0x8b, 0xe5, 0x90, 0x8e, 0xe5, 0x88, 0xb7, 0xe6, 0x96, 0xb0, 0xe4, 0xbb, 0xa4, 0xe7, 0x89,
+	0x8c, 0xe7, 0xab, 0x8b, 0xe5, 0x8d, 0xb3, 0xe5, 0xa4, 0xb1, 0xe6, 0x95, 0x88, 0xef, 0xbc, 0x8c,
+	0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c, 0xe8, 0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe5, 0x8f, 0xaf, 0xe4,
+	0xbb, 0xa5, 0xe5, 0xb0, 0x81, 0xe7, 0xa6, 0x81, 0xe4, 0xbb, 0xbb, 0xe6, 0x84, 0x8f, 0xe7, 0x94,
+	0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe4, 0xbc, 0x9a, 0xe8, 0xaf, 0x9d, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x19, 0x22, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x8c, 0x01, 0x0a,
+	0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18,
+	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x46, 0x92, 0x41, 0x2c, 0x12, 0x2a, 0xe4, 0xb8, 0xba, 0xe5, 0xbd, 0x93,
+	0xe5, 0x89, 0x8d, 0xe7, 0x99, 0xbb, 0xe5, 0xbd, 0x95, 0xe7, 0x9a, 0x84, 0xe7, 0x94, 0xa8, 0xe6,
+	0x88, 0xb7, 0xe5, 0x88, 0x9b, 0xe5, 0xbb, 0xba, 0xe4, 0xb8, 0x80, 0xe4, 0xb8, 0xaa, 0xe8, 0xb4,
+	0xa6, 0xe6, 0x88, 0xb7, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x3a, 0x01, 0x2a, 0x22, 0x0c, 0x2f,
+	0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0xac, 0x01, 0x0a, 0x0a,
+	0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6f, 0x92, 0x41, 0x53, 0x12, 0x51,
+	0xe6, 0x9f, 0xa5, 0xe8, 0xaf, 0xa2, 0xe5, 0xbd, 0x93, 0xe5, 0x89, 0x8d, 0xe7, 0x99, 0xbb, 0xe5,
+	0xbd, 0x95, 0xe7, 0x9a, 0x84, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe4, 0xb8,
+	0x80, 0xe4, 0xb8, 0xaa, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xef, 0xbc, 0x8c, 0xe9, 0x93, 0xb6,
+	0xe8, 0xa1, 0x8c, 0xe8, 0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe5, 0x8f, 0xaf, 0xe4, 0xbb, 0xa5, 0xe6,
+	0x9f, 0xa5, 0xe8, 0xaf, 0xa2, 0xe4, 0xbb, 0xbb, 0xe6, 0x84, 0x8f, 0xe8, 0xb4, 0xa6, 0xe6, 0x88,
+	0xb7, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x12, 0x11, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x86, 0x01, 0x0a, 0x0c, 0x4c,
+	0x69, 0x73, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x62,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x43,
+	0x92, 0x41, 0x2c, 0x12, 0x2a, 0xe5, 0x88, 0x86, 0xe9, 0xa1, 0xb5, 0xe6, 0x9f, 0xa5, 0xe8, 0xaf,
+	0xa2, 0xe5, 0xbd, 0x93, 0xe5, 0x89, 0x8d, 0xe7, 0x99, 0xbb, 0xe5, 0xbd, 0x95, 0xe7, 0x9a, 0x84,
+	0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x12, 0xa7, 0x01, 0x0a, 0x0d, 0x46, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x7a,
+	0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x61, 0x92, 0x41, 0x3e, 0x12,
+	0x3c, 0xe5, 0x86, 0xbb, 0xe7, 0xbb, 0x93, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xef, 0xbc, 0x8c,
+	0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c, 0xe8, 0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe5, 0x8f, 0xaf, 0xe4,
+	0xbb, 0xa5, 0xe5, 0x86, 0xbb, 0xe7, 0xbb, 0x93, 0xe4, 0xbb, 0xbb, 0xe6, 0x84, 0x8f, 0xe5, 0xae,
+	0xa2, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1a, 0x22, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x12, 0xa9, 0x01,
+	0x0a, 0x0f, 0x55, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x70, 0x62, 0x2e, 0x55, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5d, 0x92, 0x41, 0x38, 0x12,
+	0x36, 0xe8, 0xa7, 0xa3, 0xe5, 0x86, 0xbb, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xef, 0xbc, 0x8c,
+	0xe5, 0xae, 0xa2, 0xe6, 0x88, 0xb7, 0xe5, 0x8f, 0xaa, 0xe8, 0x83, 0xbd, 0xe8, 0xa7, 0xa3, 0xe5,
+	0x86, 0xbb, 0xe8, 0x87, 0xaa, 0xe5, 0xb7, 0xb1, 0xe5, 0x86, 0xbb, 0xe7, 0xbb, 0x93, 0xe7, 0x9a,
+	0x84, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x22, 0x1a, 0x2f,
+	0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d,
+	0x2f, 0x75, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x12, 0xc9, 0x01, 0x0a, 0x0c, 0x43, 0x6c,
+	0x6f, 0x73, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x85, 0x01,
+	0x92, 0x41, 0x60, 0x12, 0x5e, 0xe5, 0x85, 0xb3, 0xe9, 0x97, 0xad, 0xe5, 0xbd, 0x93, 0xe5, 0x89,
+	0x8d, 0xe7, 0x99, 0xbb, 0xe5, 0xbd, 0x95, 0xe7, 0x9a, 0x84, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7,
+	0xe7, 0x9a, 0x84, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xef, 0xbc, 0x8c, 0xe8, 0xb4, 0xa6, 0xe6,
+	0x88, 0xb7, 0xe8, 0xbf, 0x98, 0xe6, 0x9c, 0x89, 0xe4, 0xbd, 0x99, 0xe9, 0xa2, 0x9d, 0xe6, 0x97,
+	0xb6, 0xe5, 0xb0, 0x86, 0xe4, 0xbd, 0x99, 0xe9, 0xa2, 0x9d, 0xe5, 0x85, 0xa8, 0xe9, 0x83, 0xa8,
+	0xe8, 0xbd, 0xac, 0xe5, 0x85, 0xa5, 0x20, 0x73, 0x77, 0x65, 0x65, 0x70, 0x20, 0xe8, 0xb4, 0xa6,
+	0xe6, 0x88, 0xb7, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x3a, 0x01, 0x2a, 0x22, 0x17, 0x2f, 0x76,
+	0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0xa1, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x6f, 0x70, 0x65, 0x6e,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6f,
+	0x70, 0x65, 0x6e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x6f, 0x70, 0x65, 0x6e, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5b, 0x92, 0x41,
+	0x38, 0x12, 0x36, 0xe9, 0x87, 0x8d, 0xe6, 0x96, 0xb0, 0xe5, 0xbc, 0x80, 0xe5, 0x90, 0xaf, 0xe5,
+	0xbd, 0x93, 0xe5, 0x89, 0x8d, 0xe7, 0x99, 0xbb, 0xe5, 0xbd, 0x95, 0xe7, 0x9a, 0x84, 0xe7, 0x94,
+	0xa8, 0xe6, 0x88, 0xb7, 0xe5, 0xb7, 0xb2, 0xe7, 0xbb, 0x8f, 0xe5, 0x85, 0xb3, 0xe9, 0x97, 0xad,
+	0xe7, 0x9a, 0x84, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x22,
+	0x18, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x69,
+	0x64, 0x7d, 0x2f, 0x72, 0x65, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0xd2, 0x01, 0x0a, 0x12, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x12, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x7d, 0x92, 0x41, 0x59, 0x12, 0x57, 0xe6, 0x8c, 0x89, 0xe7, 0x85, 0xa7, 0xe6, 0x97, 0xb6, 0xe9,
+	0x97, 0xb4, 0xe8, 0x8c, 0x83, 0xe5, 0x9b, 0xb4, 0xe5, 0x88, 0x86, 0xe9, 0xa1, 0xb5, 0xe6, 0x9f,
+	0xa5, 0xe8, 0xaf, 0xa2, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe5, 0xaf, 0xb9,
+	0xe8, 0xb4, 0xa6, 0xe5, 0x8d, 0x95, 0xef, 0xbc, 0x8c, 0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c, 0xe8,
+	0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe5, 0x8f, 0xaf, 0xe4, 0xbb, 0xa5, 0xe6, 0x9f, 0xa5, 0xe8, 0xaf,
+	0xa2, 0xe4, 0xbb, 0xbb, 0xe6, 0x84, 0x8f, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0xe2,
+	0x01, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x74, 0x74, 0x70,
+	0x42, 0x6f, 0x64, 0x79, 0x22, 0x9a, 0x01, 0x92, 0x41, 0x74, 0x12, 0x72, 0xe6, 0x8c, 0x89, 0xe7,
+	0x85, 0xa7, 0xe6, 0x8c, 0x87, 0xe5, 0xae, 0x9a, 0xe6, 0xa0, 0xbc, 0xe5, 0xbc, 0x8f, 0xe5, 0xaf,
+	0xbc, 0xe5, 0x87, 0xba, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe5, 0xaf, 0xb9,
+	0xe8, 0xb4, 0xa6, 0xe5, 0x8d, 0x95, 0xe6, 0x96, 0x87, 0xe4, 0xbb, 0xb6, 0xef, 0xbc, 0x8c, 0xe6,
+	0x96, 0x87, 0xe4, 0xbb, 0xb6, 0xe5, 0x86, 0x85, 0xe5, 0xae, 0xb9, 0xe5, 0x88, 0x86, 0xe5, 0x9d,
+	0x97, 0xe8, 0xbf, 0x94, 0xe5, 0x9b, 0x9e, 0xef, 0xbc, 0x8c, 0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c,
+	0xe8, 0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe5, 0x8f, 0xaf, 0xe4, 0xbb, 0xa5, 0xe5, 0xaf, 0xbc, 0xe5,
+	0x87, 0xba, 0xe4, 0xbb, 0xbb, 0xe6, 0x84, 0x8f, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x30, 0x01, 0x12, 0xba, 0x01, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x12, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x74, 0x92, 0x41, 0x44, 0x12,
+	0x42, 0xe5, 0x90, 0x91, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe5, 0xad, 0x98, 0xe6, 0xac, 0xbe,
+	0xef, 0xbc, 0x8c, 0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c, 0xe8, 0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe5,
+	0x8f, 0xaf, 0xe4, 0xbb, 0xa5, 0xe5, 0x90, 0x91, 0xe4, 0xbb, 0xbb, 0xe6, 0x84, 0x8f, 0xe5, 0xae,
+	0xa2, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe5, 0xad, 0x98,
+	0xe6, 0xac, 0xbe, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x27, 0x3a, 0x01, 0x2a, 0x22, 0x22, 0x2f, 0x76,
+	0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x2f, 0x7b, 0x61, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73,
+	0x12, 0xc6, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x69, 0x74, 0x68, 0x64,
+	0x72, 0x61, 0x77, 0x61, 0x6c, 0x12, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x69,
+	0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x77, 0x92, 0x41, 0x44, 0x12, 0x42, 0xe4, 0xbb, 0x8e, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7,
+	0xe5, 0x8f, 0x96, 0xe6, 0xac, 0xbe, 0xef, 0xbc, 0x8c, 0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c, 0xe8,
+	0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe5, 0x8f, 0xaf, 0xe4, 0xbb, 0xa5, 0xe4, 0xbb, 0x8e, 0xe4, 0xbb,
+	0xbb, 0xe6, 0x84, 0x8f, 0xe5, 0xae, 0xa2, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe8, 0xb4, 0xa6,
+	0xe6, 0x88, 0xb7, 0xe5, 0x8f, 0x96, 0xe6, 0xac, 0xbe, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x2a, 0x3a,
+	0x01, 0x2a, 0x22, 0x25, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x2f, 0x7b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x77, 0x69,
+	0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x73, 0x12, 0xd4, 0x01, 0x0a, 0x14, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x73, 0x12, 0x1f, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x79, 0x92, 0x41, 0x53, 0x12, 0x51, 0xe5, 0x88, 0x86, 0xe9,
+	0xa1, 0xb5, 0xe6, 0x9f, 0xa5, 0xe8, 0xaf, 0xa2, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe8, 0xbd,
+	0xac, 0xe5, 0x87, 0xba, 0xe5, 0x92, 0x8c, 0xe8, 0xbd, 0xac, 0xe5, 0x85, 0xa5, 0xe7, 0x9a, 0x84,
+	0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93, 0xef, 0xbc, 0x8c, 0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c, 0xe8,
+	0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe5, 0x8f, 0xaf, 0xe4, 0xbb, 0xa5, 0xe6, 0x9f, 0xa5, 0xe8, 0xaf,
+	0xa2, 0xe4, 0xbb, 0xbb, 0xe6, 0x84, 0x8f, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73,
+	0x12, 0x97, 0x01, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x12,
+	0x15, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x44,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5a,
+	0x92, 0x41, 0x3e, 0x12, 0x3c, 0xe6, 0x9f, 0xa5, 0xe8, 0xaf, 0xa2, 0xe4, 0xb8, 0x80, 0xe7, 0xac,
+	0x94, 0xe5, 0xad, 0x98, 0xe6, 0xac, 0xbe, 0xef, 0xbc, 0x8c, 0xe5, 0xae, 0xa2, 0xe6, 0x88, 0xb7,
+	0xe5, 0x8f, 0xaa, 0xe8, 0x83, 0xbd, 0xe6, 0x9f, 0xa5, 0xe8, 0xaf, 0xa2, 0xe8, 0x87, 0xaa, 0xe5,
+	0xb7, 0xb1, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe5, 0xad, 0x98, 0xe6, 0xac,
+	0xbe, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x12, 0x11, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0xb0, 0x01, 0x0a, 0x0d, 0x53,
+	0x65, 0x74, 0x74, 0x6c, 0x65, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x12, 0x18, 0x2e, 0x70,
+	0x62, 0x2e, 0x53, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x74, 0x74,
+	0x6c, 0x65, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x6a, 0x92, 0x41, 0x47, 0x12, 0x45, 0xe7, 0xa1, 0xae, 0xe8, 0xae, 0xa4, 0xe7, 0xad,
+	0x89, 0xe5, 0xbe, 0x85, 0xe5, 0x85, 0xa5, 0xe8, 0xb4, 0xa6, 0xe7, 0x9a, 0x84, 0xe5, 0xad, 0x98,
+	0xe6, 0xac, 0xbe, 0xe5, 0xb7, 0xb2, 0xe7, 0xbb, 0x8f, 0xe5, 0x88, 0xb0, 0xe8, 0xb4, 0xa6, 0xef,
+	0xbc, 0x8c, 0xe5, 0x8f, 0xaa, 0xe5, 0x85, 0x81, 0xe8, 0xae, 0xb8, 0xe9, 0x93, 0xb6, 0xe8, 0xa1,
+	0x8c, 0xe8, 0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe8, 0xae, 0xbf, 0xe9, 0x97, 0xae, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1a, 0x22, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x73, 0x65, 0x74, 0x74, 0x6c, 0x65, 0x12, 0xa8, 0x01,
+	0x0a, 0x0b, 0x46, 0x61, 0x69, 0x6c, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x12, 0x16, 0x2e,
+	0x70, 0x62, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x44,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x68,
+	0x92, 0x41, 0x47, 0x12, 0x45, 0xe5, 0xb0, 0x86, 0xe7, 0xad, 0x89, 0xe5, 0xbe, 0x85, 0xe5, 0x85,
+	0xa5, 0xe8, 0xb4, 0xa6, 0xe7, 0x9a, 0x84, 0xe5, 0xad, 0x98, 0xe6, 0xac, 0xbe, 0xe6, 0xa0, 0x87,
+	0xe8, 0xae, 0xb0, 0xe4, 0xb8, 0xba, 0xe5, 0xa4, 0xb1, 0xe8, 0xb4, 0xa5, 0xef, 0xbc, 0x8c, 0xe5,
+	0x8f, 0xaa, 0xe5, 0x85, 0x81, 0xe8, 0xae, 0xb8, 0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c, 0xe8, 0x81,
+	0x8c, 0xe5, 0x91, 0x98, 0xe8, 0xae, 0xbf, 0xe9, 0x97, 0xae, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18,
+	0x22, 0x16, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x2f, 0x7b,
+	0x69, 0x64, 0x7d, 0x2f, 0x66, 0x61, 0x69, 0x6c, 0x12, 0xd8, 0x01, 0x0a, 0x0e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x70, 0x62,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x8e, 0x01, 0x92, 0x41, 0x73, 0x12, 0x71, 0xe4, 0xbb, 0x8e, 0xe5, 0xbd, 0x93,
+	0xe5, 0x89, 0x8d, 0xe7, 0x99, 0xbb, 0xe5, 0xbd, 0x95, 0xe7, 0x9a, 0x84, 0xe7, 0x94, 0xa8, 0xe6,
+	0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe5, 0x90, 0x91, 0xe5, 0x8f,
+	0xa6, 0xe4, 0xb8, 0x80, 0xe4, 0xb8, 0xaa, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe8, 0xbd, 0xac,
+	0xe8, 0xb4, 0xa6, 0xef, 0xbc, 0x8c, 0xe5, 0x8f, 0xaf, 0xe4, 0xbb, 0xa5, 0xe5, 0x9c, 0xa8, 0x20,
+	0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x2d, 0x4b, 0x65, 0x79, 0x20,
+	0xe8, 0xaf, 0xb7, 0xe6, 0xb1, 0x82, 0xe5, 0xa4, 0xb4, 0xe4, 0xb8, 0xad, 0xe6, 0x90, 0xba, 0xe5,
+	0xb8, 0xa6, 0xe5, 0xb9, 0x82, 0xe7, 0xad, 0x89, 0xe9, 0x94, 0xae, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x12, 0x3a, 0x01, 0x2a, 0x22, 0x0d, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x73, 0x12, 0xd2, 0x01, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x62,
+	0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x91, 0x01, 0x92, 0x41, 0x74, 0x12, 0x72, 0xe6, 0x9f, 0xa5, 0xe8,
+	0xaf, 0xa2, 0xe4, 0xb8, 0x80, 0xe7, 0xac, 0x94, 0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93, 0xef, 0xbc,
+	0x8c, 0xe5, 0x8f, 0xaa, 0xe6, 0x9c, 0x89, 0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93, 0xe4, 0xbb, 0xbb,
+	0xe6, 0x84, 0x8f, 0xe4, 0xb8, 0x80, 0xe6, 0x96, 0xb9, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe7,
+	0x9a, 0x84, 0xe6, 0x89, 0x80, 0xe6, 0x9c, 0x89, 0xe8, 0x80, 0x85, 0xe6, 0x89, 0x8d, 0xe8, 0x83,
+	0xbd, 0xe6, 0x9f, 0xa5, 0xe8, 0xaf, 0xa2, 0xef, 0xbc, 0x8c, 0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c,
+	0xe8, 0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe5, 0x8f, 0xaf, 0xe4, 0xbb, 0xa5, 0xe6, 0x9f, 0xa5, 0xe8,
+	0xaf, 0xa2, 0xe4, 0xbb, 0xbb, 0xe6, 0x84, 0x8f, 0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0xd7, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x76,
+	0x65, 0x72, 0x73, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x76, 0x65, 0x72, 0x73, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x8a, 0x01, 0x92, 0x41, 0x62, 0x12, 0x60, 0xe5, 0x86, 0xb2,
+	0xe6, 0xad, 0xa3, 0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93, 0xef, 0xbc, 0x8c, 0xe4, 0xbb, 0x8e, 0xe8,
+	0xbd, 0xac, 0xe5, 0x85, 0xa5, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe6, 0x89, 0xa3, 0xe5, 0x9b,
+	0x9e, 0xe9, 0x87, 0x91, 0xe9, 0xa2, 0x9d, 0xe5, 0xb9, 0xb6, 0xe9, 0x80, 0x80, 0xe8, 0xbf, 0x98,
+	0xe7, 0xbb, 0x99, 0xe8, 0xbd, 0xac, 0xe5, 0x87, 0xba, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xef,
+	0xbc, 0x8c, 0xe5, 0x8f, 0xaa, 0xe5, 0x85, 0x81, 0xe8, 0xae, 0xb8, 0xe9, 0x93, 0xb6, 0xe8, 0xa1,
+	0x8c, 0xe8, 0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe8, 0xae, 0xbf, 0xe9, 0x97, 0xae, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1f, 0x3a, 0x01, 0x2a, 0x22, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x65, 0x76, 0x65, 0x72,
+	0x73, 0x65, 0x12, 0xb0, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63,
+	0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5e, 0x92, 0x41, 0x41, 0x12, 0x3f, 0xe6, 0x9f, 0xa5, 0xe8,
+	0xaf, 0xa2, 0xe6, 0x9c, 0x80, 0xe8, 0xbf, 0x91, 0xe4, 0xb8, 0x80, 0xe6, 0xac, 0xa1, 0xe5, 0xaf,
+	0xb9, 0xe8, 0xb4, 0xa6, 0xe7, 0x9a, 0x84, 0xe7, 0xbb, 0x93, 0xe6, 0x9e, 0x9c, 0xef, 0xbc, 0x8c,
+	0xe5, 0x8f, 0xaa, 0xe5, 0x85, 0x81, 0xe8, 0xae, 0xb8, 0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c, 0xe8,
+	0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe8, 0xae, 0xbf, 0xe9, 0x97, 0xae, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0xa7, 0x01, 0x0a, 0x11, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x63,
+	0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x2e, 0x70, 0x62,
+	0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x62, 0x2e, 0x52,
+	0x75, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x55, 0x92, 0x41, 0x38, 0x12, 0x36, 0xe7,
+	0xab, 0x8b, 0xe5, 0x8d, 0xb3, 0xe8, 0xbf, 0x9b, 0xe8, 0xa1, 0x8c, 0xe4, 0xb8, 0x80, 0xe6, 0xac,
+	0xa1, 0xe5, 0xaf, 0xb9, 0xe8, 0xb4, 0xa6, 0xef, 0xbc, 0x8c, 0xe5, 0x8f, 0xaa, 0xe5, 0x85, 0x81,
+	0xe8, 0xae, 0xb8, 0xe9, 0x93, 0xb6, 0xe8, 0xa1, 0x8c, 0xe8, 0x81, 0x8c, 0xe5, 0x91, 0x98, 0xe8,
+	0xae, 0xbf, 0xe9, 0x97, 0xae, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x22, 0x12, 0x2f, 0x76, 0x31,
+	0x2f, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0xd0, 0x01, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x22, 0x2e, 0x70, 0x62,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x23, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6c, 0x92, 0x41, 0x47, 0x12, 0x45, 0xe5, 0x88, 0x9b, 0xe5, 0xbb,
+	0xba, 0xe4, 0xb8, 0x80, 0xe4, 0xb8, 0xaa, 0xe8, 0xae, 0xa1, 0xe5, 0x88, 0x92, 0xe4, 0xba, 0xa4,
+	0xe6, 0x98, 0x93, 0xef, 0xbc, 0x8c, 0xe5, 0x8f, 0xaa, 0xe6, 0x9c, 0x89, 0xe8, 0xbd, 0xac, 0xe5,
+	0x87, 0xba, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe6, 0x89, 0x80, 0xe6, 0x9c,
+	0x89, 0xe8, 0x80, 0x85, 0xe6, 0x89, 0x8d, 0xe8, 0x83, 0xbd, 0xe5, 0x88, 0x9b, 0xe5, 0xbb, 0xba,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x3a, 0x01, 0x2a, 0x22, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x73,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x73, 0x12, 0xb5, 0x01, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x12, 0x21, 0x2e,
+	0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x22, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x54, 0x92, 0x41, 0x32, 0x12, 0x30, 0xe5, 0x88, 0x86, 0xe9, 0xa1,
+	0xb5, 0xe6, 0x9f, 0xa5, 0xe8, 0xaf, 0xa2, 0xe5, 0xbd, 0x93, 0xe5, 0x89, 0x8d, 0xe7, 0x99, 0xbb,
+	0xe5, 0xbd, 0x95, 0xe7, 0x9a, 0x84, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe8,
+	0xae, 0xa1, 0xe5, 0x88, 0x92, 0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x19, 0x12, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64,
+	0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x12, 0xb4, 0x01, 0x0a, 0x14, 0x47,
+	0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x12, 0x1f, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x59, 0x92, 0x41, 0x32, 0x12, 0x30, 0xe6, 0x9f, 0xa5,
+	0xe8, 0xaf, 0xa2, 0xe5, 0xbd, 0x93, 0xe5, 0x89, 0x8d, 0xe7, 0x99, 0xbb, 0xe5, 0xbd, 0x95, 0xe7,
+	0x9a, 0x84, 0xe7, 0x94, 0xa8, 0xe6, 0x88, 0xb7, 0xe7, 0x9a, 0x84, 0xe4, 0xb8, 0x80, 0xe4, 0xb8,
+	0xaa, 0xe8, 0xae, 0xa1, 0xe5, 0x88, 0x92, 0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1e, 0x12, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0x12, 0xee, 0x01, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x22, 0x2e,
+	0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x23, 0x2e, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x89, 0x01, 0x92, 0x41, 0x5f, 0x12, 0x5d, 0xe4, 0xbf,
+	0xae, 0xe6, 0x94, 0xb9, 0xe8, 0xae, 0xa1, 0xe5, 0x88, 0x92, 0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93,
+	0xe7, 0x9a, 0x84, 0xe9, 0x87, 0x91, 0xe9, 0xa2, 0x9d, 0xe3, 0x80, 0x81, 0xe6, 0x89, 0xa7, 0xe8,
+	0xa1, 0x8c, 0xe8, 0xae, 0xa1, 0xe5, 0x88, 0x92, 0xe3, 0x80, 0x81, 0xe7, 0xbb, 0x93, 0xe6, 0x9d,
+	0x9f, 0xe6, 0x97, 0xb6, 0xe9, 0x97, 0xb4, 0xef, 0xbc, 0x8c, 0xe6, 0x88, 0x96, 0xe8, 0x80, 0x85,
+	0xe6, 0x9a, 0x82, 0xe5, 0x81, 0x9c, 0xe5, 0x92, 0x8c, 0xe6, 0x81, 0xa2, 0xe5, 0xa4, 0x8d, 0xe8,
+	0xae, 0xa1, 0xe5, 0x88, 0x92, 0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x21, 0x3a, 0x01, 0x2a, 0x32, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69,
+	0x64, 0x7d, 0x12, 0xde, 0x01, 0x0a, 0x17, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x12, 0x22,
+	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x7a, 0x92, 0x41, 0x53, 0x12, 0x51, 0xe5, 0x8f,
+	0x96, 0xe6, 0xb6, 0x88, 0xe8, 0xae, 0xa1, 0xe5, 0x88, 0x92, 0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93,
+	0xef, 0xbc, 0x8c, 0xe5, 0x8f, 0x96, 0xe6, 0xb6, 0x88, 0xe4, 0xb9, 0x8b, 0xe5, 0x90, 0x8e, 0xe4,
+	0xb8, 0x8d, 0xe4, 0xbc, 0x9a, 0xe5, 0x86, 0x8d, 0xe6, 0x89, 0xa7, 0xe8, 0xa1, 0x8c, 0xef, 0xbc,
+	0x8c, 0xe4, 0xbd, 0x86, 0xe6, 0x98, 0xaf, 0xe4, 0xbb, 0x8d, 0xe7, 0x84, 0xb6, 0xe4, 0xbf, 0x9d,
+	0xe7, 0x95, 0x99, 0xe6, 0x89, 0xa7, 0xe8, 0xa1, 0x8c, 0xe8, 0xae, 0xb0, 0xe5, 0xbd, 0x95, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x2a, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x2f, 0x7b,
+	0x69, 0x64, 0x7d, 0x12, 0xd7, 0x01, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6e,
+	0x73, 0x12, 0x24, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x75, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x52, 0x75, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6d,
+	0x92, 0x41, 0x41, 0x12, 0x3f, 0xe5, 0x88, 0x86, 0xe9, 0xa1, 0xb5, 0xe6, 0x9f, 0xa5, 0xe8, 0xaf,
+	0xa2, 0xe8, 0xae, 0xa1, 0xe5, 0x88, 0x92, 0xe4, 0xba, 0xa4, 0xe6, 0x98, 0x93, 0xe6, 0xaf, 0x8f,
+	0xe6, 0xac, 0xa1, 0xe6, 0x89, 0xa7, 0xe8, 0xa1, 0x8c, 0xe7, 0x9a, 0x84, 0xe7, 0xbb, 0x93, 0xe6,
+	0x9e, 0x9c, 0xe4, 0xbb, 0xa5, 0xe5, 0x8f, 0x8a, 0xe5, 0xa4, 0xb1, 0xe8, 0xb4, 0xa5, 0xe5, 0x8e,
+	0x9f, 0xe5, 0x9b, 0xa0, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x23, 0x12, 0x21, 0x2f, 0x76, 0x31, 0x2f,
+	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x72, 0x75, 0x6e, 0x73, 0x42, 0x9a, 0x03,
+	0x92, 0x41, 0x87, 0x03, 0x12, 0xa3, 0x02, 0x0a, 0x0f, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x20,
+	0x42, 0x61, 0x6e, 0x6b, 0x20, 0x41, 0x50, 0x49, 0x12, 0x8a, 0x02, 0x52, 0x45, 0x53, 0x54, 0x20,
+	0xe5, 0xae, 0xa2, 0xe6, 0x88, 0xb7, 0xe7, 0xab, 0xaf, 0xe5, 0x92, 0x8c, 0x20, 0x52, 0x50, 0x43,
+	0x20, 0xe5, 0xae, 0xa2, 0xe6, 0x88, 0xb7, 0xe7, 0xab, 0xaf, 0xe5, 0x85, 0xb1, 0xe7, 0x94, 0xa8,
+	0xe7, 0x9a, 0x84, 0xe6, 0x8e, 0xa5, 0xe5, 0x8f, 0xa3, 0xef, 0xbc, 0x8c, 0xe6, 0x89, 0x80, 0xe6,
+	0x9c, 0x89, 0xe8, 0xb7, 0xaf, 0xe7, 0x94, 0xb1, 0xe9, 0x83, 0xbd, 0xe5, 0x9c, 0xa8, 0x20, 0x2f,
+	0x76, 0x31, 0x20, 0xe4, 0xb8, 0x8b, 0xe7, 0x94, 0xb1, 0x20, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x20,
+	0xe6, 0x96, 0x87, 0xe4, 0xbb, 0xb6, 0xe7, 0x94, 0x9f, 0xe6, 0x88, 0x90, 0xe3, 0x80, 0x82, 0xe9,
+	0x87, 0x91, 0xe9, 0xa2, 0x9d, 0xe9, 0x83, 0xbd, 0xe4, 0xbd, 0xbf, 0xe7, 0x94, 0xa8, 0x20, 0x7b,
+	0x22, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x3a, 0x20, 0x22, 0x31, 0x32, 0x2e, 0x33, 0x34,
+	0x22, 0x2c, 0x20, 0x22, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x3a, 0x20, 0x22,
+	0x55, 0x53, 0x44, 0x22, 0x7d, 0x20, 0xe6, 0xa0, 0xbc, 0xe5, 0xbc, 0x8f, 0xe7, 0x9a, 0x84, 0x20,
+	0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x20, 0xef, 0xbc, 0x8c, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x20,
+	0xe6, 0x98, 0xaf, 0xe8, 0xb4, 0xa6, 0xe6, 0x88, 0xb7, 0xe8, 0xb4, 0xa7, 0xe5, 0xb8, 0x81, 0xe7,
+	0x9a, 0x84, 0xe5, 0x8d, 0x81, 0xe8, 0xbf, 0x9b, 0xe5, 0x88, 0xb6, 0xe5, 0xad, 0x97, 0xe7, 0xac,
+	0xa6, 0xe4, 0xb8, 0xb2, 0xe3, 0x80, 0x82, 0xe9, 0x94, 0x99, 0xe8, 0xaf, 0xaf, 0xe5, 0x93, 0x8d,
+	0xe5, 0xba, 0x94, 0xe4, 0xbd, 0xbf, 0xe7, 0x94, 0xa8, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x20, 0xe6, 0xa0, 0xbc, 0xe5,
+	0xbc, 0x8f, 0xe3, 0x80, 0x82, 0x32, 0x03, 0x31, 0x2e, 0x30, 0x5a, 0x51, 0x0a, 0x4f, 0x0a, 0x06,
+	0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x12, 0x45, 0x08, 0x02, 0x12, 0x30, 0xe8, 0xae, 0xbf, 0xe9,
+	0x97, 0xae, 0xe4, 0xbb, 0xa4, 0xe7, 0x89, 0x8c, 0xef, 0xbc, 0x8c, 0xe6, 0xa0, 0xbc, 0xe5, 0xbc,
+	0x8f, 0xe4, 0xb8, 0xba, 0x20, 0x22, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72, 0x20, 0x3c, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x3e, 0x22, 0x1a, 0x0d, 0x41, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x02, 0x62, 0x0c, 0x0a,
+	0x0a, 0x0a, 0x06, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x12, 0x00, 0x5a, 0x0d, 0x53, 0x69, 0x6d,
+	0x70, 0x6c, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var file_service_simple_bank_proto_goTypes = []interface{}{
+	(*CheckHealthRequest)(nil),                // 0: pb.CheckHealthRequest
+	(*CreateUserRequest)(nil),                 // 1: pb.CreateUserRequest
+	(*LoginUserRequest)(nil),                  // 2: pb.LoginUserRequest
+	(*RenewAccessTokenRequest)(nil),           // 3: pb.RenewAccessTokenRequest
+	(*VerifyEmailRequest)(nil),                // 4: pb.VerifyEmailRequest
+	(*UpdateUserRequest)(nil),                 // 5: pb.UpdateUserRequest
+	(*ListUserAccountsRequest)(nil),           // 6: pb.ListUserAccountsRequest
+	(*BlockSessionRequest)(nil),               // 7: pb.BlockSessionRequest
+	(*CreateAccountRequest)(nil),              // 8: pb.CreateAccountRequest
+	(*GetAccountRequest)(nil),                 // 9: pb.GetAccountRequest
+	(*ListAccountsRequest)(nil),               // 10: pb.ListAccountsRequest
+	(*FreezeAccountRequest)(nil),              // 11: pb.FreezeAccountRequest
+	(*UnfreezeAccountRequest)(nil),            // 12: pb.UnfreezeAccountRequest
+	(*CloseAccountRequest)(nil),               // 13: pb.CloseAccountRequest
+	(*ReopenAccountRequest)(nil),              // 14: pb.ReopenAccountRequest
+	(*ListAccountEntriesRequest)(nil),         // 15: pb.ListAccountEntriesRequest
+	(*ExportStatementRequest)(nil),            // 16: pb.ExportStatementRequest
+	(*CreateDepositRequest)(nil),              // 17: pb.CreateDepositRequest
+	(*CreateWithdrawalRequest)(nil),           // 18: pb.CreateWithdrawalRequest
+	(*ListAccountTransfersRequest)(nil),       // 19: pb.ListAccountTransfersRequest
+	(*GetDepositRequest)(nil),                 // 20: pb.GetDepositRequest
+	(*SettleDepositRequest)(nil),              // 21: pb.SettleDepositRequest
+	(*FailDepositRequest)(nil),                // 22: pb.FailDepositRequest
+	(*CreateTransferRequest)(nil),             // 23: pb.CreateTransferRequest
+	(*GetTransferRequest)(nil),                // 24: pb.GetTransferRequest
+	(*ReverseTransferRequest)(nil),            // 25: pb.ReverseTransferRequest
+	(*GetReconciliationRequest)(nil),          // 26: pb.GetReconciliationRequest
+	(*RunReconciliationRequest)(nil),          // 27: pb.RunReconciliationRequest
+	(*CreateScheduledTransferRequest)(nil),    // 28: pb.CreateScheduledTransferRequest
+	(*ListScheduledTransfersRequest)(nil),     // 29: pb.ListScheduledTransfersRequest
+	(*GetScheduledTransferRequest)(nil),       // 30: pb.GetScheduledTransferRequest
+	(*UpdateScheduledTransferRequest)(nil),    // 31: pb.UpdateScheduledTransferRequest
+	(*CancelScheduledTransferRequest)(nil),    // 32: pb.CancelScheduledTransferRequest
+	(*ListScheduledTransferRunsRequest)(nil),  // 33: pb.ListScheduledTransferRunsRequest
+	(*CheckHealthResponse)(nil),               // 34: pb.CheckHealthResponse
+	(*CreateUserResponse)(nil),                // 35: pb.CreateUserResponse
+	(*LoginUserResponse)(nil),                 // 36: pb.LoginUserResponse
+	(*RenewAccessTokenResponse)(nil),          // 37: pb.RenewAccessTokenResponse
+	(*VerifyEmailResponse)(nil),               // 38: pb.VerifyEmailResponse
+	(*UpdateUserResponse)(nil),                // 39: pb.UpdateUserResponse
+	(*ListAccountsResponse)(nil),              // 40: pb.ListAccountsResponse
+	(*BlockSessionResponse)(nil),              // 41: pb.BlockSessionResponse
+	(*CreateAccountResponse)(nil),             // 42: pb.CreateAccountResponse
+	(*GetAccountResponse)(nil),                // 43: pb.GetAccountResponse
+	(*FreezeAccountResponse)(nil),             // 44: pb.FreezeAccountResponse
+	(*UnfreezeAccountResponse)(nil),           // 45: pb.UnfreezeAccountResponse
+	(*CloseAccountResponse)(nil),              // 46: pb.CloseAccountResponse
+	(*ReopenAccountResponse)(nil),             // 47: pb.ReopenAccountResponse
+	(*ListAccountEntriesResponse)(nil),        // 48: pb.ListAccountEntriesResponse
+	(*httpbody.HttpBody)(nil),                 // 49: google.api.HttpBody
+	(*CreateDepositResponse)(nil),             // 50: pb.CreateDepositResponse
+	(*CreateWithdrawalResponse)(nil),          // 51: pb.CreateWithdrawalResponse
+	(*ListAccountTransfersResponse)(nil),      // 52: pb.ListAccountTransfersResponse
+	(*GetDepositResponse)(nil),                // 53: pb.GetDepositResponse
+	(*SettleDepositResponse)(nil),             // 54: pb.SettleDepositResponse
+	(*FailDepositResponse)(nil),               // 55: pb.FailDepositResponse
+	(*CreateTransferResponse)(nil),            // 56: pb.CreateTransferResponse
+	(*GetTransferResponse)(nil),               // 57: pb.GetTransferResponse
+	(*ReverseTransferResponse)(nil),           // 58: pb.ReverseTransferResponse
+	(*GetReconciliationResponse)(nil),         // 59: pb.GetReconciliationResponse
+	(*RunReconciliationResponse)(nil),         // 60: pb.RunReconciliationResponse
+	(*CreateScheduledTransferResponse)(nil),   // 61: pb.CreateScheduledTransferResponse
+	(*ListScheduledTransfersResponse)(nil),    // 62: pb.ListScheduledTransfersResponse
+	(*GetScheduledTransferResponse)(nil),      // 63: pb.GetScheduledTransferResponse
+	(*UpdateScheduledTransferResponse)(nil),   // 64: pb.UpdateScheduledTransferResponse
+	(*CancelScheduledTransferResponse)(nil),   // 65: pb.CancelScheduledTransferResponse
+	(*ListScheduledTransferRunsResponse)(nil), // 66: pb.ListScheduledTransferRunsResponse
+}
+var file_service_simple_bank_proto_depIdxs = []int32{
+	0,  // 0: pb.SimpleBank.CheckHealth:input_type -> pb.CheckHealthRequest
+	1,  // 1: pb.SimpleBank.CreateUser:input_type -> pb.CreateUserRequest
+	2,  // 2: pb.SimpleBank.LoginUser:input_type -> pb.LoginUserRequest
+	3,  // 3: pb.SimpleBank.RenewAccessToken:input_type -> pb.RenewAccessTokenRequest
+	4,  // 4: pb.SimpleBank.VerifyEmail:input_type -> pb.VerifyEmailRequest
+	5,  // 5: pb.SimpleBank.UpdateUser:input_type -> pb.UpdateUserRequest
+	6,  // 6: pb.SimpleBank.ListUserAccounts:input_type -> pb.ListUserAccountsRequest
+	7,  // 7: pb.SimpleBank.BlockSession:input_type -> pb.BlockSessionRequest
+	8,  // 8: pb.SimpleBank.CreateAccount:input_type -> pb.CreateAccountRequest
+	9,  // 9: pb.SimpleBank.GetAccount:input_type -> pb.GetAccountRequest
+	10, // 10: pb.SimpleBank.ListAccounts:input_type -> pb.ListAccountsRequest
+	11, // 11: pb.SimpleBank.FreezeAccount:input_type -> pb.FreezeAccountRequest
+	12, // 12: pb.SimpleBank.UnfreezeAccount:input_type -> pb.UnfreezeAccountRequest
+	13, // 13: pb.SimpleBank.CloseAccount:input_type -> pb.CloseAccountRequest
+	14, // 14: pb.SimpleBank.ReopenAccount:input_type -> pb.ReopenAccountRequest
+	15, // 15: pb.SimpleBank.ListAccountEntries:input_type -> pb.ListAccountEntriesRequest
+	16, // 16: pb.SimpleBank.ExportStatement:input_type -> pb.ExportStatementRequest
+	17, // 17: pb.SimpleBank.CreateDeposit:input_type -> pb.CreateDepositRequest
+	18, // 18: pb.SimpleBank.CreateWithdrawal:input_type -> pb.CreateWithdrawalRequest
+	19, // 19: pb.SimpleBank.ListAccountTransfers:input_type -> pb.ListAccountTransfersRequest
+	20, // 20: pb.SimpleBank.GetDeposit:input_type -> pb.GetDepositRequest
+	21, // 21: pb.SimpleBank.SettleDeposit:input_type -> pb.SettleDepositRequest
+	22, // 22: pb.SimpleBank.FailDeposit:input_type -> pb.FailDepositRequest
+	23, // 23: pb.SimpleBank.CreateTransfer:input_type -> pb.CreateTransferRequest
+	24, // 24: pb.SimpleBank.GetTransfer:input_type -> pb.GetTransferRequest
+	25, // 25: pb.SimpleBank.ReverseTransfer:input_type -> pb.ReverseTransferRequest
+	26, // 26: pb.SimpleBank.GetReconciliation:input_type -> pb.GetReconciliationRequest
+	27, // 27: pb.SimpleBank.RunReconciliation:input_type -> pb.RunReconciliationRequest
+	28, // 28: pb.SimpleBank.CreateScheduledTransfer:input_type -> pb.CreateScheduledTransferRequest
+	29, // 29: pb.SimpleBank.ListScheduledTransfers:input_type -> pb.ListScheduledTransfersRequest
+	30, // 30: pb.SimpleBank.GetScheduledTransfer:input_type -> pb.GetScheduledTransferRequest
+	31, // 31: pb.SimpleBank.UpdateScheduledTransfer:input_type -> pb.UpdateScheduledTransferRequest
+	32, // 32: pb.SimpleBank.CancelScheduledTransfer:input_type -> pb.CancelScheduledTransferRequest
+	33, // 33: pb.SimpleBank.ListScheduledTransferRuns:input_type -> pb.ListScheduledTransferRunsRequest
+	34, // 34: pb.SimpleBank.CheckHealth:output_type -> pb.CheckHealthResponse
+	35, // 35: pb.SimpleBank.CreateUser:output_type -> pb.CreateUserResponse
+	36, // 36: pb.SimpleBank.LoginUser:output_type -> pb.LoginUserResponse
+	37, // 37: pb.SimpleBank.RenewAccessToken:output_type -> pb.RenewAccessTokenResponse
+	38, // 38: pb.SimpleBank.VerifyEmail:output_type -> pb.VerifyEmailResponse
+	39, // 39: pb.SimpleBank.UpdateUser:output_type -> pb.UpdateUserResponse
+	40, // 40: pb.SimpleBank.ListUserAccounts:output_type -> pb.ListAccountsResponse
+	41, // 41: pb.SimpleBank.BlockSession:output_type -> pb.BlockSessionResponse
+	42, // 42: pb.SimpleBank.CreateAccount:output_type -> pb.CreateAccountResponse
+	43, // 43: pb.SimpleBank.GetAccount:output_type -> pb.GetAccountResponse
+	40, // 44: pb.SimpleBank.ListAccounts:output_type -> pb.ListAccountsResponse
+	44, // 45: pb.SimpleBank.FreezeAccount:output_type -> pb.FreezeAccountResponse
+	45, // 46: pb.SimpleBank.UnfreezeAccount:output_type -> pb.UnfreezeAccountResponse
+	46, // 47: pb.SimpleBank.CloseAccount:output_type -> pb.CloseAccountResponse
+	47, // 48: pb.SimpleBank.ReopenAccount:output_type -> pb.ReopenAccountResponse
+	48, // 49: pb.SimpleBank.ListAccountEntries:output_type -> pb.ListAccountEntriesResponse
+	49, // 50: pb.SimpleBank.ExportStatement:output_type -> google.api.HttpBody
+	50, // 51: pb.SimpleBank.CreateDeposit:output_type -> pb.CreateDepositResponse
+	51, // 52: pb.SimpleBank.CreateWithdrawal:output_type -> pb.CreateWithdrawalResponse
+	52, // 53: pb.SimpleBank.ListAccountTransfers:output_type -> pb.ListAccountTransfersResponse
+	53, // 54: pb.SimpleBank.GetDeposit:output_type -> pb.GetDepositResponse
+	54, // 55: pb.SimpleBank.SettleDeposit:output_type -> pb.SettleDepositResponse
+	55, // 56: pb.SimpleBank.FailDeposit:output_type -> pb.FailDepositResponse
+	56, // 57: pb.SimpleBank.CreateTransfer:output_type -> pb.CreateTransferResponse
+	57, // 58: pb.SimpleBank.GetTransfer:output_type -> pb.GetTransferResponse
+	58, // 59: pb.SimpleBank.ReverseTransfer:output_type -> pb.ReverseTransferResponse
+	59, // 60: pb.SimpleBank.GetReconciliation:output_type -> pb.GetReconciliationResponse
+	60, // 61: pb.SimpleBank.RunReconciliation:output_type -> pb.RunReconciliationResponse
+	61, // 62: pb.SimpleBank.CreateScheduledTransfer:output_type -> pb.CreateScheduledTransferResponse
+	62, // 63: pb.SimpleBank.ListScheduledTransfers:output_type -> pb.ListScheduledTransfersResponse
+	63, // 64: pb.SimpleBank.GetScheduledTransfer:output_type -> pb.GetScheduledTransferResponse
+	64, // 65: pb.SimpleBank.UpdateScheduledTransfer:output_type -> pb.UpdateScheduledTransferResponse
+	65, // 66: pb.SimpleBank.CancelScheduledTransfer:output_type -> pb.CancelScheduledTransferResponse
+	66, // 67: pb.SimpleBank.ListScheduledTransferRuns:output_type -> pb.ListScheduledTransferRunsResponse
+	34, // [34:68] is the sub-list for method output_type
+	0,  // [0:34] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_service_simple_bank_proto_init() }
+func file_service_simple_bank_proto_init() {
+	if File_service_simple_bank_proto != nil {
+		return
+	}
+	file_rpc_account_proto_init()
+	file_rpc_account_status_proto_init()
+	file_rpc_deposit_proto_init()
+	file_rpc_health_proto_init()
+	file_rpc_reconciliation_proto_init()
+	file_rpc_scheduled_transfer_proto_init()
+	file_rpc_session_proto_init()
+	file_rpc_statement_proto_init()
+	file_rpc_token_proto_init()
+	file_rpc_transfer_proto_init()
+	file_rpc_user_proto_init()
+	file_rpc_verify_email_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_service_simple_bank_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_service_simple_bank_proto_goTypes,
+		DependencyIndexes: file_service_simple_bank_proto_depIdxs,
+	}.Build()
+	File_service_simple_bank_proto = out.File
+	file_service_simple_bank_proto_rawDesc = nil
+	file_service_simple_bank_proto_goTypes = nil
+	file_service_simple_bank_proto_depIdxs = nil
+}
@@ -0,0 +1,61 @@
+package reconcile
+
+import (
+	db "SimpleBank/db/sqlc"
+	"context"
+	"log"
+	"time"
+)
+
+// Reconciler 定期根据所有条目重新计算账户的余额，记录并报告余额与条目之和不一致的账户
+// 对账只读取账户和条目，不修改余额，出现差异时需要人工排查原因
+type Reconciler struct {
+	store    db.Store
+	interval time.Duration
+}
+
+// New 创建一个每隔 interval 对账一次的 Reconciler
+func New(store db.Store, interval time.Duration) *Reconciler {
+	return &Reconciler{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run 每隔 interval 对账一次，直到 ctx 被取消，interval 不大于 0 时不执行
+func (reconciler *Reconciler) Run(ctx context.Context) {
+	if reconciler.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(reconciler.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := reconciler.Reconcile(ctx); err != nil {
+				log.Println("cannot reconcile balances:", err)
+			}
+		}
+	}
+}
+
+// Reconcile 执行一次对账并在日志中报告每个出现差异的账户
+func (reconciler *Reconciler) Reconcile(ctx context.Context) (db.ReconciliationResult, error) {
+	result, err := reconciler.store.ReconcileBalancesTx(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, drift := range result.Drifts {
+		log.Printf(
+			"balance drift in account [%d] owned by %s: balance %d %s, entries sum to %d %s, difference %d",
+			drift.AccountID, drift.Owner, drift.Balance, drift.Currency, drift.EntriesBalance, drift.Currency, drift.Balance-drift.EntriesBalance,
+		)
+	}
+	log.Printf("reconciliation run [%d] checked %d accounts, found %d drifts", result.Run.ID, result.Run.AccountsChecked, result.Run.DriftCount)
+	return result, nil
+}
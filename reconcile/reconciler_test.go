@@ -0,0 +1,76 @@
+package reconcile
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile(t *testing.T) {
+	drifted := db.ReconciliationResult{
+		Run: db.ReconciliationRun{ID: 1, AccountsChecked: 10, DriftCount: 1},
+		Drifts: []db.ListBalanceDriftsRow{
+			{RunID: 1, AccountID: 2, Balance: 100, EntriesBalance: 90, Owner: "owner", Currency: "USD"},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		result     db.ReconciliationResult
+		hasError   bool
+	}{
+		{
+			name: "NoDrift",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReconcileBalancesTx(gomock.Any()).
+					Times(1).
+					Return(db.ReconciliationResult{Run: db.ReconciliationRun{ID: 1, AccountsChecked: 10}}, nil)
+			},
+			result: db.ReconciliationResult{Run: db.ReconciliationRun{ID: 1, AccountsChecked: 10}},
+		},
+		{
+			// 出现差异的账户会被报告，但是对账本身不算失败
+			name: "Drift",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReconcileBalancesTx(gomock.Any()).Times(1).Return(drifted, nil)
+			},
+			result: drifted,
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReconcileBalancesTx(gomock.Any()).
+					Times(1).
+					Return(db.ReconciliationResult{}, errors.New("connection refused"))
+			},
+			hasError: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			result, err := New(store, time.Minute).Reconcile(context.Background())
+			if tc.hasError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	db "SimpleBank/db/sqlc"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// Scheduler 定期执行已经到期的计划交易
+// 每条计划交易在执行时都会被行锁锁定，其他实例会跳过被锁定的行，因此多个服务器实例可以同时运行 Scheduler
+type Scheduler struct {
+	store    db.Store
+	interval time.Duration
+	// 返回当前时间，便于测试时固定时间
+	now func() time.Time
+}
+
+// New 创建一个每隔 interval 检查一次到期的计划交易的 Scheduler
+func New(store db.Store, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		store:    store,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+// Run 每隔 interval 执行一次所有到期的计划交易，直到 ctx 被取消，interval 不大于 0 时不执行
+func (scheduler *Scheduler) Run(ctx context.Context) {
+	if scheduler.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(scheduler.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			executed, err := scheduler.RunDue(ctx)
+			if err != nil {
+				log.Println("cannot execute scheduled transfers:", err)
+			}
+			if executed > 0 {
+				log.Printf("executed %d scheduled transfers", executed)
+			}
+		}
+	}
+}
+
+// RunDue 依次执行所有在当前时间之前到期的计划交易，返回执行的数量（包括交易失败的执行）
+func (scheduler *Scheduler) RunDue(ctx context.Context) (int, error) {
+	// 每次执行之后下一次执行的时间都会晚于 now ，因此循环一定会结束
+	now := scheduler.now()
+
+	executed := 0
+	for {
+		result, err := scheduler.store.ExecuteDueScheduledTransferTx(ctx, now)
+		if err != nil {
+			// 没有到期的计划交易，或者剩下的都已经被其他实例锁定
+			if errors.Is(err, pgx.ErrNoRows) {
+				return executed, nil
+			}
+			return executed, err
+		}
+		executed++
+
+		if result.Run.Status == db.ScheduledTransferRunFailed {
+			log.Printf("scheduled transfer [%d] failed: %s", result.ScheduledTransfer.ID, result.Run.FailureReason)
+		}
+	}
+}
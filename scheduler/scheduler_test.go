@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDue(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		executed   int
+		hasError   bool
+	}{
+		{
+			// 执行所有到期的计划交易，包括交易失败的计划交易，直到没有到期的计划交易
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().ExecuteDueScheduledTransferTx(gomock.Any(), gomock.Eq(now)).
+						Return(db.ExecuteScheduledTransferTxResult{Run: db.ScheduledTransferRun{Status: db.ScheduledTransferRunSucceeded}}, nil),
+					store.EXPECT().ExecuteDueScheduledTransferTx(gomock.Any(), gomock.Eq(now)).
+						Return(db.ExecuteScheduledTransferTxResult{Run: db.ScheduledTransferRun{Status: db.ScheduledTransferRunFailed}}, nil),
+					store.EXPECT().ExecuteDueScheduledTransferTx(gomock.Any(), gomock.Eq(now)).
+						Return(db.ExecuteScheduledTransferTxResult{}, pgx.ErrNoRows),
+				)
+			},
+			executed: 2,
+		},
+		{
+			name: "NothingDue",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecuteDueScheduledTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteScheduledTransferTxResult{}, pgx.ErrNoRows)
+			},
+			executed: 0,
+		},
+		{
+			// 数据库出错时停止执行，等待下一次轮询
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExecuteDueScheduledTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExecuteScheduledTransferTxResult{}, errors.New("connection refused"))
+			},
+			executed: 0,
+			hasError: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			scheduler := New(store, time.Minute)
+			scheduler.now = func() time.Time { return now }
+
+			executed, err := scheduler.RunDue(context.Background())
+			require.Equal(t, tc.executed, executed)
+			if tc.hasError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
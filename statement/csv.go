@@ -0,0 +1,54 @@
+package statement
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+)
+
+// csvEncoder 将对账单写入为 CSV 格式
+type csvEncoder struct {
+	writer *csv.Writer
+	stmt   Statement
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{writer: csv.NewWriter(w)}
+}
+
+// Begin 写入期初余额和表头
+func (enc *csvEncoder) Begin(stmt Statement) error {
+	enc.stmt = stmt
+	records := [][]string{
+		{"account_id", fmt.Sprint(stmt.AccountID)},
+		{"currency", stmt.Currency},
+		{"start_time", stmt.StartTime.UTC().Format(time.RFC3339)},
+		{"end_time", stmt.EndTime.UTC().Format(time.RFC3339)},
+		{"opening_balance", stmt.formatAmount(stmt.OpeningBalance)},
+		{},
+		{"entry_id", "created_at", "transfer_id", "counterparty_account_id", "amount", "balance"},
+	}
+	return enc.writer.WriteAll(records)
+}
+
+// WriteLine 写入一条条目
+func (enc *csvEncoder) WriteLine(line Line) error {
+	return enc.writer.Write([]string{
+		fmt.Sprint(line.EntryID),
+		line.CreatedAt.UTC().Format(time.RFC3339),
+		formatOptionalID(line.TransferID),
+		formatOptionalID(line.CounterpartyAccountID),
+		enc.stmt.formatAmount(line.Amount),
+		enc.stmt.formatAmount(line.RunningBalance),
+	})
+}
+
+// End 写入期末余额
+func (enc *csvEncoder) End() error {
+	records := [][]string{
+		{},
+		{"closing_balance", enc.stmt.formatAmount(enc.stmt.ClosingBalance)},
+	}
+	return enc.writer.WriteAll(records)
+}
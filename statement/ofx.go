@@ -0,0 +1,101 @@
+package statement
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ofxBankID 是导出的 OFX 文件中银行的标识
+const ofxBankID = "SIMPLEBANK"
+
+// ofxEncoder 将对账单写入为 OFX 2.2 格式
+type ofxEncoder struct {
+	writer *bufio.Writer
+	stmt   Statement
+}
+
+func newOFXEncoder(w io.Writer) *ofxEncoder {
+	return &ofxEncoder{writer: bufio.NewWriter(w)}
+}
+
+// Begin 写入 OFX 的头部、账户信息和交易列表的开头
+func (enc *ofxEncoder) Begin(stmt Statement) error {
+	enc.stmt = stmt
+	fmt.Fprint(enc.writer, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`+"\n")
+	fmt.Fprint(enc.writer, `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>`+"\n")
+	fmt.Fprint(enc.writer, "<OFX>\n")
+	fmt.Fprint(enc.writer, "<SIGNONMSGSRSV1><SONRS>\n")
+	fmt.Fprint(enc.writer, "<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	fmt.Fprintf(enc.writer, "<DTSERVER>%s</DTSERVER>\n", formatOFXTime(time.Now()))
+	fmt.Fprint(enc.writer, "<LANGUAGE>ENG</LANGUAGE>\n")
+	fmt.Fprint(enc.writer, "</SONRS></SIGNONMSGSRSV1>\n")
+	fmt.Fprint(enc.writer, "<BANKMSGSRSV1><STMTTRNRS>\n")
+	fmt.Fprint(enc.writer, "<TRNUID>0</TRNUID>\n")
+	fmt.Fprint(enc.writer, "<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	fmt.Fprint(enc.writer, "<STMTRS>\n")
+	fmt.Fprintf(enc.writer, "<CURDEF>%s</CURDEF>\n", escapeXML(stmt.Currency))
+	fmt.Fprintf(enc.writer, "<BANKACCTFROM><BANKID>%s</BANKID><ACCTID>%d</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n", ofxBankID, stmt.AccountID)
+	fmt.Fprint(enc.writer, "<BANKTRANLIST>\n")
+	fmt.Fprintf(enc.writer, "<DTSTART>%s</DTSTART>\n", formatOFXTime(stmt.StartTime))
+	_, err := fmt.Fprintf(enc.writer, "<DTEND>%s</DTEND>\n", formatOFXTime(stmt.EndTime))
+	return err
+}
+
+// WriteLine 将一条条目写入为 STMTTRN 元素
+func (enc *ofxEncoder) WriteLine(line Line) error {
+	trnType := "CREDIT"
+	if line.Amount < 0 {
+		trnType = "DEBIT"
+	}
+
+	fmt.Fprint(enc.writer, "<STMTTRN>\n")
+	fmt.Fprintf(enc.writer, "<TRNTYPE>%s</TRNTYPE>\n", trnType)
+	fmt.Fprintf(enc.writer, "<DTPOSTED>%s</DTPOSTED>\n", formatOFXTime(line.CreatedAt))
+	fmt.Fprintf(enc.writer, "<TRNAMT>%s</TRNAMT>\n", enc.stmt.formatAmount(line.Amount))
+	fmt.Fprintf(enc.writer, "<FITID>%d</FITID>\n", line.EntryID)
+	if line.CounterpartyAccountID != nil {
+		// 对方账户作为收款人或付款人的名称
+		fmt.Fprintf(enc.writer, "<NAME>Account %d</NAME>\n", *line.CounterpartyAccountID)
+	}
+	switch {
+	case line.ReversalID != nil && line.TransferID != nil:
+		fmt.Fprintf(enc.writer, "<MEMO>Reversal of transfer %d</MEMO>\n", *line.TransferID)
+	case line.TransferID != nil:
+		fmt.Fprintf(enc.writer, "<MEMO>Transfer %d</MEMO>\n", *line.TransferID)
+	}
+	_, err := fmt.Fprint(enc.writer, "</STMTTRN>\n")
+	return err
+}
+
+// End 写入期末余额和期初余额，并关闭所有的元素
+func (enc *ofxEncoder) End() error {
+	fmt.Fprint(enc.writer, "</BANKTRANLIST>\n")
+	fmt.Fprintf(enc.writer, "<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n",
+		enc.stmt.formatAmount(enc.stmt.ClosingBalance), formatOFXTime(enc.stmt.EndTime))
+	// OFX 中没有期初余额的元素，使用 BALLIST 记录期初余额
+	fmt.Fprint(enc.writer, "<BALLIST><BAL>\n")
+	fmt.Fprint(enc.writer, "<NAME>Opening balance</NAME><DESC>Balance at DTSTART</DESC><BALTYPE>DOLLAR</BALTYPE>\n")
+	fmt.Fprintf(enc.writer, "<VALUE>%s</VALUE><DTASOF>%s</DTASOF>\n",
+		enc.stmt.formatAmount(enc.stmt.OpeningBalance), formatOFXTime(enc.stmt.StartTime))
+	fmt.Fprint(enc.writer, "</BAL></BALLIST>\n")
+	fmt.Fprint(enc.writer, "</STMTRS>\n")
+	fmt.Fprint(enc.writer, "</STMTTRNRS></BANKMSGSRSV1>\n")
+	fmt.Fprint(enc.writer, "</OFX>\n")
+	return enc.writer.Flush()
+}
+
+// formatOFXTime 将时间格式化为 OFX 使用的 UTC 时间格式，例如 20231001120000.000[0:GMT]
+func formatOFXTime(t time.Time) string {
+	return t.UTC().Format("20060102150405.000") + "[0:GMT]"
+}
+
+// escapeXML 转义 XML 文本中的特殊字符
+func escapeXML(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
@@ -0,0 +1,186 @@
+package statement
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// PDF 页面的布局参数，单位为 point ，页面大小为 A4
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 50
+	pdfFontSize     = 9
+	pdfLineHeight   = 13
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+)
+
+// 预留的 PDF 对象编号，页面树对象在所有页面写完之后才写入
+const (
+	pdfCatalogObject = 1
+	pdfPagesObject   = 2
+	pdfFontObject    = 3
+)
+
+// 每一列文本左侧的 x 坐标
+var pdfColumns = []int{pdfMargin, 110, 240, 320, 410, 490}
+
+// pdfEncoder 将对账单写入为 PDF 格式，每写满一页就立即输出该页
+type pdfEncoder struct {
+	writer *countingWriter
+	stmt   Statement
+	// 每个对象在文件中的字节偏移量，下标为对象编号
+	offsets []int64
+	// 所有页面对象的编号
+	pages []int
+	// 当前页面中还没有输出的文本行
+	lines []pdfLine
+}
+
+// pdfLine 是页面中的一行文本，每个字段对应一列
+type pdfLine []string
+
+func newPDFEncoder(w io.Writer) *pdfEncoder {
+	return &pdfEncoder{
+		writer: &countingWriter{writer: bufio.NewWriter(w)},
+		// 对象编号从 1 开始，先为预留的对象占位
+		offsets: make([]int64, pdfFontObject+1),
+	}
+}
+
+// Begin 写入 PDF 的文件头、目录和字体对象，并在第一页写入账户信息和期初余额
+func (enc *pdfEncoder) Begin(stmt Statement) error {
+	enc.stmt = stmt
+	fmt.Fprint(enc.writer, "%PDF-1.4\n")
+
+	enc.beginObject(pdfCatalogObject)
+	fmt.Fprintf(enc.writer, "<< /Type /Catalog /Pages %d 0 R >>\nendobj\n", pdfPagesObject)
+	enc.beginObject(pdfFontObject)
+	fmt.Fprint(enc.writer, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>\nendobj\n")
+
+	enc.lines = append(enc.lines,
+		pdfLine{"Account statement"},
+		pdfLine{fmt.Sprintf("Account: %d (%s)", stmt.AccountID, stmt.Owner)},
+		pdfLine{"Currency: " + stmt.Currency},
+		pdfLine{fmt.Sprintf("Period: %s - %s", stmt.StartTime.UTC().Format(time.RFC3339), stmt.EndTime.UTC().Format(time.RFC3339))},
+		pdfLine{"Opening balance: " + stmt.formatAmount(stmt.OpeningBalance)},
+		pdfLine{},
+		pdfLine{"Date", "Entry", "Transfer", "Counterparty", "Amount", "Balance"},
+	)
+	return enc.writer.err
+}
+
+// WriteLine 写入一条条目，当前页写满时输出该页
+func (enc *pdfEncoder) WriteLine(line Line) error {
+	enc.lines = append(enc.lines, pdfLine{
+		line.CreatedAt.UTC().Format("2006-01-02 15:04:05"),
+		fmt.Sprint(line.EntryID),
+		formatOptionalID(line.TransferID),
+		formatOptionalID(line.CounterpartyAccountID),
+		enc.stmt.formatAmount(line.Amount),
+		enc.stmt.formatAmount(line.RunningBalance),
+	})
+
+	if len(enc.lines) >= pdfLinesPerPage {
+		enc.flushPage()
+	}
+	return enc.writer.err
+}
+
+// End 写入期末余额，然后写入页面树、交叉引用表和文件尾
+func (enc *pdfEncoder) End() error {
+	enc.lines = append(enc.lines, pdfLine{}, pdfLine{"Closing balance: " + enc.stmt.formatAmount(enc.stmt.ClosingBalance)})
+	enc.flushPage()
+
+	// 所有页面都已经写入，此时才能写入包含所有页面的页面树
+	enc.beginObject(pdfPagesObject)
+	kids := make([]string, len(enc.pages))
+	for i, page := range enc.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+	fmt.Fprintf(enc.writer, "<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(enc.pages))
+
+	// 交叉引用表记录每个对象的字节偏移量
+	xrefOffset := enc.writer.count
+	fmt.Fprintf(enc.writer, "xref\n0 %d\n", len(enc.offsets))
+	fmt.Fprint(enc.writer, "0000000000 65535 f \n")
+	for _, offset := range enc.offsets[1:] {
+		fmt.Fprintf(enc.writer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(enc.writer, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(enc.offsets), pdfCatalogObject, xrefOffset)
+
+	if enc.writer.err != nil {
+		return enc.writer.err
+	}
+	return enc.writer.writer.Flush()
+}
+
+// flushPage 将当前页面的所有文本行写入为一个内容流对象和一个页面对象
+func (enc *pdfEncoder) flushPage() {
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT\n/F1 %d Tf\n", pdfFontSize)
+	for i, line := range enc.lines {
+		y := pdfPageHeight - pdfMargin - (i+1)*pdfLineHeight
+		for col, text := range line {
+			if text == "" {
+				continue
+			}
+			// 每一段文本都使用绝对坐标定位
+			fmt.Fprintf(&content, "1 0 0 1 %d %d Tm (%s) Tj\n", pdfColumns[col], y, escapePDFText(text))
+		}
+	}
+	fmt.Fprint(&content, "ET\n")
+	enc.lines = enc.lines[:0]
+
+	contentObject := enc.newObject()
+	fmt.Fprintf(enc.writer, "<< /Length %d >>\nstream\n", content.Len())
+	enc.writer.Write(content.Bytes())
+	fmt.Fprint(enc.writer, "\nendstream\nendobj\n")
+
+	pageObject := enc.newObject()
+	fmt.Fprintf(enc.writer,
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+		pdfPagesObject, pdfPageWidth, pdfPageHeight, pdfFontObject, contentObject)
+	enc.pages = append(enc.pages, pageObject)
+}
+
+// newObject 分配一个新的对象编号并开始写入该对象
+func (enc *pdfEncoder) newObject() int {
+	enc.offsets = append(enc.offsets, 0)
+	number := len(enc.offsets) - 1
+	enc.beginObject(number)
+	return number
+}
+
+// beginObject 记录对象的偏移量并写入对象的开头
+func (enc *pdfEncoder) beginObject(number int) {
+	enc.offsets[number] = enc.writer.count
+	fmt.Fprintf(enc.writer, "%d 0 obj\n", number)
+}
+
+// escapePDFText 转义 PDF 字符串中的特殊字符
+func escapePDFText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+	return replacer.Replace(text)
+}
+
+// countingWriter 记录已经写入的字节数，并保存第一次写入时产生的错误
+type countingWriter struct {
+	writer *bufio.Writer
+	count  int64
+	err    error
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err := w.writer.Write(p)
+	w.count += int64(n)
+	w.err = err
+	return n, err
+}
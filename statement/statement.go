@@ -0,0 +1,138 @@
+package statement
+
+import (
+	"SimpleBank/util"
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Format 是对账单导出的文件格式
+type Format string
+
+// 定义所有支持的导出格式
+const (
+	CSV Format = "csv"
+	OFX Format = "ofx"
+	PDF Format = "pdf"
+)
+
+// Statement 包含对账单的账户信息和期初、期末余额
+type Statement struct {
+	AccountID      int64
+	Owner          string
+	Currency       string
+	StartTime      time.Time
+	EndTime        time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+}
+
+// Line 是对账单中的一条条目
+type Line struct {
+	EntryID    int64
+	TransferID *int64
+	// 冲正交易产生的补偿条目所属的冲正记录，其他条目为 nil
+	ReversalID *int64
+	// 交易的对方账户，不是由交易产生的条目为 nil
+	CounterpartyAccountID *int64
+	Amount                int64
+	// 该条目入账之后的账户余额，由 Export 从期初余额开始逐条累加得到，LineSource 不需要填写
+	RunningBalance int64
+	CreatedAt      time.Time
+}
+
+// Encoder 将对账单逐条写入到指定格式的输出中，不会在内存中保存所有的条目
+type Encoder interface {
+	// Begin 写入对账单的开头部分，必须在写入条目之前调用一次
+	Begin(stmt Statement) error
+	// WriteLine 写入一条条目
+	WriteLine(line Line) error
+	// End 写入对账单的结尾部分，并将缓冲的数据全部写入输出
+	End() error
+}
+
+// NewEncoder 根据指定的格式创建一个写入 w 的 Encoder
+func NewEncoder(format Format, w io.Writer) (Encoder, error) {
+	switch format {
+	case CSV:
+		return newCSVEncoder(w), nil
+	case OFX:
+		return newOFXEncoder(w), nil
+	case PDF:
+		return newPDFEncoder(w), nil
+	}
+	return nil, fmt.Errorf("unsupported statement format %q", format)
+}
+
+// IsSupportedFormat 检查是否支持指定的导出格式
+func IsSupportedFormat(format string) bool {
+	switch Format(format) {
+	case CSV, OFX, PDF:
+		return true
+	}
+	return false
+}
+
+// ContentType 返回导出格式对应的 MIME 类型
+func (format Format) ContentType() string {
+	switch format {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case OFX:
+		return "application/x-ofx"
+	case PDF:
+		return "application/pdf"
+	}
+	return "application/octet-stream"
+}
+
+// LineSource 从 afterID 之后分批读取最多 limit 条条目，返回空切片时表示已经读取完毕
+type LineSource func(ctx context.Context, afterID int64, limit int32) ([]Line, error)
+
+// Export 从 source 中分批读取条目并写入 enc ，内存中最多只保存 batchSize 条条目
+// 每条条目入账之后的余额从期初余额开始逐条累加，跨批次延续，不需要每一批都重新查询
+func Export(ctx context.Context, enc Encoder, stmt Statement, source LineSource, batchSize int32) error {
+	if err := enc.Begin(stmt); err != nil {
+		return err
+	}
+
+	var afterID int64
+	balance := stmt.OpeningBalance
+	for {
+		lines, err := source(ctx, afterID, batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			balance += line.Amount
+			line.RunningBalance = balance
+			if err := enc.WriteLine(line); err != nil {
+				return err
+			}
+		}
+
+		// 最后一批不满 batchSize 时说明已经读取完毕
+		if len(lines) < int(batchSize) {
+			break
+		}
+		afterID = lines[len(lines)-1].EntryID
+	}
+
+	return enc.End()
+}
+
+// formatAmount 将以最小货币单位表示的金额按照对账单的货币格式化为十进制字符串，例如美元的 -1234 格式化为 -12.34
+func (stmt Statement) formatAmount(amount int64) string {
+	return util.NewMoney(amount, stmt.Currency).Decimal()
+}
+
+// formatOptionalID 格式化可能为空的 ID ，为空时返回空字符串
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return fmt.Sprint(*id)
+}
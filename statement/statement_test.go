@@ -0,0 +1,217 @@
+package statement
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomStatement(n int) (Statement, []Line) {
+	stmt := Statement{
+		AccountID:      1,
+		Owner:          "alice",
+		Currency:       "USD",
+		StartTime:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:        time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+		OpeningBalance: 10000,
+	}
+
+	// 条目中不填写入账之后的余额，由 Export 从期初余额开始累加
+	balance := stmt.OpeningBalance
+	lines := make([]Line, n)
+	for i := range lines {
+		transferID := int64(100 + i)
+		counterparty := int64(2)
+		amount := int64(-150)
+		if i%2 == 1 {
+			amount = 275
+		}
+		balance += amount
+		lines[i] = Line{
+			EntryID:               int64(i + 1),
+			TransferID:            &transferID,
+			CounterpartyAccountID: &counterparty,
+			Amount:                amount,
+			CreatedAt:             stmt.StartTime.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	stmt.ClosingBalance = balance
+	return stmt, lines
+}
+
+// sliceSource 返回一个从内存切片中分批读取条目的 LineSource ，并记录每次读取的条数
+func sliceSource(lines []Line, batches *[]int) LineSource {
+	return func(ctx context.Context, afterID int64, limit int32) ([]Line, error) {
+		var batch []Line
+		for _, line := range lines {
+			if line.EntryID > afterID && len(batch) < int(limit) {
+				batch = append(batch, line)
+			}
+		}
+		*batches = append(*batches, len(batch))
+		return batch, nil
+	}
+}
+
+func TestExportBatches(t *testing.T) {
+	stmt, lines := randomStatement(25)
+
+	var batches []int
+	var buf bytes.Buffer
+	enc, err := NewEncoder(CSV, &buf)
+	require.NoError(t, err)
+
+	err = Export(context.Background(), enc, stmt, sliceSource(lines, &batches), 10)
+	require.NoError(t, err)
+	require.Equal(t, []int{10, 10, 5}, batches)
+}
+
+func TestExportRunningBalance(t *testing.T) {
+	stmt, lines := randomStatement(25)
+
+	var batches []int
+	var buf bytes.Buffer
+	enc, err := NewEncoder(CSV, &buf)
+	require.NoError(t, err)
+	require.NoError(t, Export(context.Background(), enc, stmt, sliceSource(lines, &batches), 10))
+
+	reader := csv.NewReader(&buf)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	// 入账之后的余额跨批次延续，最后一条条目之后的余额等于期末余额
+	balance := stmt.OpeningBalance
+	for i, line := range lines {
+		balance += line.Amount
+		record := records[6+i]
+		require.Equal(t, fmt.Sprint(line.EntryID), record[0])
+		require.Equal(t, stmt.formatAmount(balance), record[5])
+	}
+	require.Equal(t, stmt.ClosingBalance, balance)
+}
+
+func TestExportSourceError(t *testing.T) {
+	stmt, _ := randomStatement(0)
+	enc, err := NewEncoder(OFX, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	source := func(ctx context.Context, afterID int64, limit int32) ([]Line, error) {
+		return nil, fmt.Errorf("connection lost")
+	}
+	err = Export(context.Background(), enc, stmt, source, 10)
+	require.EqualError(t, err, "connection lost")
+}
+
+func TestNewEncoderUnsupportedFormat(t *testing.T) {
+	_, err := NewEncoder(Format("xls"), &bytes.Buffer{})
+	require.Error(t, err)
+	require.False(t, IsSupportedFormat("xls"))
+	require.True(t, IsSupportedFormat("pdf"))
+}
+
+func TestCSVEncoder(t *testing.T) {
+	stmt, lines := randomStatement(3)
+
+	var batches []int
+	var buf bytes.Buffer
+	enc, err := NewEncoder(CSV, &buf)
+	require.NoError(t, err)
+	require.NoError(t, Export(context.Background(), enc, stmt, sliceSource(lines, &batches), 100))
+
+	reader := csv.NewReader(&buf)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"account_id", "1"}, records[0])
+	require.Equal(t, []string{"opening_balance", "100.00"}, records[4])
+	require.Equal(t, []string{"entry_id", "created_at", "transfer_id", "counterparty_account_id", "amount", "balance"}, records[5])
+	require.Equal(t, []string{"1", "2023-01-01T00:00:00Z", "100", "2", "-1.50", "98.50"}, records[6])
+	require.Equal(t, []string{"2", "2023-01-01T00:01:00Z", "101", "2", "2.75", "101.25"}, records[7])
+	require.Equal(t, []string{"closing_balance", stmt.formatAmount(stmt.ClosingBalance)}, records[len(records)-1])
+}
+
+func TestOFXEncoder(t *testing.T) {
+	stmt, lines := randomStatement(2)
+
+	var batches []int
+	var buf bytes.Buffer
+	enc, err := NewEncoder(OFX, &buf)
+	require.NoError(t, err)
+	require.NoError(t, Export(context.Background(), enc, stmt, sliceSource(lines, &batches), 100))
+
+	ofx := buf.String()
+	require.True(t, strings.HasPrefix(ofx, "<?xml"))
+	require.Contains(t, ofx, "<CURDEF>USD</CURDEF>")
+	require.Contains(t, ofx, "<TRNTYPE>DEBIT</TRNTYPE>")
+	require.Contains(t, ofx, "<TRNTYPE>CREDIT</TRNTYPE>")
+	require.Contains(t, ofx, "<TRNAMT>-1.50</TRNAMT>")
+	require.Contains(t, ofx, "<FITID>1</FITID>")
+	require.Contains(t, ofx, "<MEMO>Transfer 100</MEMO>")
+	require.Contains(t, ofx, "<BALAMT>101.25</BALAMT>")
+	require.Contains(t, ofx, "<VALUE>100.00</VALUE>")
+	require.True(t, strings.HasSuffix(strings.TrimSpace(ofx), "</OFX>"))
+}
+
+func TestOFXEncoderReversal(t *testing.T) {
+	stmt, lines := randomStatement(1)
+	reversalID := int64(7)
+	lines[0].ReversalID = &reversalID
+
+	var batches []int
+	var buf bytes.Buffer
+	enc, err := NewEncoder(OFX, &buf)
+	require.NoError(t, err)
+	require.NoError(t, Export(context.Background(), enc, stmt, sliceSource(lines, &batches), 100))
+
+	// 冲正产生的条目与普通交易的条目可以区分
+	require.Contains(t, buf.String(), "<MEMO>Reversal of transfer 100</MEMO>")
+}
+
+func TestPDFEncoder(t *testing.T) {
+	// 条目数超过一页，检查分页
+	stmt, lines := randomStatement(pdfLinesPerPage + 10)
+
+	var batches []int
+	var buf bytes.Buffer
+	enc, err := NewEncoder(PDF, &buf)
+	require.NoError(t, err)
+	require.NoError(t, Export(context.Background(), enc, stmt, sliceSource(lines, &batches), 50))
+
+	pdf := buf.String()
+	require.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	require.Contains(t, pdf, "/Count 2")
+	require.Contains(t, pdf, "(Opening balance: 100.00) Tj")
+	require.Contains(t, pdf, fmt.Sprintf("(Closing balance: %s) Tj", stmt.formatAmount(stmt.ClosingBalance)))
+
+	// startxref 指向的位置必须是交叉引用表
+	index := strings.LastIndex(pdf, "startxref\n")
+	var offset int
+	_, err = fmt.Sscanf(pdf[index+len("startxref\n"):], "%d", &offset)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(pdf[offset:], "xref\n"))
+
+	// 交叉引用表中的每个偏移量都必须指向对应的对象
+	var first, count int
+	_, err = fmt.Sscanf(pdf[offset:], "xref\n%d %d\n", &first, &count)
+	require.NoError(t, err)
+	entries := strings.Split(pdf[offset:], "\n")[3 : 3+count-1]
+	for i, entry := range entries {
+		var objectOffset int
+		_, err = fmt.Sscanf(entry, "%010d", &objectOffset)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(pdf[objectOffset:], fmt.Sprintf("%d 0 obj\n", i+1)))
+	}
+}
+
+func TestEscapePDFText(t *testing.T) {
+	require.Equal(t, `a\(b\)\\c`, escapePDFText(`a(b)\c`))
+}
@@ -0,0 +1,12 @@
+package token
+
+import "time"
+
+// Maker 是管理 token 的接口，便于在 JWT 和 PASETO 等不同实现之间切换
+type Maker interface {
+	// CreateToken 为指定的用户名和角色创建一个指定类型和有效期的 token ，同时返回 token 中存储的 payload
+	CreateToken(username string, role string, tokenType TokenType, duration time.Duration) (string, *Payload, error)
+
+	// VerifyToken 检验输入的 token 是否有效，若有效则返回 token 中存储的 payload
+	VerifyToken(token string) (*Payload, error)
+}
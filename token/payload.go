@@ -0,0 +1,63 @@
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 定义 token 验证时可能返回的错误
+var (
+	ErrInvalidToken = errors.New("token is invalid")
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// TokenType 区分访问令牌和刷新令牌，两种令牌不能互相替代
+type TokenType string
+
+// 定义 token 的所有类型
+const (
+	// 访问令牌，用于访问需要认证的 API
+	TokenTypeAccess TokenType = "access"
+	// 刷新令牌，只能用于获取新的访问令牌
+	TokenTypeRefresh TokenType = "refresh"
+)
+
+// Payload 包含 token 的负载数据
+type Payload struct {
+	// 每个 token 唯一的标识，便于之后进行 token 的作废
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	TokenType TokenType `json:"token_type"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// NewPayload 根据指定的用户名、角色、令牌类型和有效期创建一个新的 payload
+func NewPayload(username string, role string, tokenType TokenType, duration time.Duration) (*Payload, error) {
+	// 随机生成一个 UUID 作为 token 的 ID
+	tokenID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &Payload{
+		ID:        tokenID,
+		Username:  username,
+		Role:      role,
+		TokenType: tokenType,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(duration),
+	}
+	return payload, nil
+}
+
+// Valid 检验 payload 是否已经过期，同时实现了 jwt.Claims 接口
+func (payload *Payload) Valid() error {
+	if time.Now().After(payload.ExpiredAt) {
+		return ErrExpiredToken
+	}
+	return nil
+}
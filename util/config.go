@@ -1,11 +1,34 @@
 package util
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 // Config 保存所有应用的配置，里面的值是通过 viper 从配置文件或者环境变量中读取出来的
 type Config struct {
-	DBSource      string `mapstructure:"DB_SOURCE"`
-	ServerAddress string `mapstructure:"SERVER_ADDRESS"`
+	DBSource                      string        `mapstructure:"DB_SOURCE"`
+	ServerAddress                 string        `mapstructure:"SERVER_ADDRESS"`
+	GRPCServerAddress             string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	TokenSymmetricKey             string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration           time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration          time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	IdempotencyKeyTTL             time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+	IdempotencyKeyCleanupInterval time.Duration `mapstructure:"IDEMPOTENCY_KEY_CLEANUP_INTERVAL"`
+	ExchangeRatesFile             string        `mapstructure:"EXCHANGE_RATES_FILE"`
+	ExchangeQuoteTTL              time.Duration `mapstructure:"EXCHANGE_QUOTE_TTL"`
+	ScheduledTransferInterval     time.Duration `mapstructure:"SCHEDULED_TRANSFER_INTERVAL"`
+	TaskProcessorInterval         time.Duration `mapstructure:"TASK_PROCESSOR_INTERVAL"`
+	EmailSenderName               string        `mapstructure:"EMAIL_SENDER_NAME"`
+	EmailSenderAddress            string        `mapstructure:"EMAIL_SENDER_ADDRESS"`
+	EmailSenderPassword           string        `mapstructure:"EMAIL_SENDER_PASSWORD"`
+	SMTPServerAddress             string        `mapstructure:"SMTP_SERVER_ADDRESS"`
+	VerifyEmailURL                string        `mapstructure:"VERIFY_EMAIL_URL"`
+	UnverifiedTransferLimit       int64         `mapstructure:"UNVERIFIED_TRANSFER_LIMIT"`
+	ReconciliationInterval        time.Duration `mapstructure:"RECONCILIATION_INTERVAL"`
+	HealthCheckFailOnDrift        bool          `mapstructure:"HEALTH_CHECK_FAIL_ON_DRIFT"`
+	AuditLogSealInterval          time.Duration `mapstructure:"AUDIT_LOG_SEAL_INTERVAL"`
 }
 
 // LoadConfig 从指定的路径内的配置文件或者环境变量读取配置
@@ -1,5 +1,10 @@
 package util
 
+import (
+	"fmt"
+	"strings"
+)
+
 // 定义所有支持的货币类型
 const (
 	USD = "USD"
@@ -15,3 +20,97 @@ func IsSupportedCurrency(currency string) bool {
 	}
 	return false
 }
+
+// Currency 描述一种 ISO 4217 货币，金额在数据库中以最小货币单位的整数保存
+// Exponent 是最小货币单位的小数位数，例如美元为 2 （1 美元 = 100 美分），日元为 0
+type Currency struct {
+	Code     string
+	Exponent int
+}
+
+// currencies 记录了 ISO 4217 货币的小数位数，除了支持开户的货币之外，也包含汇率表中可能出现的常见货币
+var currencies = map[string]Currency{
+	USD:   {Code: USD, Exponent: 2},
+	EUR:   {Code: EUR, Exponent: 2},
+	CAD:   {Code: CAD, Exponent: 2},
+	"GBP": {Code: "GBP", Exponent: 2},
+	"CHF": {Code: "CHF", Exponent: 2},
+	"CNY": {Code: "CNY", Exponent: 2},
+	"AUD": {Code: "AUD", Exponent: 2},
+	"JPY": {Code: "JPY", Exponent: 0},
+	"KRW": {Code: "KRW", Exponent: 0},
+	"BHD": {Code: "BHD", Exponent: 3},
+	"KWD": {Code: "KWD", Exponent: 3},
+}
+
+// LookupCurrency 返回货币代码对应的 Currency ，未知的货币代码返回错误
+func LookupCurrency(code string) (Currency, error) {
+	currency, ok := currencies[code]
+	if !ok {
+		return Currency{}, fmt.Errorf("unknown currency %q", code)
+	}
+	return currency, nil
+}
+
+// Format 将以最小货币单位表示的金额格式化为十进制字符串，例如美元的 -1234 格式化为 "-12.34"
+func (currency Currency) Format(amount int64) string {
+	sign := ""
+	// 使用 uint64 避免最小的 int64 取反时溢出
+	abs := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		abs = -abs
+	}
+	if currency.Exponent == 0 {
+		return fmt.Sprintf("%s%d", sign, abs)
+	}
+
+	unit := uint64(1)
+	for i := 0; i < currency.Exponent; i++ {
+		unit *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, abs/unit, currency.Exponent, abs%unit)
+}
+
+// Parse 将十进制字符串解析为以最小货币单位表示的金额，例如美元的 "12.3" 解析为 1230
+// 小数位数不能超过货币的小数位数，也不支持科学计数法和千位分隔符，避免静默地舍入金额
+func (currency Currency) Parse(value string) (int64, error) {
+	digits := strings.TrimPrefix(value, "-")
+	negative := len(digits) < len(value)
+
+	whole, fraction, hasPoint := strings.Cut(digits, ".")
+	if whole == "" || (hasPoint && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("invalid %s amount %q", currency.Code, value)
+	}
+	if len(fraction) > currency.Exponent {
+		return 0, fmt.Errorf("invalid %s amount %q: at most %d decimal places", currency.Code, value, currency.Exponent)
+	}
+	// 补齐小数位数之后，整数部分和小数部分拼接起来就是最小货币单位的金额
+	minor := whole + fraction + strings.Repeat("0", currency.Exponent-len(fraction))
+
+	var amount uint64
+	for _, digit := range minor {
+		if amount > (1<<63-1)/10 {
+			return 0, fmt.Errorf("%s amount %q is out of range", currency.Code, value)
+		}
+		amount = amount*10 + uint64(digit-'0')
+	}
+	if amount > 1<<63-1 {
+		return 0, fmt.Errorf("%s amount %q is out of range", currency.Code, value)
+	}
+
+	if negative {
+		return -int64(amount), nil
+	}
+	return int64(amount), nil
+}
+
+// isDigits 检查字符串是否只包含 0-9 的数字，空字符串返回 true
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
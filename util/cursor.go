@@ -0,0 +1,40 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor 表示客户端传入的游标无法解析
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageCursor 是游标分页中记录上一页最后一条记录位置的游标，对客户端来说是不透明的
+type PageCursor struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EncodeCursor 将游标编码为 URL 安全的 base64 字符串
+func EncodeCursor(cursor PageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor 解析客户端传入的游标，空字符串表示从第一页开始
+func DecodeCursor(encoded string) (PageCursor, error) {
+	var cursor PageCursor
+	if encoded == "" {
+		return cursor, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return cursor, ErrInvalidCursor
+	}
+	return cursor, nil
+}
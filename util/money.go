@@ -0,0 +1,84 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCurrencyMismatch 表示对两个不同货币的金额进行了运算
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// ErrAmountOverflow 表示金额运算的结果超出了 int64 的范围
+var ErrAmountOverflow = errors.New("amount overflow")
+
+// Money 表示一个带有货币类型的金额，Amount 以最小货币单位表示，例如 12.34 美元的 Amount 为 1234
+// 接口中的金额使用 pb.Money 表示为十进制字符串，由 ParseMoney 和 Decimal 与 Money 相互转换
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// NewMoney 使用以最小货币单位表示的金额创建一个 Money
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney 将十进制字符串解析为指定货币的 Money ，例如 ParseMoney("12.34", "USD") 的 Amount 为 1234
+func ParseMoney(value string, currency string) (Money, error) {
+	c, err := LookupCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	amount, err := c.Parse(value)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(amount, currency), nil
+}
+
+// Decimal 返回金额的十进制字符串，例如 "12.34" ，未知的货币按照最小货币单位的整数格式化
+func (m Money) Decimal() string {
+	c, err := LookupCurrency(m.Currency)
+	if err != nil {
+		return fmt.Sprint(m.Amount)
+	}
+	return c.Format(m.Amount)
+}
+
+// String 返回带有货币代码的金额，例如 "12.34 USD"
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// IsPositive 检查金额是否大于 0
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Add 返回两个金额的和，两个金额的货币类型必须相同
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	sum := m.Amount + other.Amount
+	// 两个同号的数相加之后符号改变说明发生了溢出
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrAmountOverflow, m, other)
+	}
+	return NewMoney(sum, m.Currency), nil
+}
+
+// Sub 返回两个金额的差，两个金额的货币类型必须相同
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+
+	diff := m.Amount - other.Amount
+	if (other.Amount > 0 && diff > m.Amount) || (other.Amount < 0 && diff < m.Amount) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrAmountOverflow, m, other)
+	}
+	return NewMoney(diff, m.Currency), nil
+}
@@ -0,0 +1,90 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		currency string
+		expected int64
+	}{
+		{name: "TwoDecimals", value: "12.34", currency: USD, expected: 1234},
+		{name: "OneDecimal", value: "12.3", currency: EUR, expected: 1230},
+		{name: "Integer", value: "12", currency: CAD, expected: 1200},
+		{name: "Negative", value: "-0.05", currency: USD, expected: -5},
+		{name: "ZeroExponent", value: "1500", currency: "JPY", expected: 1500},
+		{name: "ThreeDecimals", value: "1.005", currency: "KWD", expected: 1005},
+		{name: "MaxInt64", value: "92233720368547758.07", currency: USD, expected: math.MaxInt64},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			money, err := ParseMoney(tc.value, tc.currency)
+			require.NoError(t, err)
+			require.Equal(t, NewMoney(tc.expected, tc.currency), money)
+		})
+	}
+}
+
+func TestParseInvalidMoney(t *testing.T) {
+	testCases := []struct {
+		value    string
+		currency string
+	}{
+		{value: "", currency: USD},
+		{value: "abc", currency: USD},
+		{value: "1.", currency: USD},
+		{value: ".5", currency: USD},
+		{value: "+1", currency: USD},
+		{value: "1e2", currency: USD},
+		{value: "1,000", currency: USD},
+		{value: "1.005", currency: USD},
+		{value: "1.5", currency: "JPY"},
+		{value: "92233720368547758.08", currency: USD},
+		{value: "1.00", currency: "XYZ"},
+	}
+
+	for _, tc := range testCases {
+		_, err := ParseMoney(tc.value, tc.currency)
+		require.Error(t, err, "%s %s", tc.value, tc.currency)
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	require.Equal(t, "12.34", NewMoney(1234, USD).Decimal())
+	require.Equal(t, "-0.05", NewMoney(-5, EUR).Decimal())
+	require.Equal(t, "0.00", NewMoney(0, CAD).Decimal())
+	require.Equal(t, "1500", NewMoney(1500, "JPY").Decimal())
+	require.Equal(t, "1.005", NewMoney(1005, "BHD").Decimal())
+	require.Equal(t, "-92233720368547758.08", NewMoney(math.MinInt64, USD).Decimal())
+	require.Equal(t, "12.34 USD", NewMoney(1234, USD).String())
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	sum, err := NewMoney(1234, USD).Add(NewMoney(66, USD))
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(1300, USD), sum)
+
+	diff, err := NewMoney(1234, USD).Sub(NewMoney(2000, USD))
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(-766, USD), diff)
+
+	// 不同货币的金额不能直接运算
+	_, err = NewMoney(1234, USD).Add(NewMoney(1, EUR))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+	_, err = NewMoney(1234, USD).Sub(NewMoney(1, CAD))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = NewMoney(math.MaxInt64, USD).Add(NewMoney(1, USD))
+	require.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = NewMoney(math.MinInt64, USD).Sub(NewMoney(1, USD))
+	require.ErrorIs(t, err, ErrAmountOverflow)
+}
@@ -0,0 +1,16 @@
+package util
+
+// 定义所有的用户角色，depositor 为银行的客户，banker 为银行的职员
+const (
+	DepositorRole = "depositor"
+	BankerRole    = "banker"
+)
+
+// IsSupportedRole 对输入的 role 进行验证，若为支持的角色，返回 true ，否则返回 false
+func IsSupportedRole(role string) bool {
+	switch role {
+	case DepositorRole, BankerRole:
+		return true
+	}
+	return false
+}
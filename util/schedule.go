@@ -0,0 +1,32 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// MinScheduleInterval 是计划交易两次执行之间允许的最短间隔
+const MinScheduleInterval = time.Minute
+
+// Schedule 表示计划交易的执行计划
+type Schedule interface {
+	// Next 返回晚于 t 的下一次执行时间
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule 解析计划交易的执行计划，支持标准的 5 字段 cron 表达式（例如 "0 9 1 * *"）、
+// @daily 和 @monthly 等描述符，以及 "@every 24h" 形式的固定间隔，可以使用 "CRON_TZ=Asia/Shanghai" 前缀指定时区
+func ParseSchedule(spec string) (Schedule, error) {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+
+	// cron 表达式的最小单位为分钟，只需要检查固定间隔
+	if every, ok := schedule.(cron.ConstantDelaySchedule); ok && every.Delay < MinScheduleInterval {
+		return nil, fmt.Errorf("invalid schedule %q: interval must be at least %s", spec, MinScheduleInterval)
+	}
+	return schedule, nil
+}
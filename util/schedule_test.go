@@ -0,0 +1,52 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		spec     string
+		expected time.Time
+	}{
+		{
+			name:     "Every",
+			spec:     "@every 24h",
+			expected: now.Add(24 * time.Hour),
+		},
+		{
+			// 每月 1 日 9 点执行
+			name:     "Cron",
+			spec:     "CRON_TZ=UTC 0 9 1 * *",
+			expected: time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Descriptor",
+			spec:     "CRON_TZ=UTC @daily",
+			expected: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.spec)
+			require.NoError(t, err)
+			require.True(t, tc.expected.Equal(schedule.Next(now)), "got %s", schedule.Next(now))
+		})
+	}
+}
+
+func TestParseInvalidSchedule(t *testing.T) {
+	for _, spec := range []string{"", "not a schedule", "* * *", "@every 30s"} {
+		_, err := ParseSchedule(spec)
+		require.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	db "SimpleBank/db/sqlc"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgtype"
+)
+
+// TaskDistributor 将异步任务放入队列
+// 每个方法都接收一个 db.Querier ，传入事务的查询对象时，任务会和事务中的其他操作一起提交或者回滚
+type TaskDistributor interface {
+	DistributeTaskSendVerifyEmail(ctx context.Context, q db.Querier, payload *PayloadSendVerifyEmail) error
+}
+
+// PostgresTaskDistributor 将任务保存在 Postgres 的 tasks 表中，不需要额外的消息队列
+type PostgresTaskDistributor struct{}
+
+// NewPostgresTaskDistributor 创建一个 PostgresTaskDistributor
+func NewPostgresTaskDistributor() TaskDistributor {
+	return &PostgresTaskDistributor{}
+}
+
+// distribute 将任务的载荷编码为 JSON 并插入 tasks 表，任务会被立即处理
+func (distributor *PostgresTaskDistributor) distribute(
+	ctx context.Context,
+	q db.Querier,
+	taskType string,
+	payload interface{},
+	maxAttempts int32,
+) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task payload: %w", err)
+	}
+
+	_, err = q.CreateTask(ctx, db.CreateTaskParams{
+		Type:        taskType,
+		Payload:     pgtype.JSONB{Bytes: data, Status: pgtype.Present},
+		MaxAttempts: maxAttempts,
+		RunAt:       time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task %s: %w", taskType, err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package worker
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/mail"
+	"SimpleBank/util"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 任务处理失败之后的重试间隔从 minRetryDelay 开始每次翻倍，最长为 maxRetryDelay
+const (
+	minRetryDelay = 10 * time.Second
+	maxRetryDelay = time.Hour
+)
+
+// 处理一个任务的租约时长，超过该时长仍未处理完成的任务会被取消，并由其他处理器重新领取
+const taskLease = 5 * time.Minute
+
+// TaskProcessor 从队列中取出到期的任务并进行处理
+type TaskProcessor interface {
+	// Start 每隔一段时间处理一次所有到期的任务，直到 ctx 被取消
+	Start(ctx context.Context)
+}
+
+// taskHandler 处理一种类型的任务，payload 为任务的 JSON 载荷
+type taskHandler func(ctx context.Context, payload []byte) error
+
+// PostgresTaskProcessor 处理 PostgresTaskDistributor 放入 tasks 表中的任务
+// 任务在处理之前会被标记为处理中，其他处理器在租约到期之前会跳过该任务，因此多个服务器实例可以同时运行处理器
+type PostgresTaskProcessor struct {
+	config   util.Config
+	store    db.Store
+	mailer   mail.EmailSender
+	interval time.Duration
+	handlers map[string]taskHandler
+	// 返回当前时间，便于测试时固定时间
+	now func() time.Time
+}
+
+// NewPostgresTaskProcessor 创建一个每隔 config.TaskProcessorInterval 检查一次到期任务的 PostgresTaskProcessor
+func NewPostgresTaskProcessor(config util.Config, store db.Store, mailer mail.EmailSender) *PostgresTaskProcessor {
+	processor := &PostgresTaskProcessor{
+		config:   config,
+		store:    store,
+		mailer:   mailer,
+		interval: config.TaskProcessorInterval,
+		now:      time.Now,
+	}
+	processor.handlers = map[string]taskHandler{
+		TaskSendVerifyEmail: processor.ProcessTaskSendVerifyEmail,
+	}
+	return processor
+}
+
+// Start 每隔 interval 处理一次所有到期的任务，直到 ctx 被取消，interval 不大于 0 时不处理
+func (processor *PostgresTaskProcessor) Start(ctx context.Context) {
+	if processor.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(processor.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := processor.ProcessDue(ctx); err != nil {
+				log.Println("cannot process tasks:", err)
+			}
+		}
+	}
+}
+
+// ProcessDue 依次处理所有在当前时间之前到期的任务，返回处理的数量（包括处理失败的任务）
+func (processor *PostgresTaskProcessor) ProcessDue(ctx context.Context) (int, error) {
+	// 处理失败的任务会在 now 之后重试，因此循环一定会结束
+	now := processor.now()
+
+	processed := 0
+	for {
+		task, err := processor.store.ProcessDueTask(ctx, db.ProcessDueTaskParams{
+			Now:        now,
+			Lease:      taskLease,
+			Handle:     processor.handle,
+			RetryDelay: retryDelay,
+		})
+		if err != nil {
+			// 没有到期的任务，或者剩下的都已经被其他处理器领取
+			if errors.Is(err, pgx.ErrNoRows) {
+				return processed, nil
+			}
+			// 处理超时，任务已经被其他处理器重新领取，继续处理下一个任务
+			if errors.Is(err, db.ErrTaskLeaseExpired) {
+				log.Println("cannot record task result:", err)
+				processed++
+				continue
+			}
+			return processed, err
+		}
+		processed++
+
+		switch task.Status {
+		case db.TaskStatusPending:
+			log.Printf("task [%d] %s failed, will retry at %s: %s", task.ID, task.Type, task.RunAt.Format(time.RFC3339), task.LastError)
+		case db.TaskStatusDead:
+			log.Printf("task [%d] %s failed %d times, moved to dead letter: %s", task.ID, task.Type, task.Attempts, task.LastError)
+		}
+	}
+}
+
+// handle 根据任务的类型选择对应的处理函数
+func (processor *PostgresTaskProcessor) handle(ctx context.Context, task db.Task) error {
+	handler, ok := processor.handlers[task.Type]
+	if !ok {
+		return fmt.Errorf("unknown task type %s", task.Type)
+	}
+	return handler(ctx, task.Payload.Bytes)
+}
+
+// retryDelay 返回任务第 attempts 次处理失败之后到下一次重试之间的等待时间
+func retryDelay(attempts int32) time.Duration {
+	delay := minRetryDelay
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
@@ -0,0 +1,263 @@
+package worker
+
+import (
+	mockdb "SimpleBank/db/mock"
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/mail"
+	"SimpleBank/util"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProcessor 创建一个用于测试的 PostgresTaskProcessor
+func newTestProcessor(store db.Store, mailer mail.EmailSender) *PostgresTaskProcessor {
+	config := util.Config{
+		TaskProcessorInterval: time.Minute,
+		VerifyEmailURL:        "http://localhost:8080/verify_email",
+	}
+	return NewPostgresTaskProcessor(config, store, mailer)
+}
+
+func TestProcessDue(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		processed  int
+		hasError   bool
+	}{
+		{
+			// 处理所有到期的任务，包括处理失败的任务，直到没有到期的任务
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().ProcessDueTask(gomock.Any(), gomock.Any()).
+						Return(db.Task{ID: 1, Status: db.TaskStatusSucceeded}, nil),
+					store.EXPECT().ProcessDueTask(gomock.Any(), gomock.Any()).
+						Return(db.Task{ID: 2, Status: db.TaskStatusPending}, nil),
+					store.EXPECT().ProcessDueTask(gomock.Any(), gomock.Any()).
+						Return(db.Task{ID: 3, Status: db.TaskStatusDead}, nil),
+					store.EXPECT().ProcessDueTask(gomock.Any(), gomock.Any()).
+						Return(db.Task{}, pgx.ErrNoRows),
+				)
+			},
+			processed: 3,
+		},
+		{
+			name: "NothingDue",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ProcessDueTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Task{}, pgx.ErrNoRows)
+			},
+			processed: 0,
+		},
+		{
+			// 处理超时之后任务被其他处理器重新领取，继续处理下一个任务
+			name: "LeaseExpired",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().ProcessDueTask(gomock.Any(), gomock.Any()).
+						Return(db.Task{}, db.ErrTaskLeaseExpired),
+					store.EXPECT().ProcessDueTask(gomock.Any(), gomock.Any()).
+						Return(db.Task{}, pgx.ErrNoRows),
+				)
+			},
+			processed: 1,
+		},
+		{
+			// 数据库出错时停止处理，等待下一次轮询
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ProcessDueTask(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Task{}, errors.New("connection refused"))
+			},
+			processed: 0,
+			hasError:  true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			processor := newTestProcessor(store, mail.NewFakeSender())
+			processor.now = func() time.Time { return now }
+
+			processed, err := processor.ProcessDue(context.Background())
+			require.Equal(t, tc.processed, processed)
+			if tc.hasError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHandleTask(t *testing.T) {
+	user := db.User{
+		Username: util.RandomOwner(),
+		FullName: util.RandomOwner(),
+		Email:    util.RandomEmail(),
+	}
+	payload, err := json.Marshal(PayloadSendVerifyEmail{Username: user.Username})
+	require.NoError(t, err)
+
+	sendVerifyEmailTask := db.Task{
+		Type:    TaskSendVerifyEmail,
+		Payload: pgtype.JSONB{Bytes: payload, Status: pgtype.Present},
+	}
+
+	testCases := []struct {
+		name          string
+		task          db.Task
+		sendErr       error
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, err error, sent []mail.Email)
+	}{
+		{
+			name: "SendVerifyEmail",
+			task: sendVerifyEmailTask,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().CreateVerifyEmail(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreateVerifyEmailParams) (db.VerifyEmail, error) {
+						require.Equal(t, user.Username, arg.Username)
+						require.Equal(t, user.Email, arg.Email)
+						require.Len(t, arg.SecretCode, 2*verifyEmailSecretBytes)
+						return db.VerifyEmail{ID: 1, Username: arg.Username, Email: arg.Email, SecretCode: arg.SecretCode}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, err error, sent []mail.Email) {
+				require.NoError(t, err)
+				require.Len(t, sent, 1)
+				require.Equal(t, []string{user.Email}, sent[0].To)
+				require.Contains(t, sent[0].Content, "http://localhost:8080/verify_email?code=")
+				require.Contains(t, sent[0].Content, "&amp;id=1")
+			},
+		},
+		{
+			// 邮箱已经验证过时不再发送验证邮件
+			name: "AlreadyVerified",
+			task: sendVerifyEmailTask,
+			buildStubs: func(store *mockdb.MockStore) {
+				verified := user
+				verified.IsEmailVerified = true
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(verified, nil)
+				store.EXPECT().CreateVerifyEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, err error, sent []mail.Email) {
+				require.NoError(t, err)
+				require.Empty(t, sent)
+			},
+		},
+		{
+			// 发送失败时任务处理失败，稍后会重试
+			name:    "SendEmailError",
+			task:    sendVerifyEmailTask,
+			sendErr: errors.New("smtp unavailable"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().CreateVerifyEmail(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.VerifyEmail{ID: 1, Username: user.Username, Email: user.Email}, nil)
+			},
+			checkResponse: func(t *testing.T, err error, sent []mail.Email) {
+				require.Error(t, err)
+				require.Empty(t, sent)
+			},
+		},
+		{
+			// 用户不存在时任务处理失败，稍后会重试
+			name: "UserNotFound",
+			task: sendVerifyEmailTask,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.User{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, err error, sent []mail.Email) {
+				require.Error(t, err)
+				require.Empty(t, sent)
+			},
+		},
+		{
+			name: "InvalidPayload",
+			task: db.Task{
+				Type:    TaskSendVerifyEmail,
+				Payload: pgtype.JSONB{Bytes: []byte(`"invalid"`), Status: pgtype.Present},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, err error, sent []mail.Email) {
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "UnknownType",
+			task: db.Task{
+				Type:    "task:unknown",
+				Payload: pgtype.JSONB{Bytes: payload, Status: pgtype.Present},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, err error, sent []mail.Email) {
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			mailer := mail.NewFakeSender()
+			mailer.Err = tc.sendErr
+
+			processor := newTestProcessor(store, mailer)
+			err := processor.handle(context.Background(), tc.task)
+			tc.checkResponse(t, err, mailer.Sent())
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	require.Equal(t, minRetryDelay, retryDelay(1))
+	require.Equal(t, 2*minRetryDelay, retryDelay(2))
+	require.Equal(t, 4*minRetryDelay, retryDelay(3))
+
+	// 重试间隔不会超过 maxRetryDelay
+	require.Equal(t, maxRetryDelay, retryDelay(20))
+}
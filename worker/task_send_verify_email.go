@@ -0,0 +1,90 @@
+package worker
+
+import (
+	db "SimpleBank/db/sqlc"
+	"SimpleBank/util"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strconv"
+)
+
+// TaskSendVerifyEmail 是向新用户发送验证邮件的任务类型
+const TaskSendVerifyEmail = "task:send_verify_email"
+
+// sendVerifyEmailMaxAttempts 是发送验证邮件的最大尝试次数
+const sendVerifyEmailMaxAttempts = 10
+
+// verifyEmailSecretBytes 是邮箱验证码的字节数
+const verifyEmailSecretBytes = 32
+
+// PayloadSendVerifyEmail 是发送验证邮件任务的载荷
+type PayloadSendVerifyEmail struct {
+	Username string `json:"username"`
+}
+
+// DistributeTaskSendVerifyEmail 将发送验证邮件的任务放入队列
+func (distributor *PostgresTaskDistributor) DistributeTaskSendVerifyEmail(
+	ctx context.Context,
+	q db.Querier,
+	payload *PayloadSendVerifyEmail,
+) error {
+	return distributor.distribute(ctx, q, TaskSendVerifyEmail, payload, sendVerifyEmailMaxAttempts)
+}
+
+// ProcessTaskSendVerifyEmail 处理发送验证邮件的任务，为用户当前的邮箱生成一个验证码，并发送包含验证链接的邮件
+// 发送失败重试时会生成新的验证码，之前生成的验证码在过期之前仍然有效
+func (processor *PostgresTaskProcessor) ProcessTaskSendVerifyEmail(ctx context.Context, data []byte) error {
+	var payload PayloadSendVerifyEmail
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+
+	user, err := processor.store.GetUser(ctx, payload.Username)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	// 邮箱已经验证过，不需要再发送
+	if user.IsEmailVerified {
+		return nil
+	}
+
+	secretCode, err := util.RandomSecret(verifyEmailSecretBytes)
+	if err != nil {
+		return fmt.Errorf("failed to generate secret code: %w", err)
+	}
+
+	verifyEmail, err := processor.store.CreateVerifyEmail(ctx, db.CreateVerifyEmailParams{
+		Username:   user.Username,
+		Email:      user.Email,
+		SecretCode: secretCode,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create verify email: %w", err)
+	}
+
+	// 用户的姓名和链接都需要转义之后才能放入 HTML 格式的邮件正文中
+	subject := "欢迎使用 Simple Bank"
+	content := fmt.Sprintf(`%s 您好，<br/>
+感谢您注册 Simple Bank ！<br/>
+请 <a href="%s">点击这里</a> 验证您的邮箱地址。<br/>`,
+		html.EscapeString(user.FullName), html.EscapeString(processor.verifyEmailLink(verifyEmail)))
+	err = processor.mailer.SendEmail(subject, content, []string{verifyEmail.Email}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to send verify email: %w", err)
+	}
+
+	log.Printf("processed task %s for user %s <%s>", TaskSendVerifyEmail, user.Username, user.Email)
+	return nil
+}
+
+// verifyEmailLink 返回用户点击之后验证邮箱的链接
+func (processor *PostgresTaskProcessor) verifyEmailLink(verifyEmail db.VerifyEmail) string {
+	query := url.Values{}
+	query.Set("id", strconv.FormatInt(verifyEmail.ID, 10))
+	query.Set("code", verifyEmail.SecretCode)
+	return processor.config.VerifyEmailURL + "?" + query.Encode()
+}